@@ -87,6 +87,7 @@ var replaceModules = []string{
 	"/extension/extensiontest",
 	"/extension/zpagesextension",
 	"/extension/xextension",
+	"/extension/xextension/storage/storagetest",
 	"/featuregate",
 	"/internal/memorylimiter",
 	"/internal/fanoutconsumer",
@@ -168,6 +168,8 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmid
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
 
 replace go.opentelemetry.io/collector/config/configretry => ../../config/configretry
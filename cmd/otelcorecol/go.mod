@@ -22,6 +22,7 @@ require (
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.135.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.135.0
 	go.opentelemetry.io/collector/extension v1.41.0
+	go.opentelemetry.io/collector/extension/healthextension v0.135.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.135.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.135.0
 	go.opentelemetry.io/collector/otelcol v0.135.0
@@ -273,10 +274,14 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmid
 
 replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
 
+replace go.opentelemetry.io/collector/extension/healthextension => ../../extension/healthextension
+
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
 
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
@@ -40,6 +40,10 @@ type BackOffConfig struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded. If set to 0, the retries are never stopped.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// MaxThrottleDelay is the upper bound on the delay before retrying requested by the destination, for example
+	// using the gRPC `RetryInfo` or the HTTP `Retry-After` header. Longer requested delays are reduced to this value.
+	// If set to 0, the requested delay is never reduced.
+	MaxThrottleDelay time.Duration `mapstructure:"max_throttle_delay"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -63,6 +67,9 @@ func (bs *BackOffConfig) Validate() error {
 	if bs.MaxElapsedTime < 0 {
 		return errors.New("'max_elapsed_time' must be non-negative")
 	}
+	if bs.MaxThrottleDelay < 0 {
+		return errors.New("'max_throttle_delay' must be non-negative")
+	}
 	if bs.MaxElapsedTime > 0 {
 		if bs.MaxElapsedTime < bs.InitialInterval {
 			return errors.New("'max_elapsed_time' must not be less than 'initial_interval'")
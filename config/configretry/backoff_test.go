@@ -81,6 +81,15 @@ func TestInvalidMaxElapsedTime(t *testing.T) {
 	assert.NoError(t, cfg.Validate())
 }
 
+func TestInvalidMaxThrottleDelay(t *testing.T) {
+	cfg := NewDefaultBackOffConfig()
+	require.NoError(t, cfg.Validate())
+	cfg.MaxThrottleDelay = -1
+	require.EqualError(t, cfg.Validate(), "'max_throttle_delay' must be non-negative")
+	cfg.MaxThrottleDelay = 0
+	assert.NoError(t, cfg.Validate())
+}
+
 func TestDisabledWithInvalidValues(t *testing.T) {
 	cfg := BackOffConfig{
 		Enabled:             false,
@@ -89,6 +98,7 @@ func TestDisabledWithInvalidValues(t *testing.T) {
 		Multiplier:          0,
 		MaxInterval:         -1,
 		MaxElapsedTime:      -1,
+		MaxThrottleDelay:    -1,
 	}
 	assert.NoError(t, cfg.Validate())
 }
@@ -49,3 +49,28 @@ type Unmarshaler = internal.Unmarshaler
 // A configuration struct can implement this interface to override the default
 // marshaling.
 type Marshaler = internal.Marshaler
+
+// ListMergeStrategy defines how a list is merged into the list at the same path of the existing configuration.
+type ListMergeStrategy = internal.ListMergeStrategy
+
+const (
+	// ListMergeReplace replaces the existing list, this is the default strategy.
+	ListMergeReplace = internal.ListMergeReplace
+	// ListMergeAppend appends the elements of the list to the existing list.
+	ListMergeAppend = internal.ListMergeAppend
+	// ListMergeAppendUnique appends the elements of the list which are not in the existing list.
+	ListMergeAppendUnique = internal.ListMergeAppendUnique
+	// ListMergeByKey merges the maps of the list into the maps of the existing list having the same value for the
+	// key of the rule, and appends the other elements.
+	ListMergeByKey = internal.ListMergeByKey
+)
+
+// ListMergeRule sets the strategy used to merge the lists at the paths matching a pattern,
+// see ResolverSettings.ListMergeRules.
+type ListMergeRule = internal.ListMergeRule
+
+// ParseListMergeRule parses a rule in the "<path>=<strategy>" format, where the strategy of a merge by key
+// is followed by the key, e.g. "exporters::*::headers=merge-by-key:name".
+func ParseListMergeRule(s string) (ListMergeRule, error) {
+	return internal.ParseListMergeRule(s)
+}
@@ -0,0 +1,432 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap/internal"
+)
+
+const (
+	// ifDirective removes the map holding it if its condition is false.
+	ifDirective = "$if"
+	// forDirective generates the entries of the map, or the elements of the list, holding it from a template.
+	forDirective = "$for"
+	// defaultsDirective sets the default values of the maps which are the other values of the map holding it.
+	defaultsDirective = "$defaults"
+
+	forEachKey   = "each"
+	forAsKey     = "as"
+	forDoKey     = "do"
+	defaultForAs = "item"
+)
+
+var loopVariableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// functions are the functions which can be called like providers in the ${} references when the directives are
+// enabled, e.g. ${lower:${env:NAME}}, with the expanded opaque value as argument.
+var functions = map[string]func(string) (string, error){
+	"lower": func(s string) (string, error) {
+		return strings.ToLower(s), nil
+	},
+	"trim": func(s string) (string, error) {
+		return strings.TrimSpace(s), nil
+	},
+	"base64decode": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+		return string(b), err
+	},
+}
+
+// isFunction returns whether the scheme is a function, only if there is no provider for it.
+func (mr *Resolver) isFunction(scheme string) bool {
+	_, isFunc := functions[scheme]
+	_, isProvider := mr.providers[scheme]
+	return isFunc && !isProvider && internal.EnableDirectives.IsEnabled()
+}
+
+// callFunction returns the result of the function called with the opaque value of the uri, parsed as YAML the same
+// way as the values retrieved by the env provider.
+func (mr *Resolver) callFunction(uri location) (*Retrieved, error) {
+	val, err := functions[uri.scheme](uri.opaqueValue)
+	if err != nil {
+		return nil, fmt.Errorf("cannot call the %q function: %w", uri.scheme, err)
+	}
+	return NewRetrievedFromYAML([]byte(val))
+}
+
+// applyDirectives applies the directives of the configuration, before its ${} references are expanded. Only the
+// values of the directives are expanded, to evaluate them.
+func (mr *Resolver) applyDirectives(ctx context.Context, conf map[string]any) (map[string]any, error) {
+	ret, keep, err := mr.applyMapDirectives(ctx, conf, "")
+	if err != nil || !keep {
+		return map[string]any{}, err
+	}
+	return ret, nil
+}
+
+// applyMapDirectives returns the map with its directives, and the ones of its values, applied, and false if the map
+// must be removed.
+func (mr *Resolver) applyMapDirectives(ctx context.Context, m map[string]any, path string) (map[string]any, bool, error) {
+	// The condition is evaluated first, so the other directives of a removed map are not evaluated.
+	if cond, ok := m[ifDirective]; ok {
+		include, err := mr.evalCondition(ctx, cond)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", joinPath(path, ifDirective), err)
+		}
+		if !include {
+			return nil, false, nil
+		}
+	}
+
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		if k != ifDirective && k != forDirective && k != defaultsDirective {
+			ret[k] = v
+		}
+	}
+
+	if loops, ok := m[forDirective]; ok {
+		generated, err := mr.expandLoops(ctx, loops)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", joinPath(path, forDirective), err)
+		}
+		for _, g := range generated {
+			entries, ok := g.(map[string]any)
+			if !ok {
+				return nil, false, fmt.Errorf("%s: the template of a map must be a map, got %T", joinPath(path, forDirective), g)
+			}
+			for k, v := range entries {
+				if _, ok := ret[k]; ok {
+					return nil, false, fmt.Errorf("%s: duplicate key %q", joinPath(path, forDirective), k)
+				}
+				ret[k] = v
+			}
+		}
+	}
+
+	if defaults, ok := m[defaultsDirective]; ok {
+		defaultsMap, ok := defaults.(map[string]any)
+		if !ok && defaults != nil {
+			return nil, false, fmt.Errorf("%s: the defaults must be a map, got %T", joinPath(path, defaultsDirective), defaults)
+		}
+		for k, v := range ret {
+			switch v := v.(type) {
+			case nil:
+				ret[k] = mergeDefaults(defaultsMap, map[string]any{})
+			case map[string]any:
+				ret[k] = mergeDefaults(defaultsMap, v)
+			default:
+				return nil, false, fmt.Errorf("%s: cannot set the defaults of %q, its value is not a map", joinPath(path, defaultsDirective), k)
+			}
+		}
+	}
+
+	for k, v := range ret {
+		val, keep, err := mr.applyValueDirectives(ctx, v, joinPath(path, k))
+		if err != nil {
+			return nil, false, err
+		}
+		if !keep {
+			delete(ret, k)
+			continue
+		}
+		ret[k] = val
+	}
+	return ret, true, nil
+}
+
+// applyListDirectives returns the list with the directives of its elements applied. An element being a map holding
+// only a $for directive is replaced by the elements it generates.
+func (mr *Resolver) applyListDirectives(ctx context.Context, l []any, path string) ([]any, error) {
+	if l == nil {
+		return nil, nil
+	}
+	ret := make([]any, 0, len(l))
+	for i, e := range l {
+		elemPath := joinPath(path, strconv.Itoa(i))
+		elems := []any{e}
+		if m, ok := e.(map[string]any); ok && len(m) == 1 && m[forDirective] != nil {
+			generated, err := mr.expandLoops(ctx, m[forDirective])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", joinPath(elemPath, forDirective), err)
+			}
+			elems = elems[:0]
+			for _, g := range generated {
+				// A list template generates several elements.
+				if gl, ok := g.([]any); ok {
+					elems = append(elems, gl...)
+				} else {
+					elems = append(elems, g)
+				}
+			}
+		}
+		for _, elem := range elems {
+			val, keep, err := mr.applyValueDirectives(ctx, elem, elemPath)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				ret = append(ret, val)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func (mr *Resolver) applyValueDirectives(ctx context.Context, v any, path string) (any, bool, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		return mr.applyMapDirectives(ctx, v, path)
+	case []any:
+		l, err := mr.applyListDirectives(ctx, v, path)
+		return l, err == nil, err
+	default:
+		return v, true, nil
+	}
+}
+
+// evalCondition evaluates the condition of a $if directive, which is a boolean, or a string holding a value, the
+// comparison of two values using == or !=, optionally negated with a leading !. The values are expanded, and a
+// value must be a boolean, or empty for false.
+func (mr *Resolver) evalCondition(ctx context.Context, cond any) (bool, error) {
+	switch c := cond.(type) {
+	case bool:
+		return c, nil
+	case string:
+		c = strings.TrimSpace(c)
+		negate := strings.HasPrefix(c, "!")
+		if negate {
+			c = strings.TrimSpace(c[1:])
+		}
+		var result bool
+		var err error
+		switch {
+		case strings.Contains(c, " == "):
+			left, right, _ := strings.Cut(c, " == ")
+			result, err = mr.compare(ctx, left, right)
+		case strings.Contains(c, " != "):
+			left, right, _ := strings.Cut(c, " != ")
+			result, err = mr.compare(ctx, left, right)
+			result = !result
+		default:
+			result, err = mr.evalBool(ctx, c)
+		}
+		return result != negate, err
+	default:
+		return false, fmt.Errorf("invalid condition of type %T, must be a boolean or a string", cond)
+	}
+}
+
+func (mr *Resolver) compare(ctx context.Context, left, right string) (bool, error) {
+	l, err := mr.expandScalar(ctx, left)
+	if err != nil {
+		return false, err
+	}
+	r, err := mr.expandScalar(ctx, right)
+	if err != nil {
+		return false, err
+	}
+	return l == r, nil
+}
+
+func (mr *Resolver) evalBool(ctx context.Context, value string) (bool, error) {
+	s, err := mr.expandScalar(ctx, value)
+	if err != nil || s == "" {
+		return false, err
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition value %q, must be a boolean", s)
+	}
+	return b, nil
+}
+
+// expandScalar returns the value with its ${} references expanded, as a trimmed string.
+func (mr *Resolver) expandScalar(ctx context.Context, value string) (string, error) {
+	expanded, err := mr.expandValueRecursively(ctx, strings.TrimSpace(value))
+	if err != nil {
+		return "", err
+	}
+	s, ok := scalarString(expanded)
+	if !ok {
+		return "", fmt.Errorf("the value of %q is not a scalar", value)
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// expandLoops returns the values generated by a $for directive, which holds a loop, or a list of loops.
+func (mr *Resolver) expandLoops(ctx context.Context, loops any) ([]any, error) {
+	switch l := loops.(type) {
+	case map[string]any:
+		return mr.expandLoop(ctx, l)
+	case []any:
+		var ret []any
+		for _, loop := range l {
+			m, ok := loop.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid loop of type %T, must be a map", loop)
+			}
+			generated, err := mr.expandLoop(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, generated...)
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("invalid loop of type %T, must be a map or a list", loops)
+	}
+}
+
+// expandLoop returns a copy of the template of the loop for each of its items, where the $(<as>) placeholders are
+// replaced by the item.
+func (mr *Resolver) expandLoop(ctx context.Context, loop map[string]any) ([]any, error) {
+	for k := range loop {
+		if k != forEachKey && k != forAsKey && k != forDoKey {
+			return nil, fmt.Errorf("invalid key %q in loop, must be %q, %q or %q", k, forEachKey, forAsKey, forDoKey)
+		}
+	}
+	each, ok := loop[forEachKey]
+	if !ok {
+		return nil, fmt.Errorf("missing %q in loop", forEachKey)
+	}
+	template, ok := loop[forDoKey]
+	if !ok {
+		return nil, fmt.Errorf("missing %q in loop", forDoKey)
+	}
+	as := defaultForAs
+	if v, ok := loop[forAsKey]; ok {
+		if as, ok = v.(string); !ok || !loopVariableRegexp.MatchString(as) {
+			return nil, fmt.Errorf("invalid loop variable %v, must match %s", v, loopVariableRegexp)
+		}
+	}
+
+	expanded, err := mr.expandValueRecursively(ctx, each)
+	if err != nil {
+		return nil, err
+	}
+	items, err := loopItems(expanded)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]any, 0, len(items))
+	for _, item := range items {
+		placeholder := "$(" + as + ")"
+		ret = append(ret, copyValue(template, func(s string) string { return strings.ReplaceAll(s, placeholder, item) }))
+	}
+	return ret, nil
+}
+
+// loopItems returns the items of a loop, from a list of scalars, or a comma-separated string.
+func loopItems(each any) ([]string, error) {
+	switch e := each.(type) {
+	case internal.ExpandedValue:
+		if l, ok := e.Value.([]any); ok {
+			return loopItems(l)
+		}
+		return loopItems(e.Original)
+	case []any:
+		items := make([]string, 0, len(e))
+		for _, v := range e {
+			s, ok := scalarString(v)
+			if !ok {
+				return nil, fmt.Errorf("invalid item of type %T in loop, must be a scalar", v)
+			}
+			items = append(items, s)
+		}
+		return items, nil
+	case string:
+		var items []string
+		for _, item := range strings.Split(e, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case nil:
+		return nil, nil
+	default:
+		s, ok := scalarString(e)
+		if !ok {
+			return nil, fmt.Errorf("invalid items of type %T in loop, must be a list or a comma-separated string", each)
+		}
+		return []string{s}, nil
+	}
+}
+
+// scalarString returns the string representation of a scalar value, and false if the value is not a scalar.
+func scalarString(v any) (string, bool) {
+	switch v := v.(type) {
+	case internal.ExpandedValue:
+		return v.Original, true
+	case string:
+		return v, true
+	case nil:
+		return "", true
+	case bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
+
+// copyValue returns a deep copy of the value, where the keys and the string values are replaced using the function.
+func copyValue(v any, replace func(string) string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		ret := make(map[string]any, len(v))
+		for k, e := range v {
+			ret[replace(k)] = copyValue(e, replace)
+		}
+		return ret
+	case []any:
+		if v == nil {
+			return v
+		}
+		ret := make([]any, len(v))
+		for i, e := range v {
+			ret[i] = copyValue(e, replace)
+		}
+		return ret
+	case string:
+		return replace(v)
+	default:
+		return v
+	}
+}
+
+// mergeDefaults returns a copy of the defaults, deeply merged with the map, whose values take precedence.
+func mergeDefaults(defaults, m map[string]any) map[string]any {
+	ret := copyValue(defaults, func(s string) string { return s }).(map[string]any)
+	if ret == nil {
+		ret = map[string]any{}
+	}
+	for k, v := range m {
+		dm, dok := ret[k].(map[string]any)
+		vm, vok := v.(map[string]any)
+		if dok && vok {
+			ret[k] = mergeDefaults(dm, vm)
+			continue
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + KeyDelimiter + key
+}
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/internal"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+var directivesEnv = map[string]string{
+	"DEPLOY_ENV": "Prod",
+	"ENABLED":    "true",
+	"DISABLED":   "false",
+	"TENANTS":    "a, b,,c",
+	"REGIONS":    "[eu, us]",
+	"ENCODED":    "aGVsbG8=",
+	"SPACED":     "  value  ",
+}
+
+func resolveWithDirectives(t *testing.T, config string) (*Conf, error) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"yaml:" + config},
+		ProviderFactories: []ProviderFactory{
+			newFakeProvider("yaml", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				return NewRetrievedFromYAML([]byte(uri[len("yaml:"):]))
+			}),
+			newFakeProvider("env", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				return NewRetrievedFromYAML([]byte(directivesEnv[uri[len("env:"):]]))
+			}),
+		},
+		DefaultScheme: "env",
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, resolver.Shutdown(context.Background()))
+	return conf, err
+}
+
+func enableDirectives(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(internal.EnableDirectives.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(internal.EnableDirectives.ID(), false))
+	})
+}
+
+func TestDirectivesDisabled(t *testing.T) {
+	conf, err := resolveWithDirectives(t, "processors:\n  batch:\n    $if: false\n  other: ${lower:A}")
+	require.Error(t, err)
+	assert.Nil(t, conf)
+
+	conf, err = resolveWithDirectives(t, "processors:\n  batch:\n    $if: false")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"processors": map[string]any{"batch": map[string]any{"$if": false}}}, conf.ToStringMap())
+}
+
+func TestDirectives(t *testing.T) {
+	enableDirectives(t)
+	tests := []struct {
+		name     string
+		config   string
+		expected map[string]any
+	}{
+		{
+			name: "if",
+			config: `
+processors:
+  enabled:
+    $if: ${env:ENABLED}
+    key: value
+  disabled:
+    $if: ${env:DISABLED}
+  literal:
+    $if: false
+  unset:
+    $if: ${env:UNSET}
+  negated:
+    $if: "!${env:DISABLED}"
+  equal:
+    $if: ${lower:${env:DEPLOY_ENV}} == prod
+  not_equal:
+    $if: ${env:DEPLOY_ENV} != Prod
+`,
+			expected: map[string]any{"processors": map[string]any{
+				"enabled": map[string]any{"key": "value"},
+				"negated": map[string]any{},
+				"equal":   map[string]any{},
+			}},
+		},
+		{
+			name: "if in list",
+			config: `
+list:
+  - name: kept
+  - name: removed
+    $if: false
+  - scalar
+`,
+			expected: map[string]any{"list": []any{map[string]any{"name": "kept"}, "scalar"}},
+		},
+		{
+			name: "defaults",
+			config: `
+exporters:
+  $defaults:
+    timeout: 10s
+    retry_on_failure:
+      enabled: true
+      max_elapsed_time: 1m
+  otlp/a:
+    endpoint: a
+  otlp/b:
+    retry_on_failure:
+      enabled: false
+  otlp/c:
+`,
+			expected: map[string]any{"exporters": map[string]any{
+				"otlp/a": map[string]any{"endpoint": "a", "timeout": "10s", "retry_on_failure": map[string]any{"enabled": true, "max_elapsed_time": "1m"}},
+				"otlp/b": map[string]any{"timeout": "10s", "retry_on_failure": map[string]any{"enabled": false, "max_elapsed_time": "1m"}},
+				"otlp/c": map[string]any{"timeout": "10s", "retry_on_failure": map[string]any{"enabled": true, "max_elapsed_time": "1m"}},
+			}},
+		},
+		{
+			name: "for in map",
+			config: `
+pipelines:
+  traces:
+    receivers: [otlp]
+  $for:
+    each: ${env:TENANTS}
+    as: tenant
+    do:
+      traces/$(tenant):
+        receivers: [otlp/$(tenant)]
+        exporters: [otlp/$(tenant)]
+`,
+			expected: map[string]any{"pipelines": map[string]any{
+				"traces":   map[string]any{"receivers": []any{"otlp"}},
+				"traces/a": map[string]any{"receivers": []any{"otlp/a"}, "exporters": []any{"otlp/a"}},
+				"traces/b": map[string]any{"receivers": []any{"otlp/b"}, "exporters": []any{"otlp/b"}},
+				"traces/c": map[string]any{"receivers": []any{"otlp/c"}, "exporters": []any{"otlp/c"}},
+			}},
+		},
+		{
+			name: "for in list",
+			config: `
+receivers:
+  - first
+  - $for:
+      each: ${env:REGIONS}
+      do: otlp/$(item)
+  - $for:
+      each: [x, 1]
+      do: [a/$(item), b/$(item)]
+`,
+			expected: map[string]any{"receivers": []any{"first", "otlp/eu", "otlp/us", "a/x", "b/x", "a/1", "b/1"}},
+		},
+		{
+			name: "nested loops and conditions",
+			config: `
+exporters:
+  $for:
+    each: [traces, logs]
+    as: signal
+    do:
+      otlp/$(signal):
+        $if: $(signal) == traces
+pipelines:
+  $for:
+    each: [traces, logs]
+    as: signal
+    do:
+      $(signal):
+        receivers:
+          - $for:
+              each: [eu, us]
+              as: region
+              do: otlp/$(signal)-$(region)
+`,
+			expected: map[string]any{
+				"exporters": map[string]any{"otlp/traces": map[string]any{}},
+				"pipelines": map[string]any{
+					"traces": map[string]any{"receivers": []any{"otlp/traces-eu", "otlp/traces-us"}},
+					"logs":   map[string]any{"receivers": []any{"otlp/logs-eu", "otlp/logs-us"}},
+				},
+			},
+		},
+		{
+			name: "functions",
+			config: `
+lower: ${lower:${env:DEPLOY_ENV}}
+trim: ${trim:${env:SPACED}}
+decoded: ${base64decode:${env:ENCODED}}
+embedded: env-${lower:${env:DEPLOY_ENV}}
+`,
+			expected: map[string]any{"lower": "prod", "trim": "value", "decoded": "hello", "embedded": "env-prod"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := resolveWithDirectives(t, tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conf.ToStringMap())
+		})
+	}
+}
+
+func TestDirectivesErrors(t *testing.T) {
+	enableDirectives(t)
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "invalid condition",
+			config: "a:\n  $if: ${env:DEPLOY_ENV}",
+			err:    `a::$if: invalid condition value "Prod", must be a boolean`,
+		},
+		{
+			name:   "invalid condition type",
+			config: "a:\n  $if: [true]",
+			err:    "a::$if: invalid condition of type []interface {}, must be a boolean or a string",
+		},
+		{
+			name:   "missing each",
+			config: "a:\n  $for:\n    do: x",
+			err:    `a::$for: missing "each" in loop`,
+		},
+		{
+			name:   "missing do",
+			config: "a:\n  $for:\n    each: [x]",
+			err:    `a::$for: missing "do" in loop`,
+		},
+		{
+			name:   "invalid key",
+			config: "a:\n  $for:\n    each: [x]\n    do: {}\n    in: [y]",
+			err:    `a::$for: invalid key "in" in loop, must be "each", "as" or "do"`,
+		},
+		{
+			name:   "invalid variable",
+			config: "a:\n  $for:\n    each: [x]\n    as: a-b\n    do: {}",
+			err:    "a::$for: invalid loop variable a-b",
+		},
+		{
+			name:   "invalid items",
+			config: "a:\n  $for:\n    each: [[x]]\n    do: {}",
+			err:    "a::$for: invalid item of type []interface {} in loop, must be a scalar",
+		},
+		{
+			name:   "template not a map",
+			config: "a:\n  $for:\n    each: [x]\n    do: $(item)",
+			err:    "a::$for: the template of a map must be a map, got string",
+		},
+		{
+			name:   "duplicate key",
+			config: "a:\n  x: {}\n  $for:\n    each: [x]\n    do:\n      $(item): {}",
+			err:    `a::$for: duplicate key "x"`,
+		},
+		{
+			name:   "defaults not a map",
+			config: "a:\n  $defaults: [x]",
+			err:    "a::$defaults: the defaults must be a map, got []interface {}",
+		},
+		{
+			name:   "defaults of a scalar",
+			config: "a:\n  $defaults: {x: 1}\n  b: c",
+			err:    `a::$defaults: cannot set the defaults of "b", its value is not a map`,
+		},
+		{
+			name:   "invalid base64",
+			config: "a: ${base64decode:not base64}",
+			err:    `cannot call the "base64decode" function`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveWithDirectives(t, tt.config)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestFunctionsSensitive(t *testing.T) {
+	enableDirectives(t)
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"yaml:token: ${trim:${secret:token}}"},
+		ProviderFactories: []ProviderFactory{
+			newFakeProvider("yaml", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				return NewRetrievedFromYAML([]byte(uri[len("yaml:"):]))
+			}),
+			newFakeProvider("secret", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(" s3cr3t ", WithRetrievedSensitive())
+			}),
+		},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, resolver.Shutdown(context.Background()))
+	assert.Equal(t, "s3cr3t", conf.Get("token"))
+	assert.Equal(t, "[REDACTED]", resolver.Redact(conf).Get("token"))
+}
@@ -202,11 +202,17 @@ func (mr *Resolver) expandURI(ctx context.Context, input string) (*Retrieved, er
 	if strings.Contains(lURI.opaqueValue, "$") {
 		return nil, fmt.Errorf("the uri %q contains unsupported characters ('$')", lURI.asString())
 	}
-	ret, err := mr.retrieveValue(ctx, lURI)
+	var ret *Retrieved
+	if mr.isFunction(lURI.scheme) {
+		ret, err = mr.callFunction(lURI)
+	} else {
+		ret, err = mr.retrieveValue(ctx, lURI)
+	}
 	if err != nil {
 		return nil, err
 	}
 	mr.closers = append(mr.closers, ret.Close)
+	mr.recordSensitive(ret)
 	return ret, nil
 }
 
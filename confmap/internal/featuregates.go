@@ -12,3 +12,10 @@ var EnableMergeAppendOption = featuregate.GlobalRegistry().MustRegister(
 	featuregate.WithRegisterDescription("Combines lists when resolving configs from different sources. This feature gate will not be stabilized 'as is'; the current behavior will remain the default."),
 	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector/issues/8754"),
 )
+
+var EnableDirectives = featuregate.GlobalRegistry().MustRegister(
+	"confmap.enableDirectives",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("Applies the $if, $for and $defaults directives of the configuration, and enables the lower, trim and base64decode functions in the ${} references."),
+)
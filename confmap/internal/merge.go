@@ -4,37 +4,185 @@
 package internal // import "go.opentelemetry.io/collector/confmap/internal"
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/gobwas/glob"
 	"github.com/knadh/koanf/maps"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
 )
 
-func mergeAppend(src, dest map[string]any) error {
-	// mergeAppend recursively merges the src map into the dest map (left to right),
-	// modifying and expanding the dest map in the process.
-	// This function does not overwrite component lists, and ensures that the
-	// final value is a name-aware copy of lists from src and dest.
+// ListMergeStrategy defines how a list is merged into the list at the same path of the existing configuration.
+type ListMergeStrategy string
+
+const (
+	// ListMergeReplace replaces the existing list, this is the default strategy.
+	ListMergeReplace ListMergeStrategy = "replace"
+	// ListMergeAppend appends the elements of the list to the existing list.
+	ListMergeAppend ListMergeStrategy = "append"
+	// ListMergeAppendUnique appends the elements of the list which are not in the existing list.
+	ListMergeAppendUnique ListMergeStrategy = "append-unique"
+	// ListMergeByKey merges the maps of the list into the maps of the existing list having the same value for the
+	// key of the rule, and appends the other elements.
+	ListMergeByKey ListMergeStrategy = "merge-by-key"
+)
+
+// ListMergeRule sets the strategy used to merge the lists at the paths matching a pattern.
+type ListMergeRule struct {
+	// Path is the pattern of the paths of the lists, using KeyDelimiter to separate the keys,
+	// e.g. "service::pipelines::*::processors". `*` matches a single key, and `**` any number of keys.
+	Path string
+	// Strategy is the strategy used to merge the lists.
+	Strategy ListMergeStrategy
+	// Key is the key identifying the maps of the lists merged with the ListMergeByKey strategy.
+	Key string
+}
+
+// ParseListMergeRule parses a rule in the "<path>=<strategy>" format, where the strategy of a merge by key
+// is followed by the key, e.g. "exporters::*::headers=merge-by-key:name".
+func ParseListMergeRule(s string) (ListMergeRule, error) {
+	idx := strings.LastIndex(s, "=")
+	if idx == -1 {
+		return ListMergeRule{}, fmt.Errorf("invalid list merge rule %q: missing equal sign", s)
+	}
+	rule := ListMergeRule{Path: strings.TrimSpace(s[:idx])}
+	strategy, key, _ := strings.Cut(strings.TrimSpace(s[idx+1:]), ":")
+	rule.Strategy, rule.Key = ListMergeStrategy(strategy), key
+	if err := rule.Validate(); err != nil {
+		return ListMergeRule{}, fmt.Errorf("invalid list merge rule %q: %w", s, err)
+	}
+	return rule, nil
+}
+
+// Validate checks if the rule is valid.
+func (r ListMergeRule) Validate() error {
+	if r.Path == "" {
+		return errors.New("path must not be empty")
+	}
+	if _, err := glob.Compile(r.Path, ':'); err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	switch r.Strategy {
+	case ListMergeReplace, ListMergeAppend, ListMergeAppendUnique:
+		if r.Key != "" {
+			return fmt.Errorf("key is only supported by the %q strategy", ListMergeByKey)
+		}
+	case ListMergeByKey:
+		if r.Key == "" {
+			return fmt.Errorf("key must be set for the %q strategy", ListMergeByKey)
+		}
+	default:
+		return fmt.Errorf("unknown strategy %q", r.Strategy)
+	}
+	return nil
+}
+
+type compiledListMergeRule struct {
+	glob glob.Glob
+	ListMergeRule
+}
+
+// ListMergeRules are the compiled rules used by MergeWithRules.
+type ListMergeRules struct {
+	rules []compiledListMergeRule
+}
+
+// NewListMergeRules compiles the rules. When several rules match the path of a list, the first one is used.
+func NewListMergeRules(rules []ListMergeRule) (*ListMergeRules, error) {
+	lmr := &ListMergeRules{}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid list merge rule for path %q: %w", rule.Path, err)
+		}
+		lmr.rules = append(lmr.rules, compiledListMergeRule{glob: glob.MustCompile(rule.Path, ':'), ListMergeRule: rule})
+	}
+	return lmr, nil
+}
 
-	// Compile the globs once
-	patterns := []string{
+// componentListRules are the rules used when the EnableMergeAppendOption feature gate is enabled.
+var componentListRules = func() *ListMergeRules {
+	lmr := &ListMergeRules{}
+	for _, path := range []string{
 		"service::extensions",
 		"service::**::receivers",
 		"service::**::exporters",
+	} {
+		lmr.rules = append(lmr.rules, compiledListMergeRule{
+			glob:          glob.MustCompile(path),
+			ListMergeRule: ListMergeRule{Path: path, Strategy: ListMergeAppendUnique},
+		})
+	}
+	return lmr
+}()
+
+func (lmr *ListMergeRules) match(key string) (ListMergeRule, bool) {
+	if lmr == nil {
+		return ListMergeRule{}, false
 	}
-	var globs []glob.Glob
-	for _, p := range patterns {
-		if g, err := glob.Compile(p); err == nil {
-			globs = append(globs, g)
+	for _, rule := range lmr.rules {
+		if rule.glob.Match(key) {
+			return rule.ListMergeRule, true
 		}
 	}
+	return ListMergeRule{}, false
+}
+
+// MergeWithRules merges the input given configuration into the existing config, merging the lists according to
+// the rules. The lists not matching any rule are merged the same way as by Conf.Merge.
+// Note that the given map may be modified.
+func MergeWithRules(l, in *Conf, rules *ListMergeRules) error {
+	if rules == nil || len(rules.rules) == 0 {
+		return l.Merge(in)
+	}
+	var defaults *ListMergeRules
+	if EnableMergeAppendOption.IsEnabled() {
+		defaults = componentListRules
+	}
+	return l.mergeLists(in, func(key string) (ListMergeRule, bool) {
+		if rule, ok := rules.match(key); ok {
+			return rule, true
+		}
+		return defaults.match(key)
+	})
+}
+
+// mergeAppend merges the input given configuration into the existing config.
+// Note that the given map may be modified.
+// Additionally, mergeAppend performs deduplication when merging lists.
+// For example, if listA = [extension1, extension2] and listB = [extension1, extension3],
+// the resulting list will be [extension1, extension2, extension3].
+func (l *Conf) mergeAppend(in *Conf) error {
+	return l.mergeLists(in, componentListRules.match)
+}
+
+// mergeLists merges the input given configuration into the existing config, merging the lists according to the
+// rule returned by match for their path.
+func (l *Conf) mergeLists(in *Conf, match func(key string) (ListMergeRule, bool)) error {
+	err := l.k.Load(confmap.Provider(in.ToStringMap(), ""), nil, koanf.WithMergeFunc(func(src, dest map[string]any) error {
+		return mergeListsFunc(src, dest, match)
+	}))
+	if err != nil {
+		return err
+	}
+	l.isNil = l.isNil && in.isNil
+	return nil
+}
+
+func mergeListsFunc(src, dest map[string]any, match func(key string) (ListMergeRule, bool)) error {
+	// mergeListsFunc recursively merges the src map into the dest map (left to right),
+	// modifying and expanding the dest map in the process.
+	// The lists matching a rule are replaced by the result of the merge of the lists from src and dest.
 
 	// Flatten both source and destination maps
 	srcFlat, _ := maps.Flatten(src, []string{}, KeyDelimiter)
 	destFlat, _ := maps.Flatten(dest, []string{}, KeyDelimiter)
 
 	for sKey, sVal := range srcFlat {
-		if !isMatch(sKey, globs) {
+		rule, ok := match(sKey)
+		if !ok {
 			continue
 		}
 
@@ -46,9 +194,17 @@ func mergeAppend(src, dest map[string]any) error {
 		srcVal := reflect.ValueOf(sVal)
 		destVal := reflect.ValueOf(dVal)
 
-		// Only merge if the value is a slice or array; let maps.Merge handle other types
-		if srcVal.Kind() == reflect.Slice || srcVal.Kind() == reflect.Array {
-			srcFlat[sKey] = mergeSlice(srcVal, destVal)
+		// Only merge if both values are slices or arrays; let maps.Merge handle other types
+		if !isList(srcVal) || !isList(destVal) {
+			continue
+		}
+		switch rule.Strategy {
+		case ListMergeAppend:
+			srcFlat[sKey] = appendSlice(srcVal, destVal, false)
+		case ListMergeAppendUnique:
+			srcFlat[sKey] = appendSlice(srcVal, destVal, true)
+		case ListMergeByKey:
+			srcFlat[sKey] = mergeSliceByKey(srcVal, destVal, rule.Key)
 		}
 	}
 
@@ -59,24 +215,18 @@ func mergeAppend(src, dest map[string]any) error {
 	return nil
 }
 
-// isMatch checks if a key matches any glob in the list
-func isMatch(key string, globs []glob.Glob) bool {
-	for _, g := range globs {
-		if g.Match(key) {
-			return true
-		}
-	}
-	return false
+func isList(val reflect.Value) bool {
+	return val.Kind() == reflect.Slice || val.Kind() == reflect.Array
 }
 
-func mergeSlice(src, dest reflect.Value) any {
-	slice := reflect.MakeSlice(src.Type(), 0, src.Cap()+dest.Cap())
+func appendSlice(src, dest reflect.Value, unique bool) any {
+	slice := reflect.MakeSlice(reflect.TypeOf([]any{}), 0, src.Len()+dest.Len())
 	for i := 0; i < dest.Len(); i++ {
 		slice = reflect.Append(slice, dest.Index(i))
 	}
 
 	for i := 0; i < src.Len(); i++ {
-		if isPresent(slice, src.Index(i)) {
+		if unique && isPresent(slice, src.Index(i)) {
 			continue
 		}
 		slice = reflect.Append(slice, src.Index(i))
@@ -92,3 +242,51 @@ func isPresent(slice, val reflect.Value) bool {
 	}
 	return false
 }
+
+// mergeSliceByKey merges the maps of src into the maps of dest having the same value for the key,
+// and appends the other elements of src.
+func mergeSliceByKey(src, dest reflect.Value, key string) any {
+	merged := make([]any, 0, src.Len()+dest.Len())
+	index := map[any]int{}
+	for i := 0; i < dest.Len(); i++ {
+		elem := dest.Index(i).Interface()
+		if id, ok := mapKey(elem, key); ok {
+			if _, found := index[id]; !found {
+				index[id] = len(merged)
+			}
+		}
+		merged = append(merged, elem)
+	}
+
+	for i := 0; i < src.Len(); i++ {
+		elem := src.Index(i).Interface()
+		id, ok := mapKey(elem, key)
+		if !ok {
+			merged = append(merged, elem)
+			continue
+		}
+		j, found := index[id]
+		if !found {
+			index[id] = len(merged)
+			merged = append(merged, elem)
+			continue
+		}
+		destMap := maps.Copy(merged[j].(map[string]any))
+		maps.Merge(maps.Copy(elem.(map[string]any)), destMap)
+		merged[j] = destMap
+	}
+	return merged
+}
+
+// mapKey returns the value of the key if elem is a map with a comparable value for it.
+func mapKey(elem any, key string) (any, bool) {
+	m, ok := elem.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	id, ok := m[key]
+	if !ok || id == nil || !reflect.TypeOf(id).Comparable() {
+		return nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListMergeRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected ListMergeRule
+		err      string
+	}{
+		{
+			rule:     "service::extensions=append",
+			expected: ListMergeRule{Path: "service::extensions", Strategy: ListMergeAppend},
+		},
+		{
+			rule:     " service::pipelines::*::processors = append-unique ",
+			expected: ListMergeRule{Path: "service::pipelines::*::processors", Strategy: ListMergeAppendUnique},
+		},
+		{
+			rule:     "receivers::*::targets=merge-by-key:name",
+			expected: ListMergeRule{Path: "receivers::*::targets", Strategy: ListMergeByKey, Key: "name"},
+		},
+		{
+			rule:     "exporters::otlp::headers=replace",
+			expected: ListMergeRule{Path: "exporters::otlp::headers", Strategy: ListMergeReplace},
+		},
+		{rule: "service::extensions", err: "missing equal sign"},
+		{rule: "=append", err: "path must not be empty"},
+		{rule: "service::[extensions=append", err: "invalid path"},
+		{rule: "service::extensions=prepend", err: `unknown strategy "prepend"`},
+		{rule: "service::extensions=merge-by-key", err: "key must be set"},
+		{rule: "service::extensions=append:name", err: "key is only supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseListMergeRule(tt.rule)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule)
+		})
+	}
+}
+
+func TestMergeWithRules(t *testing.T) {
+	rules, err := NewListMergeRules([]ListMergeRule{
+		{Path: "list", Strategy: ListMergeByKey, Key: "id"},
+	})
+	require.NoError(t, err)
+
+	conf := NewFromStringMap(map[string]any{
+		"list":  []any{map[string]any{"id": 1, "a": "1"}, map[string]any{"id": []any{"not comparable"}}, "str"},
+		"other": []any{"a"},
+	})
+	require.NoError(t, MergeWithRules(conf, NewFromStringMap(map[string]any{
+		"list":  []any{map[string]any{"id": 1, "b": "2"}, "str"},
+		"other": []any{"b"},
+	}), rules))
+	assert.Equal(t, map[string]any{
+		"list": []any{
+			map[string]any{"id": 1, "a": "1", "b": "2"},
+			map[string]any{"id": []any{"not comparable"}},
+			"str",
+			"str",
+		},
+		"other": []any{"b"},
+	}, conf.ToStringMap())
+
+	conf = NewFromStringMap(map[string]any{"list": []any{"a"}})
+	require.NoError(t, MergeWithRules(conf, NewFromStringMap(map[string]any{"list": []any{"b"}}), nil))
+	assert.Equal(t, map[string]any{"list": []any{"b"}}, conf.ToStringMap())
+}
@@ -110,6 +110,8 @@ type Retrieved struct {
 
 	stringRepresentation string
 	isSetString          bool
+	sensitive            bool
+	fragments            []*Retrieved
 }
 
 type retrievedSettings struct {
@@ -117,6 +119,8 @@ type retrievedSettings struct {
 	stringRepresentation string
 	isSetString          bool
 	closeFunc            CloseFunc
+	sensitive            bool
+	fragments            []*Retrieved
 }
 
 // RetrievedOption options to customize Retrieved values.
@@ -138,6 +142,23 @@ func WithRetrievedClose(closeFunc CloseFunc) RetrievedOption {
 	})
 }
 
+// WithRetrievedSensitive marks the retrieved value as sensitive, e.g. a password or a token. The Resolver records
+// the keys whose values are expanded from sensitive ${} references, so they can be redacted using Resolver.Redact.
+func WithRetrievedSensitive() RetrievedOption {
+	return retrievedOptionFunc(func(settings *retrievedSettings) {
+		settings.sensitive = true
+	})
+}
+
+// WithRetrievedFragments indicates that the retrieved configuration is the merge of the given fragments, e.g. the
+// files of a directory. The Resolver merges the fragments in order, with its list merge rules, the same way it merges
+// the configurations retrieved from several URIs, instead of using the retrieved configuration.
+func WithRetrievedFragments(fragments ...*Retrieved) RetrievedOption {
+	return retrievedOptionFunc(func(settings *retrievedSettings) {
+		settings.fragments = fragments
+	})
+}
+
 func withStringRepresentation(stringRepresentation string) RetrievedOption {
 	return retrievedOptionFunc(func(settings *retrievedSettings) {
 		settings.stringRepresentation = stringRepresentation
@@ -195,6 +216,8 @@ func NewRetrieved(rawConf any, opts ...RetrievedOption) (*Retrieved, error) {
 		closeFunc:            set.closeFunc,
 		stringRepresentation: set.stringRepresentation,
 		isSetString:          set.isSetString,
+		sensitive:            set.sensitive,
+		fragments:            set.fragments,
 	}, nil
 }
 
@@ -7,17 +7,54 @@ package fileprovider // import "go.opentelemetry.io/collector/confmap/provider/f
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
-const schemeName = "file"
+const (
+	schemeName = "file"
+
+	defaultPollInterval = time.Second
+	defaultDebounce     = 2 * time.Second
+)
+
+var watchFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"confmap.fileProviderWatch",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("Watches the files read by the file provider, and reloads the configuration when their content changes."),
+)
 
-type provider struct{}
+var directoriesFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"confmap.fileProviderDirectories",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("Reads the YAML files of a directory, or the files matching a pattern, with the file provider."),
+)
+
+type provider struct {
+	logger *zap.Logger
+	// pollInterval is the interval between two reads of a watched file.
+	pollInterval time.Duration
+	// debounce is how long the content of a watched file must be stable after a change before notifying it,
+	// so a file being written, or replaced in several steps, is reloaded only once.
+	debounce time.Duration
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+	wg           sync.WaitGroup
+}
 
 // NewFactory returns a factory for a confmap.Provider that reads the configuration from a file.
 //
@@ -34,32 +71,204 @@ type provider struct{}
 // `file:/path/to/file` - absolute path (unix, windows)
 // `file:c:/path/to/file` - absolute path including drive-letter (windows)
 // `file:c:\path\to\file` - absolute path including drive-letter (windows)
+//
+// If the "confmap.fileProviderDirectories" feature gate is enabled, the path can also be a directory or a pattern:
+// `file:/path/to/conf.d` - directory, the YAML files it contains are merged in lexical order
+// `file:/path/to/conf.d/*.yaml` - pattern, the files matching it are merged in lexical order
+//
+// If the "confmap.fileProviderWatch" feature gate is enabled, the file is polled for changes, and the
+// watcher is called once its content changed and is stable. The path is resolved on every poll, so the
+// replacement of the file or of a symbolic link to it, as done for Kubernetes ConfigMap mounts, is detected.
 func NewFactory() confmap.ProviderFactory {
 	return confmap.NewProviderFactory(newProvider)
 }
 
-func newProvider(confmap.ProviderSettings) confmap.Provider {
-	return &provider{}
+func newProvider(set confmap.ProviderSettings) confmap.Provider {
+	return &provider{
+		logger:       set.Logger,
+		pollInterval: defaultPollInterval,
+		debounce:     defaultDebounce,
+		shutdown:     make(chan struct{}),
+	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
 	if !strings.HasPrefix(uri, schemeName+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
 
 	// Clean the path before using it.
-	content, err := os.ReadFile(filepath.Clean(uri[len(schemeName)+1:]))
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	files, single, err := resolveFiles(path)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
 	}
+	contents, sum, err := readFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
+	}
+
+	var opts []confmap.RetrievedOption
+	if watcher != nil && watchFeatureGate.IsEnabled() {
+		stop := fmp.watch(path, sum, watcher)
+		opts = append(opts, confmap.WithRetrievedClose(func(context.Context) error {
+			stop()
+			return nil
+		}))
+	}
+
+	if single {
+		return confmap.NewRetrievedFromYAML(contents[0], opts...)
+	}
+
+	// The resolver merges the files in order with its list merge rules, the same way it merges the configurations of
+	// several URIs. The merged configuration is only used by the other callers.
+	conf := confmap.New()
+	fragments := make([]*confmap.Retrieved, 0, len(contents))
+	for i, content := range contents {
+		ret, err := confmap.NewRetrievedFromYAML(content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the file %v: %w", files[i], err)
+		}
+		fileConf, err := ret.AsConf()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the file %v: %w", files[i], err)
+		}
+		if err = conf.Merge(fileConf); err != nil {
+			return nil, fmt.Errorf("unable to merge the file %v: %w", files[i], err)
+		}
+		fragments = append(fragments, ret)
+	}
+	return confmap.NewRetrieved(conf.ToStringMap(), append(opts, confmap.WithRetrievedFragments(fragments...))...)
+}
+
+// resolveFiles returns the files to read for the path, in lexical order, and whether the path is a single file.
+// A directory is resolved to the YAML files it contains, and a pattern to the files matching it. Hidden files
+// are ignored, so the files of Kubernetes ConfigMap mounts are read only once. The path is a single file when the
+// confmap.fileProviderDirectories feature gate is disabled.
+func resolveFiles(path string) ([]string, bool, error) {
+	if !directoriesFeatureGate.IsEnabled() {
+		return []string{path}, true, nil
+	}
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, false, err
+		}
+		var files []string
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); ext == ".yaml" || ext == ".yml" {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		return filterFiles(files, fmt.Errorf("no YAML file found in the directory %v", path))
+	case err != nil && strings.ContainsAny(path, "*?["):
+		files, err := filepath.Glob(path)
+		if err != nil {
+			return nil, false, err
+		}
+		return filterFiles(files, fmt.Errorf("no file matches the pattern %v", path))
+	default:
+		return []string{path}, true, nil
+	}
+}
+
+func filterFiles(candidates []string, errNotFound error) ([]string, bool, error) {
+	var files []string
+	for _, file := range candidates {
+		if strings.HasPrefix(filepath.Base(file), ".") {
+			continue
+		}
+		// Follow the symbolic links, and skip the directories.
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, false, errNotFound
+	}
+	slices.Sort(files)
+	return files, false, nil
+}
+
+// readFiles returns the contents of the files, and a hash of their names and contents.
+func readFiles(files []string) ([][]byte, [sha256.Size]byte, error) {
+	contents := make([][]byte, 0, len(files))
+	h := sha256.New()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, [sha256.Size]byte{}, err
+		}
+		contents = append(contents, content)
+		fileSum := sha256.Sum256(content)
+		h.Write([]byte(file))
+		h.Write(fileSum[:])
+	}
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	return contents, sum, nil
+}
+
+// watch polls the files of the path until their names or contents are different from the retrieved ones and
+// stable for the debounce duration, then calls the watcher once. It returns a function stopping the polling.
+func (fmp *provider) watch(path string, retrieved [sha256.Size]byte, watcher confmap.WatcherFunc) func() {
+	stop := make(chan struct{})
+	fmp.wg.Add(1)
+	go func() {
+		defer fmp.wg.Done()
+		ticker := time.NewTicker(fmp.pollInterval)
+		defer ticker.Stop()
+
+		last := retrieved
+		var changedAt time.Time
+		for {
+			var now time.Time
+			select {
+			case <-stop:
+				return
+			case <-fmp.shutdown:
+				return
+			case now = <-ticker.C:
+			}
+
+			files, _, err := resolveFiles(path)
+			var current [sha256.Size]byte
+			if err == nil {
+				_, current, err = readFiles(files)
+			}
+			if err != nil {
+				// The file may be in the middle of being replaced, wait until it is readable again.
+				fmp.logger.Debug("Failed to read the watched configuration file", zap.String("path", path), zap.Error(err))
+				changedAt = now
+				continue
+			}
+			switch {
+			case current != last:
+				last = current
+				changedAt = now
+			case current != retrieved && now.Sub(changedAt) >= fmp.debounce:
+				fmp.logger.Info("Configuration file changed", zap.String("path", path))
+				watcher(&confmap.ChangeEvent{})
+				return
+			}
+		}
+	}()
 
-	return confmap.NewRetrievedFromYAML(content)
+	var once sync.Once
+	return func() { once.Do(func() { close(stop) }) }
 }
 
 func (*provider) Scheme() string {
 	return schemeName
 }
 
-func (*provider) Shutdown(context.Context) error {
+// Shutdown stops watching the files and waits until the watchers are no longer called.
+func (fmp *provider) Shutdown(context.Context) error {
+	fmp.shutdownOnce.Do(func() { close(fmp.shutdown) })
+	fmp.wg.Wait()
 	return nil
 }
@@ -7,13 +7,16 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 const fileSchemePrefix = schemeName + ":"
@@ -90,6 +93,281 @@ func TestAbsolutePath(t *testing.T) {
 	assert.NoError(t, fp.Shutdown(context.Background()))
 }
 
+func TestDirectory(t *testing.T) {
+	setDirectoriesGateForTest(t, true)
+	fp := createProvider()
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "conf.d"), nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	// The hidden and non YAML files are ignored, and the files are merged in lexical order.
+	expectedMap := confmap.NewFromStringMap(map[string]any{
+		"processors::testprocessor":    nil,
+		"exporters::otlp::endpoint":    "localhost:4318",
+		"exporters::otlp::compression": "none",
+	})
+	assert.Equal(t, expectedMap.ToStringMap(), retMap.ToStringMap())
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestDirectoryDisabled(t *testing.T) {
+	setDirectoriesGateForTest(t, false)
+	fp := createProvider()
+	// The path is read as a single file.
+	_, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "conf.d"), nil)
+	require.Error(t, err)
+	_, err = fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "conf.d", "*.yaml"), nil)
+	require.Error(t, err)
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestDirectoryListMergeRules(t *testing.T) {
+	setDirectoriesGateForTest(t, true)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10-base.yaml"), []byte("service: {extensions: [health_check]}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20-pprof.yaml"), []byte("service: {extensions: [pprof]}"), 0o600))
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:              []string{fileSchemePrefix + dir},
+		ProviderFactories: []confmap.ProviderFactory{NewFactory()},
+		ListMergeRules:    []confmap.ListMergeRule{{Path: "service::extensions", Strategy: confmap.ListMergeAppend}},
+	})
+	require.NoError(t, err)
+	// The files are merged by the resolver, with its list merge rules.
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"health_check", "pprof"}, conf.Get("service::extensions"))
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestGlob(t *testing.T) {
+	setDirectoriesGateForTest(t, true)
+	fp := createProvider()
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+absolutePath(t, filepath.Join("testdata", "conf.d", "*.yaml")), nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	expectedMap := confmap.NewFromStringMap(map[string]any{
+		"processors::testprocessor": nil,
+		"exporters::otlp::endpoint": "localhost:4317",
+	})
+	assert.Equal(t, expectedMap.ToStringMap(), retMap.ToStringMap())
+
+	ret, err = fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "conf.d", "[0-9]*"), nil)
+	require.NoError(t, err)
+	retMap, err = ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4318", retMap.Get("exporters::otlp::endpoint"))
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestDirectoryAndGlobErrors(t *testing.T) {
+	setDirectoriesGateForTest(t, true)
+	fp := createProvider()
+	_, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "conf.d", "*.json"), nil)
+	require.ErrorContains(t, err, "no file matches the pattern")
+
+	_, err = fp.Retrieve(context.Background(), fileSchemePrefix+t.TempDir(), nil)
+	require.ErrorContains(t, err, "no YAML file found in the directory")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("key: value"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("- not a map"), 0o600))
+	_, err = fp.Retrieve(context.Background(), fileSchemePrefix+dir, nil)
+	require.ErrorContains(t, err, "b.yaml")
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchDirectory(t *testing.T) {
+	setDirectoriesGateForTest(t, true)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10-base.yaml"), []byte("key: v1"), 0o600))
+
+	fp := createWatchingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+dir, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	// Adding a file that is not part of the configuration is not a change.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o600))
+	assertNotChanged(t, changed)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20-team.yaml"), []byte("other: v1"), 0o600))
+	select {
+	case event := <-changed:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: v1"), 0o600))
+
+	fp := createWatchingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	require.NoError(t, featuregate.GlobalRegistry().Set(watchFeatureGate.ID(), false))
+	_, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("key: v2"), 0o600))
+	assertNotChanged(t, changed)
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: v1"), 0o600))
+
+	fp := createWatchingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 2)
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	assertNotChanged(t, changed)
+
+	require.NoError(t, os.WriteFile(path, []byte("key: v2"), 0o600))
+	select {
+	case event := <-changed:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+	require.NoError(t, os.WriteFile(path, []byte("key: v3"), 0o600))
+	assertNotChanged(t, changed)
+
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchDebounce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: v1"), 0o600))
+
+	fp := createWatchingProvider(t)
+	fp.debounce = 200 * time.Millisecond
+	changed := make(chan *confmap.ChangeEvent, 2)
+	_, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	// Keep changing the file faster than the debounce duration, the change must not be notified while it is written.
+	for i := range 10 {
+		require.NoError(t, os.WriteFile(path, []byte("key: "+string(rune('a'+i))), 0o600))
+		time.Sleep(50 * time.Millisecond)
+		select {
+		case <-changed:
+			t.Fatal("change notified while the file is changing")
+		default:
+		}
+	}
+
+	// Reverting to the retrieved content is not a change.
+	require.NoError(t, os.WriteFile(path, []byte("key: v1"), 0o600))
+	assertNotChanged(t, changed)
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchSymlinkSwap(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symbolic links requires privileges on windows")
+	}
+	// Reproduce the layout of the Kubernetes ConfigMap mounts, where the file is a link to a link to a
+	// directory, which is atomically replaced on updates.
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "v1"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v1", "config.yaml"), []byte("key: v1"), 0o600))
+	require.NoError(t, os.Symlink("v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), filepath.Join(dir, "config.yaml")))
+
+	fp := createWatchingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join(dir, "config.yaml"), func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "v2"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v2", "config.yaml"), []byte("key: v2"), 0o600))
+	require.NoError(t, os.Symlink("v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	select {
+	case event := <-changed:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: v1"), 0o600))
+
+	fp := createWatchingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	require.NoError(t, ret.Close(context.Background()))
+
+	require.NoError(t, os.WriteFile(path, []byte("key: v2"), 0o600))
+	assertNotChanged(t, changed)
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: v1"), 0o600))
+
+	fp := createWatchingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	_, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	// A missing file is not a change, it may be in the middle of being replaced.
+	require.NoError(t, os.Remove(path))
+	assertNotChanged(t, changed)
+
+	require.NoError(t, os.WriteFile(path, []byte("key: v2"), 0o600))
+	select {
+	case event := <-changed:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func setDirectoriesGateForTest(t *testing.T, enabled bool) {
+	initial := directoriesFeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(directoriesFeatureGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(directoriesFeatureGate.ID(), initial))
+	})
+}
+
+func createWatchingProvider(t *testing.T) *provider {
+	prev := watchFeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(watchFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(watchFeatureGate.ID(), prev))
+	})
+
+	fp := createProvider().(*provider)
+	fp.pollInterval = 10 * time.Millisecond
+	fp.debounce = 50 * time.Millisecond
+	return fp
+}
+
+func assertNotChanged(t *testing.T, changed <-chan *confmap.ChangeEvent) {
+	select {
+	case <-changed:
+		t.Fatal("unexpected change notified")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
 func absolutePath(t *testing.T, relativePath string) string {
 	dir, err := os.Getwd()
 	require.NoError(t, err)
@@ -6,6 +6,8 @@
 package httpprovider // import "go.opentelemetry.io/collector/confmap/provider/httpprovider"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
 )
@@ -15,10 +17,41 @@ import (
 // This Provider supports "http" scheme.
 //
 // One example for HTTP URI is: http://localhost:3333/getConfig
-func NewFactory() confmap.ProviderFactory {
-	return confmap.NewProviderFactory(newProvider)
+//
+// The options can enable polling the configuration, and caching it.
+func NewFactory(opts ...Option) confmap.ProviderFactory {
+	var options configurablehttpprovider.Options
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return confmap.NewProviderFactory(func(set confmap.ProviderSettings) confmap.Provider {
+		return configurablehttpprovider.New(configurablehttpprovider.HTTPScheme, set, options)
+	})
+}
+
+// Option is an option of the provider created by NewFactory.
+type Option interface {
+	apply(*configurablehttpprovider.Options)
+}
+
+type optionFunc func(*configurablehttpprovider.Options)
+
+func (of optionFunc) apply(o *configurablehttpprovider.Options) {
+	of(o)
+}
+
+// WithPollInterval polls the configuration at the given interval using conditional requests, so the Collector
+// reloads it when its content changes. The configuration is not polled by default, or if the interval is 0.
+func WithPollInterval(interval time.Duration) Option {
+	return optionFunc(func(o *configurablehttpprovider.Options) {
+		o.PollInterval = interval
+	})
 }
 
-func newProvider(set confmap.ProviderSettings) confmap.Provider {
-	return configurablehttpprovider.New(configurablehttpprovider.HTTPScheme, set)
+// WithCacheDir stores the last configuration retrieved in the given directory, and reads it from there if the server
+// cannot be reached or answers with an error. No cache is used by default.
+func WithCacheDir(dir string) Option {
+	return optionFunc(func(o *configurablehttpprovider.Options) {
+		o.CacheDir = dir
+	})
 }
@@ -5,11 +5,17 @@ package httpprovider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -18,3 +24,36 @@ func TestSupportedScheme(t *testing.T) {
 	assert.Equal(t, "http", fp.Scheme())
 	require.NoError(t, fp.Shutdown(context.Background()))
 }
+
+func TestOptions(t *testing.T) {
+	var content atomic.Value
+	content.Store("key: value")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(content.Load().(string)))
+	}))
+	defer ts.Close()
+	factory := NewFactory(WithPollInterval(10*time.Millisecond), WithCacheDir(filepath.Join(t.TempDir(), "cache")))
+
+	fp := factory.Create(confmaptest.NewNopProviderSettings())
+	changed := make(chan struct{}, 1)
+	ret, err := fp.Retrieve(context.Background(), ts.URL, func(*confmap.ChangeEvent) { changed <- struct{}{} })
+	require.NoError(t, err)
+	content.Store("key: changed")
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change was not notified")
+	}
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+
+	// The configuration retrieved first is cached, and used when the server cannot be reached.
+	ts.Close()
+	fp = factory.Create(confmaptest.NewNopProviderSettings())
+	ret, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, raw)
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
@@ -6,6 +6,8 @@
 package httpsprovider // import "go.opentelemetry.io/collector/confmap/provider/httpsprovider"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
 )
@@ -16,10 +18,41 @@ import (
 //
 // To add extra CA certificates you need to install certificates in the system pool. This procedure is operating system
 // dependent. E.g.: on Linux please refer to the `update-ca-trust` command.
-func NewFactory() confmap.ProviderFactory {
-	return confmap.NewProviderFactory(newProvider)
+//
+// The options can enable polling the configuration, and caching it.
+func NewFactory(opts ...Option) confmap.ProviderFactory {
+	var options configurablehttpprovider.Options
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return confmap.NewProviderFactory(func(set confmap.ProviderSettings) confmap.Provider {
+		return configurablehttpprovider.New(configurablehttpprovider.HTTPSScheme, set, options)
+	})
+}
+
+// Option is an option of the provider created by NewFactory.
+type Option interface {
+	apply(*configurablehttpprovider.Options)
+}
+
+type optionFunc func(*configurablehttpprovider.Options)
+
+func (of optionFunc) apply(o *configurablehttpprovider.Options) {
+	of(o)
+}
+
+// WithPollInterval polls the configuration at the given interval using conditional requests, so the Collector
+// reloads it when its content changes. The configuration is not polled by default, or if the interval is 0.
+func WithPollInterval(interval time.Duration) Option {
+	return optionFunc(func(o *configurablehttpprovider.Options) {
+		o.PollInterval = interval
+	})
 }
 
-func newProvider(set confmap.ProviderSettings) confmap.Provider {
-	return configurablehttpprovider.New(configurablehttpprovider.HTTPSScheme, set)
+// WithCacheDir stores the last configuration retrieved in the given directory, and reads it from there if the server
+// cannot be reached or answers with an error. No cache is used by default.
+func WithCacheDir(dir string) Option {
+	return optionFunc(func(o *configurablehttpprovider.Options) {
+		o.CacheDir = dir
+	})
 }
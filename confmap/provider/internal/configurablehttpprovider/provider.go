@@ -4,9 +4,12 @@
 package configurablehttpprovider // import "go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +17,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/confmap"
 )
@@ -25,10 +32,35 @@ const (
 	HTTPSScheme SchemeType = "https"
 )
 
+// Options are the options of the provider.
+type Options struct {
+	// PollInterval is the interval between two requests checking if the configuration changed. The configuration is
+	// not polled if it is 0.
+	PollInterval time.Duration
+	// CacheDir is the directory where the last configuration successfully retrieved is stored, and read from if the
+	// server cannot be reached. No cache is used if it is empty.
+	CacheDir string
+}
+
 type provider struct {
 	scheme             SchemeType
 	caCertPath         string // Used for tests
 	insecureSkipVerify bool   // Used for tests
+
+	logger       *zap.Logger
+	pollInterval time.Duration
+	cacheDir     string
+
+	shutdownCtx    context.Context
+	shutdownCancel context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+// response is a successful response of the server.
+type response struct {
+	body         []byte
+	etag         string
+	lastModified string
 }
 
 // New returns a new provider that reads the configuration from http server using the configured transport mechanism
@@ -38,8 +70,18 @@ type provider struct {
 // One example for http-uri: http://localhost:3333/getConfig
 // One example for https-uri: https://localhost:3333/getConfig
 // This is used by the http and https external implementations.
-func New(scheme SchemeType, _ confmap.ProviderSettings) confmap.Provider {
-	return &provider{scheme: scheme}
+//
+// If the poll interval is set, the configuration is polled using conditional requests, and the watcher is called
+// when its content changes. If the cache directory is set, the last configuration successfully retrieved is used
+// when the server cannot be reached.
+func New(scheme SchemeType, set confmap.ProviderSettings, opts Options) confmap.Provider {
+	logger := set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	fmp := &provider{scheme: scheme, logger: logger, pollInterval: opts.PollInterval, cacheDir: opts.CacheDir}
+	fmp.shutdownCtx, fmp.shutdownCancel = context.WithCancel(context.Background())
+	return fmp
 }
 
 // Create the client based on the type of scheme that was selected.
@@ -77,7 +119,7 @@ func (fmp *provider) createClient() (*http.Client, error) {
 	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
 	if !strings.HasPrefix(uri, string(fmp.scheme)+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, string(fmp.scheme))
 	}
@@ -91,14 +133,64 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("unable to configure http transport layer: %w", err)
 	}
 
+	resp, err := fmp.get(ctx, client, uri, nil)
+	fromCache := false
+	if err != nil {
+		cached, cacheErr := fmp.readCache(uri)
+		if cacheErr != nil {
+			return nil, err
+		}
+		fmp.logger.Warn("Unable to retrieve the configuration, using the last configuration retrieved",
+			zap.String("uri", uri), zap.Error(err))
+		// Poll the server without conditions, so the configuration is reloaded as soon as it is different.
+		resp, fromCache = &response{body: cached}, true
+	}
+
+	ret, err := confmap.NewRetrievedFromYAML(resp.body)
+	if err != nil {
+		return nil, err
+	}
+	// Only cache configurations, so a response that is not one, e.g. an error page, does not replace the last
+	// configuration retrieved.
+	if _, err = ret.AsConf(); err == nil && !fromCache {
+		fmp.writeCache(uri, resp.body)
+	}
+
+	if watcher == nil || fmp.pollInterval <= 0 {
+		return ret, nil
+	}
+	stop := fmp.watch(client, uri, resp, watcher)
+	return confmap.NewRetrievedFromYAML(resp.body, confmap.WithRetrievedClose(func(context.Context) error {
+		stop()
+		return nil
+	}))
+}
+
+// get sends a GET request to the uri, and returns the response. If the previous response is not nil, the request
+// is conditional, and a nil response is returned if the configuration was not modified.
+func (fmp *provider) get(ctx context.Context, client *http.Client, uri string, prev *response) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
+	}
+	if prev != nil && prev.etag != "" {
+		req.Header.Set("If-None-Match", prev.etag)
+	}
+	if prev != nil && prev.lastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.lastModified)
+	}
+
 	// send a HTTP GET request
-	resp, err := client.Get(uri)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
 	}
 	defer resp.Body.Close()
 
 	// check the HTTP status code
+	if prev != nil && resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
 	}
@@ -109,13 +201,96 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
 	}
 
-	return confmap.NewRetrievedFromYAML(body)
+	return &response{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// watch polls the uri until the content of the configuration is different from the retrieved one, then calls the
+// watcher once. It returns a function stopping the polling.
+func (fmp *provider) watch(client *http.Client, uri string, retrieved *response, watcher confmap.WatcherFunc) func() {
+	ctx, cancel := context.WithCancel(fmp.shutdownCtx)
+	fmp.wg.Add(1)
+	go func() {
+		defer fmp.wg.Done()
+		ticker := time.NewTicker(fmp.pollInterval)
+		defer ticker.Stop()
+
+		prev := retrieved
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			resp, err := fmp.get(ctx, client, uri, prev)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				// Keep the current configuration, and retry on the next poll.
+				fmp.logger.Warn("Unable to poll the configuration", zap.String("uri", uri), zap.Error(err))
+			case resp == nil:
+				// Not modified.
+			case bytes.Equal(resp.body, retrieved.body):
+				// Only the validators changed, use the new ones for the next requests.
+				prev = resp
+			default:
+				fmp.logger.Info("Configuration changed", zap.String("uri", uri))
+				watcher(&confmap.ChangeEvent{})
+				return
+			}
+		}
+	}()
+	return cancel
+}
+
+// cachePath returns the path of the cache file of the uri, or an empty string if the cache is disabled.
+func (fmp *provider) cachePath(uri string) string {
+	if fmp.cacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(fmp.cacheDir, hex.EncodeToString(sum[:])+".yaml")
+}
+
+func (fmp *provider) readCache(uri string) ([]byte, error) {
+	path := fmp.cachePath(uri)
+	if path == "" {
+		return nil, os.ErrNotExist
+	}
+	return os.ReadFile(filepath.Clean(path))
+}
+
+// writeCache stores the configuration in the cache. The cache file is replaced atomically, so the last
+// configuration retrieved is not lost if the collector stops while writing it.
+func (fmp *provider) writeCache(uri string, body []byte) {
+	path := fmp.cachePath(uri)
+	if path == "" {
+		return
+	}
+	err := os.MkdirAll(fmp.cacheDir, 0o700)
+	if err == nil {
+		tmp := path + ".tmp"
+		if err = os.WriteFile(tmp, body, 0o600); err == nil {
+			err = os.Rename(tmp, path)
+		}
+	}
+	if err != nil {
+		fmp.logger.Warn("Unable to cache the configuration", zap.String("uri", uri), zap.Error(err))
+	}
 }
 
 func (fmp *provider) Scheme() string {
 	return string(fmp.scheme)
 }
 
-func (*provider) Shutdown(context.Context) error {
+// Shutdown stops polling the configurations and waits until the watchers are no longer called.
+func (fmp *provider) Shutdown(context.Context) error {
+	fmp.shutdownCancel()
+	fmp.wg.Wait()
 	return nil
 }
@@ -17,6 +17,8 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -28,7 +30,7 @@ import (
 )
 
 func newConfigurableHTTPProvider(scheme SchemeType, set confmap.ProviderSettings) *provider {
-	return New(scheme, set).(*provider)
+	return New(scheme, set, Options{}).(*provider)
 }
 
 func answerGet(w http.ResponseWriter, _ *http.Request) {
@@ -219,19 +221,19 @@ func TestFunctionalityDownloadFileHTTPS(t *testing.T) {
 }
 
 func TestUnsupportedScheme(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 	_, err := fp.Retrieve(context.Background(), "https://...", nil)
 	require.Error(t, err)
 	require.NoError(t, fp.Shutdown(context.Background()))
 
-	fp = New(HTTPSScheme, confmaptest.NewNopProviderSettings())
+	fp = New(HTTPSScheme, confmaptest.NewNopProviderSettings(), Options{})
 	_, err = fp.Retrieve(context.Background(), "http://...", nil)
 	require.Error(t, err)
 	assert.NoError(t, fp.Shutdown(context.Background()))
 }
 
 func TestEmptyURI(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 	}))
@@ -242,7 +244,7 @@ func TestEmptyURI(t *testing.T) {
 }
 
 func TestRetrieveFromShutdownServer(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 	ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
 	ts.Close()
 	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
@@ -251,7 +253,7 @@ func TestRetrieveFromShutdownServer(t *testing.T) {
 }
 
 func TestNonExistent(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 	}))
@@ -262,7 +264,7 @@ func TestNonExistent(t *testing.T) {
 }
 
 func TestInvalidYAML(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, err := w.Write([]byte("wrong : ["))
@@ -280,17 +282,17 @@ func TestInvalidYAML(t *testing.T) {
 }
 
 func TestScheme(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 	assert.Equal(t, "http", fp.Scheme())
 	require.NoError(t, fp.Shutdown(context.Background()))
 }
 
 func TestValidateProviderScheme(t *testing.T) {
-	assert.NoError(t, confmaptest.ValidateProviderScheme(New(HTTPScheme, confmaptest.NewNopProviderSettings())))
+	assert.NoError(t, confmaptest.ValidateProviderScheme(New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})))
 }
 
 func TestInvalidURI(t *testing.T) {
-	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), Options{})
 
 	tests := []struct {
 		uri string
@@ -317,3 +319,231 @@ func TestInvalidURI(t *testing.T) {
 		})
 	}
 }
+
+// configServer is a test server answering with the current configuration, and supporting conditional requests.
+type configServer struct {
+	mu           sync.Mutex
+	body         string
+	etag         string
+	lastModified string
+	status       int
+	conditional  int
+}
+
+func (cs *configServer) set(body, etag, lastModified string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.body, cs.etag, cs.lastModified = body, etag, lastModified
+}
+
+func (cs *configServer) setStatus(status int) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.status = status
+}
+
+func (cs *configServer) conditionalRequests() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.conditional
+}
+
+func (cs *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.status != 0 {
+		w.WriteHeader(cs.status)
+		return
+	}
+	if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+		cs.conditional++
+	}
+	if cs.etag != "" {
+		w.Header().Set("ETag", cs.etag)
+		if r.Header.Get("If-None-Match") == cs.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	if cs.lastModified != "" {
+		w.Header().Set("Last-Modified", cs.lastModified)
+		if r.Header.Get("If-Modified-Since") == cs.lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	_, _ = w.Write([]byte(cs.body))
+}
+
+func newPollingProvider(t *testing.T) *provider {
+	fp := newConfigurableHTTPProvider(HTTPScheme, confmaptest.NewNopProviderSettings())
+	fp.pollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { assert.NoError(t, fp.Shutdown(context.Background())) })
+	return fp
+}
+
+func waitChanged(t *testing.T, changed <-chan *confmap.ChangeEvent) {
+	select {
+	case event := <-changed:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+}
+
+func assertNotChanged(t *testing.T, changed <-chan *confmap.ChangeEvent) {
+	select {
+	case <-changed:
+		t.Fatal("unexpected change notified")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(),
+		Options{PollInterval: 30 * time.Second, CacheDir: "/var/cache/otelcol"}).(*provider)
+	assert.Equal(t, 30*time.Second, fp.pollInterval)
+	assert.Equal(t, "/var/cache/otelcol", fp.cacheDir)
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestNewNilLogger(t *testing.T) {
+	fp := New(HTTPScheme, confmap.ProviderSettings{}, Options{CacheDir: filepath.Join(t.TempDir(), "cache")}).(*provider)
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	fp.writeCache(ts.URL, []byte("key: value"))
+
+	// A warning is logged since the server cannot be reached and the cached configuration is used.
+	ret, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, raw)
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestPollConditionalRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		etag         string
+		lastModified string
+	}{
+		{name: "etag", etag: `"v1"`},
+		{name: "last_modified", lastModified: "Mon, 02 Jan 2006 15:04:05 GMT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := &configServer{}
+			cs.set("key: v1", tt.etag, tt.lastModified)
+			ts := httptest.NewServer(cs)
+			defer ts.Close()
+
+			fp := newPollingProvider(t)
+			changed := make(chan *confmap.ChangeEvent, 1)
+			ret, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) { changed <- event })
+			require.NoError(t, err)
+			assertNotChanged(t, changed)
+			assert.Positive(t, cs.conditionalRequests())
+
+			cs.set("key: v2", `"v2"`, "Tue, 03 Jan 2006 15:04:05 GMT")
+			waitChanged(t, changed)
+			require.NoError(t, ret.Close(context.Background()))
+		})
+	}
+}
+
+func TestPollWithoutValidators(t *testing.T) {
+	cs := &configServer{}
+	cs.set("key: v1", "", "")
+	ts := httptest.NewServer(cs)
+	defer ts.Close()
+
+	fp := newPollingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	_, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	// The content is compared when the server does not support conditional requests.
+	assertNotChanged(t, changed)
+	assert.Zero(t, cs.conditionalRequests())
+
+	cs.set("key: v1", `"v1"`, "")
+	assertNotChanged(t, changed)
+
+	cs.set("key: v2", "", "")
+	waitChanged(t, changed)
+}
+
+func TestPollServerError(t *testing.T) {
+	cs := &configServer{}
+	cs.set("key: v1", `"v1"`, "")
+	ts := httptest.NewServer(cs)
+	defer ts.Close()
+
+	fp := newPollingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	_, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	// The errors are not notified, so the current configuration is kept.
+	cs.setStatus(http.StatusInternalServerError)
+	assertNotChanged(t, changed)
+
+	cs.setStatus(0)
+	cs.set("key: v2", `"v2"`, "")
+	waitChanged(t, changed)
+}
+
+func TestPollClose(t *testing.T) {
+	cs := &configServer{}
+	cs.set("key: v1", `"v1"`, "")
+	ts := httptest.NewServer(cs)
+	defer ts.Close()
+
+	fp := newPollingProvider(t)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	require.NoError(t, ret.Close(context.Background()))
+
+	cs.set("key: v2", `"v2"`, "")
+	assertNotChanged(t, changed)
+}
+
+func TestCache(t *testing.T) {
+	cs := &configServer{}
+	cs.set("key: v1", "", "")
+	ts := httptest.NewServer(cs)
+	defer ts.Close()
+
+	fp := newPollingProvider(t)
+	fp.cacheDir = filepath.Join(t.TempDir(), "cache")
+
+	// The server cannot be reached, and there is no cache.
+	cs.setStatus(http.StatusServiceUnavailable)
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.Error(t, err)
+
+	cs.setStatus(0)
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+
+	// A response that is not a configuration is not cached.
+	cs.set("not a configuration", "", "")
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+
+	cs.setStatus(http.StatusServiceUnavailable)
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	conf, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "v1"}, conf.ToStringMap())
+
+	// Once the server is reachable again, the configuration is reloaded only if it is different from the cached one.
+	cs.setStatus(0)
+	cs.set("key: v1", "", "")
+	assertNotChanged(t, changed)
+	cs.set("key: v2", "", "")
+	waitChanged(t, changed)
+}
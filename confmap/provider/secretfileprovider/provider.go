@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package secretfileprovider // import "go.opentelemetry.io/collector/confmap/provider/secretfileprovider"
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	schemeName = "secretfile"
+
+	defaultPollInterval = 10 * time.Second
+)
+
+type provider struct {
+	logger *zap.Logger
+	// pollInterval is the interval between two reads of a watched secret.
+	pollInterval time.Duration
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+	wg           sync.WaitGroup
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads secrets from files, such as the files of a
+// Kubernetes Secret mounted as a volume.
+//
+// This Provider supports "secretfile" scheme, and can be called with a "uri" that follows:
+//
+//	secretfile-uri	= "secretfile:" local-path
+//
+// The "local-path" can be relative or absolute, and it can be any OS supported format.
+//
+// Examples:
+// `secretfile:/etc/secrets/backend/token` - file, retrieved as a string without its trailing newline
+// `secretfile:/etc/secrets/backend` - directory, retrieved as a map of the names of the files it contains to
+// their contents, ignoring the hidden files and subdirectories
+//
+// The retrieved values are never parsed as YAML, and are marked as sensitive, so they are redacted when the
+// configuration is printed. The secret is polled for changes, and the watcher is called once its content changed,
+// so a rotated secret is reloaded without restarting the Collector.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(newProvider)
+}
+
+func newProvider(set confmap.ProviderSettings) confmap.Provider {
+	return &provider{
+		logger:       set.Logger,
+		pollInterval: defaultPollInterval,
+		shutdown:     make(chan struct{}),
+	}
+}
+
+func (sfp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	// Clean the path before using it.
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	value, sum, err := readSecret(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the secret %v: %w", uri, err)
+	}
+
+	opts := []confmap.RetrievedOption{confmap.WithRetrievedSensitive()}
+	if watcher != nil {
+		stop := sfp.watch(path, sum, watcher)
+		opts = append(opts, confmap.WithRetrievedClose(func(context.Context) error {
+			stop()
+			return nil
+		}))
+	}
+	return confmap.NewRetrieved(value, opts...)
+}
+
+// readSecret returns the value of the secret at the path, and a hash of the names and contents of its files.
+// A file is read as a string, and a directory as a map of the names of the files it contains to their contents.
+// Hidden files are ignored, so the files of Kubernetes Secret mounts, e.g. "..data", are read only once.
+func readSecret(path string) (any, [sha256.Size]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, [sha256.Size]byte{}, err
+	}
+
+	var names []string
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, [sha256.Size]byte{}, err
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			// Follow the symbolic links, and skip the directories.
+			if info, err := os.Stat(filepath.Join(path, entry.Name())); err != nil || info.IsDir() {
+				continue
+			}
+			names = append(names, entry.Name())
+		}
+		slices.Sort(names)
+	}
+
+	h := sha256.New()
+	read := func(file string) (string, error) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		fileSum := sha256.Sum256(content)
+		h.Write([]byte(file))
+		h.Write(fileSum[:])
+		return trimNewline(string(content)), nil
+	}
+
+	var value any
+	if info.IsDir() {
+		values := make(map[string]any, len(names))
+		for _, name := range names {
+			if values[name], err = read(filepath.Join(path, name)); err != nil {
+				return nil, [sha256.Size]byte{}, err
+			}
+		}
+		value = values
+	} else if value, err = read(path); err != nil {
+		return nil, [sha256.Size]byte{}, err
+	}
+
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	return value, sum, nil
+}
+
+// trimNewline removes the trailing newline added by most editors and by `echo`, which is not part of the secret.
+func trimNewline(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
+
+// watch polls the secret at the path until its files are different from the retrieved ones, then calls the watcher
+// once. It returns a function stopping the polling.
+func (sfp *provider) watch(path string, retrieved [sha256.Size]byte, watcher confmap.WatcherFunc) func() {
+	stop := make(chan struct{})
+	sfp.wg.Add(1)
+	go func() {
+		defer sfp.wg.Done()
+		ticker := time.NewTicker(sfp.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-sfp.shutdown:
+				return
+			case <-ticker.C:
+			}
+
+			_, current, err := readSecret(path)
+			if err != nil {
+				// The secret may be in the middle of being replaced, wait until it is readable again.
+				sfp.logger.Debug("Failed to read the watched secret", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			if current != retrieved {
+				sfp.logger.Info("Secret changed", zap.String("path", path))
+				watcher(&confmap.ChangeEvent{})
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(stop) }) }
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+// Shutdown stops watching the secrets and waits until the watchers are no longer called.
+func (sfp *provider) Shutdown(context.Context) error {
+	sfp.shutdownOnce.Do(func() { close(sfp.shutdown) })
+	sfp.wg.Wait()
+	return nil
+}
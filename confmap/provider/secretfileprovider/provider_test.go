@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretfileprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+const secretFileSchemePrefix = schemeName + ":"
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(createProvider()))
+}
+
+func TestEmptyName(t *testing.T) {
+	sfp := createProvider()
+	_, err := sfp.Retrieve(context.Background(), "", nil)
+	require.Error(t, err)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	sfp := createProvider()
+	_, err := sfp.Retrieve(context.Background(), "file:/path/to/secret", nil)
+	require.Error(t, err)
+	assert.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestNonExistent(t *testing.T) {
+	sfp := createProvider()
+	_, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+filepath.Join(t.TempDir(), "non-existent"), nil)
+	require.Error(t, err)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("key: s3cr3t\n"), 0o600))
+
+	sfp := createProvider()
+	ret, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+path, nil)
+	require.NoError(t, err)
+	// The content is not parsed as YAML, and the trailing newline is removed.
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "key: s3cr3t", raw)
+	str, err := ret.AsString()
+	require.NoError(t, err)
+	assert.Equal(t, "key: s3cr3t", str)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "username"), []byte("admin"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("s3cr3t\r\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("ignored"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o700))
+
+	sfp := createProvider()
+	ret, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+dir, nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"username": "admin", "password": "s3cr3t"}, raw)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestRedacted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("s3cr3t\n"), 0o600))
+
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs: []string{"yaml:headers:\n  authorization: Bearer ${secretfile:" + path + "}"},
+		ProviderFactories: []confmap.ProviderFactory{
+			NewFactory(),
+			confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider { return &yamlProvider{} }),
+		},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer s3cr3t", conf.Get("headers::authorization"))
+	// The whole value is redacted, so the length and the position of the secret are not disclosed.
+	assert.Equal(t, "[REDACTED]", resolver.Redact(conf).Get("headers::authorization"))
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestWatchChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	sfp := createWatchingProvider()
+	changed := make(chan *confmap.ChangeEvent, 2)
+	ret, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	assertNotChanged(t, changed)
+
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	assertChanged(t, changed)
+	require.NoError(t, os.WriteFile(path, []byte("v3"), 0o600))
+	assertNotChanged(t, changed)
+
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestWatchKubernetesSecretRotation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symbolic links requires privileges on windows")
+	}
+	// Reproduce the layout of the Kubernetes Secret mounts, where the files are links to a link to a directory,
+	// which is atomically replaced on updates.
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "v1"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v1", "token"), []byte("v1"), 0o600))
+	require.NoError(t, os.Symlink("v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "token"), filepath.Join(dir, "token")))
+
+	sfp := createWatchingProvider()
+	changed := make(chan *confmap.ChangeEvent, 2)
+	ret, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+dir, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"token": "v1"}, raw)
+	assertNotChanged(t, changed)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "v2"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v2", "token"), []byte("v2"), 0o600))
+	require.NoError(t, os.Symlink("v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	assertChanged(t, changed)
+
+	require.NoError(t, ret.Close(context.Background()))
+	ret, err = sfp.Retrieve(context.Background(), secretFileSchemePrefix+dir, nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"token": "v2"}, raw)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestWatchNewKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("v1"), 0o600))
+
+	sfp := createWatchingProvider()
+	changed := make(chan *confmap.ChangeEvent, 1)
+	_, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+dir, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("v1"), 0o600))
+	assertChanged(t, changed)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	sfp := createWatchingProvider()
+	changed := make(chan *confmap.ChangeEvent, 1)
+	_, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+
+	// A missing file is not a change, it may be in the middle of being replaced.
+	require.NoError(t, os.Remove(path))
+	assertNotChanged(t, changed)
+
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	assertChanged(t, changed)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+func TestWatchClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	sfp := createWatchingProvider()
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := sfp.Retrieve(context.Background(), secretFileSchemePrefix+path, func(event *confmap.ChangeEvent) { changed <- event })
+	require.NoError(t, err)
+	require.NoError(t, ret.Close(context.Background()))
+
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	assertNotChanged(t, changed)
+	require.NoError(t, sfp.Shutdown(context.Background()))
+}
+
+// yamlProvider retrieves the configuration from the YAML in the uri.
+type yamlProvider struct{}
+
+func (*yamlProvider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	return confmap.NewRetrievedFromYAML([]byte(uri[len("yaml:"):]))
+}
+
+func (*yamlProvider) Scheme() string {
+	return "yaml"
+}
+
+func (*yamlProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+func createProvider() confmap.Provider {
+	return NewFactory().Create(confmaptest.NewNopProviderSettings())
+}
+
+func createWatchingProvider() *provider {
+	sfp := createProvider().(*provider)
+	sfp.pollInterval = 10 * time.Millisecond
+	return sfp
+}
+
+func assertChanged(t *testing.T, changed <-chan *confmap.ChangeEvent) {
+	select {
+	case event := <-changed:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+}
+
+func assertNotChanged(t *testing.T, changed <-chan *confmap.ChangeEvent) {
+	select {
+	case <-changed:
+		t.Fatal("unexpected change notified")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+// redactedValue replaces the sensitive values, the same way as the configopaque.String values are marshaled.
+const redactedValue = "[REDACTED]"
+
+// recordSensitive records that the value being expanded contains a sensitive Retrieved.
+func (mr *Resolver) recordSensitive(ret *Retrieved) {
+	if ret.sensitive {
+		mr.expandedSensitive = true
+	}
+}
+
+// Redact returns a copy of the configuration where the values of the keys expanded from a ${} reference retrieved by
+// a provider with the WithRetrievedSensitive option, during the last call to Resolve, are replaced by "[REDACTED]".
+// The whole value of the key is redacted, including when the reference is part of a longer string, e.g.
+// "Bearer ${secretfile:token}", and all the values under the key if it expands to a map or a list.
+//
+// It can be called after Shutdown, and should never be called concurrently with Resolve.
+func (mr *Resolver) Redact(conf *Conf) *Conf {
+	if len(mr.sensitivePaths) == 0 {
+		return conf
+	}
+	return NewFromStringMap(mr.redact(conf.ToStringMap(), "", false).(map[string]any))
+}
+
+func (mr *Resolver) redact(v any, path string, sensitive bool) any {
+	if !sensitive {
+		_, sensitive = mr.sensitivePaths[path]
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		ret := make(map[string]any, len(v))
+		for k, e := range v {
+			ret[k] = mr.redact(e, joinPath(path, k), sensitive)
+		}
+		return ret
+	case []any:
+		if v == nil {
+			return v
+		}
+		ret := make([]any, len(v))
+		for i, e := range v {
+			ret[i] = mr.redact(e, path, sensitive)
+		}
+		return ret
+	case nil:
+		return nil
+	default:
+		if sensitive {
+			return redactedValue
+		}
+		return v
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverRedact(t *testing.T) {
+	inputProvider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(map[string]any{
+			"token":   "${secret:token}",
+			"header":  "Bearer ${secret:token}",
+			"port":    "${secret:port}",
+			"keys":    "${secret:keys}",
+			"user":    "${env:USER}",
+			"missing": nil,
+		})
+	})
+	secretProvider := newFakeProvider("secret", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		switch strings.TrimPrefix(uri, "secret:") {
+		case "token":
+			return NewRetrieved("s3cr3t", WithRetrievedSensitive())
+		case "port":
+			return NewRetrieved("4317", WithRetrievedSensitive())
+		default:
+			return NewRetrieved(map[string]any{"a": "key-a", "b": []any{"key-b"}}, WithRetrievedSensitive())
+		}
+	})
+	envProvider := newFakeProvider("env", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved("alice")
+	})
+
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"input:"},
+		ProviderFactories: []ProviderFactory{inputProvider, secretProvider, envProvider},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, resolver.Shutdown(context.Background()))
+
+	// The resolved configuration is not modified.
+	assert.Equal(t, "s3cr3t", conf.Get("token"))
+
+	// Only the keys expanded from the sensitive values are redacted, not the other values equal to them.
+	require.NoError(t, conf.Merge(NewFromStringMap(map[string]any{"number": 4317, "password": "s3cr3t"})))
+	assert.Equal(t, map[string]any{
+		"token":    "[REDACTED]",
+		"header":   "[REDACTED]",
+		"port":     "[REDACTED]",
+		"number":   4317,
+		"password": "s3cr3t",
+		"keys":     map[string]any{"a": "[REDACTED]", "b": []any{"[REDACTED]"}},
+		"user":     "alice",
+		"missing":  nil,
+	}, resolver.Redact(conf).ToStringMap())
+}
+
+func TestResolverRedactNoSensitiveValues(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{filepath.Join("testdata", "config.yaml")},
+		ProviderFactories: []ProviderFactory{newFileProvider(t)},
+		DefaultScheme:     "file",
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conf.ToStringMap(), resolver.Redact(conf).ToStringMap())
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -26,9 +27,20 @@ type Resolver struct {
 	providers     map[string]Provider
 	defaultScheme string
 	converters    []Converter
+	mergeRules    *internal.ListMergeRules
 
 	closers []CloseFunc
-	watcher chan error
+	// sensitivePaths holds the keys whose values were expanded from sensitive values by the last call to Resolve.
+	sensitivePaths map[string]struct{}
+	// expandedSensitive is set when a sensitive value is expanded, while expanding the value of a key.
+	expandedSensitive bool
+	watcher           chan error
+	// watcherMu guards watcherClosed, so providers calling the watcher from their own
+	// goroutines never send to the watcher channel after Shutdown closed it, and
+	// watcherDone releases the ones blocked sending to it.
+	watcherMu     sync.RWMutex
+	watcherClosed bool
+	watcherDone   chan struct{}
 }
 
 // ResolverSettings are the settings to configure the behavior of the Resolver.
@@ -58,6 +70,11 @@ type ResolverSettings struct {
 	// factories when instantiating Converters.
 	ConverterSettings ConverterSettings
 
+	// ListMergeRules set how the lists are merged when merging the configurations retrieved from the URIs.
+	// When several rules match the path of a list, the first one is used. The lists not matching any rule
+	// are replaced, unless the "confmap.enableMergeAppendOption" feature gate is enabled.
+	ListMergeRules []ListMergeRule
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -121,6 +138,11 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		}
 	}
 
+	mergeRules, err := internal.NewListMergeRules(set.ListMergeRules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'confmap.ResolverSettings' configuration: %w", err)
+	}
+
 	converters := make([]Converter, len(set.ConverterFactories))
 	for i, factory := range set.ConverterFactories {
 		converters[i] = factory.Create(set.ConverterSettings)
@@ -151,7 +173,9 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		providers:     providers,
 		defaultScheme: set.DefaultScheme,
 		converters:    converters,
+		mergeRules:    mergeRules,
 		watcher:       make(chan error, 1),
+		watcherDone:   make(chan struct{}),
 	}, nil
 }
 
@@ -162,6 +186,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 	if err := mr.closeIfNeeded(ctx); err != nil {
 		return nil, fmt.Errorf("cannot close previous watch: %w", err)
 	}
+	mr.sensitivePaths = map[string]struct{}{}
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
@@ -171,23 +196,30 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 			return nil, fmt.Errorf("cannot retrieve the configuration: %w", err)
 		}
 		mr.closers = append(mr.closers, ret.Close)
-		retCfgMap, err := ret.AsConf()
-		if err != nil {
+		if err := mr.mergeRetrieved(retMap, ret); err != nil {
 			return nil, err
 		}
+	}
 
-		if err := retMap.Merge(retCfgMap); err != nil {
-			return nil, err
+	if internal.EnableDirectives.IsEnabled() {
+		directivesApplied, err := mr.applyDirectives(ctx, retMap.ToStringMap())
+		if err != nil {
+			return nil, fmt.Errorf("cannot apply the directives: %w", err)
 		}
+		retMap = NewFromStringMap(directivesApplied)
 	}
 
 	cfgMap := make(map[string]any)
 	for _, k := range retMap.AllKeys() {
 		ug := internal.UnsanitizedGetter{Conf: retMap}
+		mr.expandedSensitive = false
 		val, err := mr.expandValueRecursively(ctx, ug.UnsanitizedGet(k))
 		if err != nil {
 			return nil, err
 		}
+		if mr.expandedSensitive {
+			mr.sensitivePaths[k] = struct{}{}
+		}
 		cfgMap[k] = escapeDollarSigns(val)
 	}
 	retMap = NewFromStringMap(cfgMap)
@@ -202,6 +234,23 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 	return retMap, nil
 }
 
+// mergeRetrieved merges the retrieved configuration into retMap, or its fragments in order if it has any.
+func (mr *Resolver) mergeRetrieved(retMap *Conf, ret *Retrieved) error {
+	if len(ret.fragments) > 0 {
+		for _, fragment := range ret.fragments {
+			if err := mr.mergeRetrieved(retMap, fragment); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	retCfgMap, err := ret.AsConf()
+	if err != nil {
+		return err
+	}
+	return internal.MergeWithRules(retMap, retCfgMap, mr.mergeRules)
+}
+
 func escapeDollarSigns(val any) any {
 	switch v := val.(type) {
 	case string:
@@ -243,7 +292,11 @@ func (mr *Resolver) Watch() <-chan error {
 //
 // Should never be called concurrently with itself or Get.
 func (mr *Resolver) Shutdown(ctx context.Context) error {
+	close(mr.watcherDone)
+	mr.watcherMu.Lock()
+	mr.watcherClosed = true
 	close(mr.watcher)
+	mr.watcherMu.Unlock()
 
 	var errs error
 	errs = multierr.Append(errs, mr.closeIfNeeded(ctx))
@@ -255,7 +308,15 @@ func (mr *Resolver) Shutdown(ctx context.Context) error {
 }
 
 func (mr *Resolver) onChange(event *ChangeEvent) {
-	mr.watcher <- event.Error
+	mr.watcherMu.RLock()
+	defer mr.watcherMu.RUnlock()
+	if mr.watcherClosed {
+		return
+	}
+	select {
+	case mr.watcher <- event.Error:
+	case <-mr.watcherDone:
+	}
 }
 
 func (mr *Resolver) closeIfNeeded(ctx context.Context) error {
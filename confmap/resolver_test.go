@@ -411,6 +411,33 @@ func TestResolverShutdownClosesWatch(t *testing.T) {
 	watcherWG.Wait()
 }
 
+func TestResolverWatcherCalledAfterShutdown(t *testing.T) {
+	var watcher WatcherFunc
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{filepath.Join("testdata", "config.yaml")},
+		ProviderFactories: []ProviderFactory{newFakeProvider("file", func(_ context.Context, _ string, w WatcherFunc) (*Retrieved, error) {
+			watcher = w
+			return NewRetrieved(map[string]any{})
+		})},
+		ConverterFactories: nil,
+	})
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	// The first change fills the watch channel, the second one blocks until the shutdown.
+	watcher(&ChangeEvent{})
+	blocked := make(chan struct{})
+	go func() {
+		watcher(&ChangeEvent{})
+		close(blocked)
+	}()
+
+	require.NoError(t, resolver.Shutdown(context.Background()))
+	<-blocked
+	assert.NotPanics(t, func() { watcher(&ChangeEvent{}) })
+}
+
 func TestProvidesDefaultLogger(t *testing.T) {
 	factory, provider := newObservableFileProvider(t)
 	_, err := NewResolver(ResolverSettings{
@@ -443,6 +470,7 @@ func TestResolverDefaultProviderSet(t *testing.T) {
 type mergeTest struct {
 	Name        string           `yaml:"name"`
 	AppendPaths []string         `yaml:"append_paths"`
+	Rules       []string         `yaml:"rules"`
 	Configs     []map[string]any `yaml:"configs"`
 	Expected    map[string]any   `yaml:"expected"`
 }
@@ -478,6 +506,66 @@ func TestMergeFunctionality(t *testing.T) {
 	}
 }
 
+func TestListMergeRules(t *testing.T) {
+	runScenario(t, "testdata/merge-list-rules-scenarios.yaml")
+}
+
+func TestListMergeRulesWithMergeAppendOption(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(internal.EnableMergeAppendOption.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(internal.EnableMergeAppendOption.ID(), false))
+	}()
+
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"mock:first", "mock:second"},
+		ProviderFactories: []ProviderFactory{newFakeProvider("mock", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+			if uri == "mock:first" {
+				return NewRetrievedFromYAML([]byte("service: {extensions: [nop], pipelines: {traces: {receivers: [nop]}}}"))
+			}
+			return NewRetrievedFromYAML([]byte("service: {extensions: [nop2], pipelines: {traces: {receivers: [nop, nop2]}}}"))
+		})},
+		ListMergeRules: []ListMergeRule{{Path: "service::extensions", Strategy: ListMergeReplace}},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	// The rules take precedence over the feature gate, which still applies to the other lists.
+	assert.Equal(t, []any{"nop2"}, conf.Get("service::extensions"))
+	assert.Equal(t, []any{"nop", "nop2"}, conf.Get("service::pipelines::traces::receivers"))
+}
+
+func TestListMergeRulesRetrievedFragments(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"mock:first", "mock:second"},
+		ProviderFactories: []ProviderFactory{newFakeProvider("mock", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+			if uri == "mock:first" {
+				return NewRetrievedFromYAML([]byte("service: {extensions: [nop]}"))
+			}
+			first, err := NewRetrievedFromYAML([]byte("service: {extensions: [nop2]}"))
+			require.NoError(t, err)
+			second, err := NewRetrievedFromYAML([]byte("service: {extensions: [nop3]}"))
+			require.NoError(t, err)
+			// The merged configuration is ignored by the resolver.
+			return NewRetrieved(map[string]any{"service": map[string]any{"extensions": []any{"nop3"}}},
+				WithRetrievedFragments(first, second))
+		})},
+		ListMergeRules: []ListMergeRule{{Path: "service::extensions", Strategy: ListMergeAppend}},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"nop", "nop2", "nop3"}, conf.Get("service::extensions"))
+}
+
+func TestInvalidListMergeRules(t *testing.T) {
+	_, err := NewResolver(ResolverSettings{
+		URIs:              []string{filepath.Join("testdata", "config.yaml")},
+		ProviderFactories: []ProviderFactory{newFileProvider(t)},
+		ListMergeRules:    []ListMergeRule{{Path: "service::extensions", Strategy: "prepend"}},
+	})
+	require.ErrorContains(t, err, `unknown strategy "prepend"`)
+}
+
 func runScenario(t *testing.T, path string) {
 	yamlData, err := os.ReadFile(filepath.Clean(path))
 	require.NoError(t, err)
@@ -500,10 +588,18 @@ func runScenario(t *testing.T, path string) {
 				configFiles = append(configFiles, file.Name())
 			}
 
+			var rules []ListMergeRule
+			for _, r := range tt.Rules {
+				rule, err := ParseListMergeRule(r)
+				require.NoError(t, err)
+				rules = append(rules, rule)
+			}
+
 			resolver, err := NewResolver(ResolverSettings{
 				URIs:              configFiles,
 				ProviderFactories: []ProviderFactory{newFileProvider(t)},
 				DefaultScheme:     "file",
+				ListMergeRules:    rules,
 			})
 			require.NoError(t, err)
 			conf, err := resolver.Resolve(context.Background())
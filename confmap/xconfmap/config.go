@@ -25,6 +25,8 @@ type Validator interface {
 
 // Validate validates a config, by doing this:
 //   - Call Validate on the config itself if the config implements ConfigValidator.
+//
+// The returned error joins a PathError for each invalid value of the config.
 func Validate(cfg any) error {
 	var err error
 
@@ -35,35 +37,40 @@ func Validate(cfg any) error {
 	return err
 }
 
-type pathError struct {
-	err  error
+// PathError is the error of an invalid value of a config, with the path of the value.
+type PathError struct {
+	err error
+	// path holds the keys of the path in reverse order.
 	path []string
 }
 
-func (pe pathError) Error() string {
-	if len(pe.path) > 0 {
-		var path string
-		sb := strings.Builder{}
-
-		_, _ = sb.WriteString(pe.path[len(pe.path)-1])
-		for i := len(pe.path) - 2; i >= 0; i-- {
+// Path returns the keys of the path of the invalid value, from the validated config, separated by
+// confmap.KeyDelimiter. It is empty if the validated config itself is invalid.
+func (pe PathError) Path() string {
+	sb := strings.Builder{}
+	for i := len(pe.path) - 1; i >= 0; i-- {
+		_, _ = sb.WriteString(pe.path[i])
+		if i > 0 {
 			_, _ = sb.WriteString(confmap.KeyDelimiter)
-			_, _ = sb.WriteString(pe.path[i])
 		}
-		path = sb.String()
+	}
+	return sb.String()
+}
 
-		return fmt.Sprintf("%s: %s", path, pe.err)
+func (pe PathError) Error() string {
+	if len(pe.path) > 0 {
+		return fmt.Sprintf("%s: %s", pe.Path(), pe.err)
 	}
 
 	return pe.err.Error()
 }
 
-func (pe pathError) Unwrap() error {
+func (pe PathError) Unwrap() error {
 	return pe.err
 }
 
-func validate(v reflect.Value) []pathError {
-	errs := []pathError{}
+func validate(v reflect.Value) []PathError {
+	errs := []PathError{}
 	// Validate the value itself.
 	switch v.Kind() {
 	case reflect.Invalid:
@@ -73,7 +80,7 @@ func validate(v reflect.Value) []pathError {
 	case reflect.Struct:
 		err := callValidateIfPossible(v)
 		if err != nil {
-			errs = append(errs, pathError{err: err})
+			errs = append(errs, PathError{err: err})
 		}
 
 		// Reflect on the pointed data and check each of its fields.
@@ -86,7 +93,7 @@ func validate(v reflect.Value) []pathError {
 
 			subpathErrs := validate(v.Field(i))
 			for _, err := range subpathErrs {
-				errs = append(errs, pathError{
+				errs = append(errs, PathError{
 					err:  err.err,
 					path: append(err.path, path),
 				})
@@ -96,7 +103,7 @@ func validate(v reflect.Value) []pathError {
 	case reflect.Slice, reflect.Array:
 		err := callValidateIfPossible(v)
 		if err != nil {
-			errs = append(errs, pathError{err: err})
+			errs = append(errs, PathError{err: err})
 		}
 
 		// Reflect on the pointed data and check each of its fields.
@@ -104,7 +111,7 @@ func validate(v reflect.Value) []pathError {
 			subPathErrs := validate(v.Index(i))
 
 			for _, err := range subPathErrs {
-				errs = append(errs, pathError{
+				errs = append(errs, PathError{
 					err:  err.err,
 					path: append(err.path, strconv.Itoa(i)),
 				})
@@ -114,7 +121,7 @@ func validate(v reflect.Value) []pathError {
 	case reflect.Map:
 		err := callValidateIfPossible(v)
 		if err != nil {
-			errs = append(errs, pathError{err: err})
+			errs = append(errs, PathError{err: err})
 		}
 
 		iter := v.MapRange()
@@ -124,18 +131,18 @@ func validate(v reflect.Value) []pathError {
 			key := stringifyMapKey(iter.Key())
 
 			for _, err := range keyErrs {
-				errs = append(errs, pathError{err: err.err, path: append(err.path, key)})
+				errs = append(errs, PathError{err: err.err, path: append(err.path, key)})
 			}
 
 			for _, err := range valueErrs {
-				errs = append(errs, pathError{err: err.err, path: append(err.path, key)})
+				errs = append(errs, PathError{err: err.err, path: append(err.path, key)})
 			}
 		}
 		return errs
 	default:
 		err := callValidateIfPossible(v)
 		if err != nil {
-			return []pathError{{err: err}}
+			return []PathError{{err: err}}
 		}
 
 		return nil
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type configChildStruct struct {
@@ -321,3 +322,25 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePathErrors(t *testing.T) {
+	err := Validate(configChildStruct{
+		Child:    errValidateConfig{err: errors.New("child error")},
+		ChildPtr: &errValidateConfig{err: errors.New("child ptr error")},
+	})
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+
+	paths := map[string]string{}
+	for _, e := range joined.Unwrap() {
+		var pe PathError
+		require.ErrorAs(t, e, &pe)
+		paths[pe.Path()] = pe.Unwrap().Error()
+	}
+	assert.Equal(t, map[string]string{"child": "child error", "childptr": "child ptr error"}, paths)
+
+	var pe PathError
+	require.ErrorAs(t, Validate(&errValidateConfig{err: errors.New("root error")}), &pe)
+	assert.Empty(t, pe.Path())
+	assert.EqualError(t, pe, "root error")
+}
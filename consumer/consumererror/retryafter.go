@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+	"time"
+)
+
+// retryAfter is an error that indicates the source requested to wait before retrying.
+type retryAfter struct {
+	err   error
+	delay time.Duration
+}
+
+// NewRetryAfter wraps an error to indicate that the source is throttling, and the
+// operation should not be retried before the given delay elapses. This is usually
+// set from the `RetryInfo` gRPC error details or the `Retry-After` HTTP header.
+//
+// Experimental: *NOTE* this function is subject to change or removal in the future.
+func NewRetryAfter(err error, delay time.Duration) error {
+	return retryAfter{err: err, delay: delay}
+}
+
+func (r retryAfter) Error() string {
+	return "Throttle (" + r.delay.String() + "), error: " + r.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (r retryAfter) Unwrap() error {
+	return r.err
+}
+
+// RetryAfter returns the delay requested by the source of an error wrapped with the
+// NewRetryAfter function, and true. If the error was not wrapped, it returns false.
+//
+// Experimental: *NOTE* this function is subject to change or removal in the future.
+func RetryAfter(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var ra retryAfter
+	if !errors.As(err, &ra) {
+		return 0, false
+	}
+	return ra.delay, true
+}
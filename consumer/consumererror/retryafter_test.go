@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	var err error
+	_, ok := RetryAfter(err)
+	assert.False(t, ok)
+
+	err = errors.New("testError")
+	_, ok = RetryAfter(err)
+	assert.False(t, ok)
+
+	err = NewRetryAfter(err, 5*time.Second)
+	assert.Equal(t, "Throttle (5s), error: testError", err.Error())
+	delay, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	err = NewPermanent(fmt.Errorf("%w", err))
+	delay, ok = RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+}
+
+func TestRetryAfter_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	retryAfterErr := NewRetryAfter(err, time.Second)
+
+	target := testErrorType{}
+	require.ErrorAs(t, retryAfterErr, &target)
+	require.Equal(t, err, target)
+}
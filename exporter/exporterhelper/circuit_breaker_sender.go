@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// CircuitBreakerConfig defines configuration for the circuit breaker and the retry budget of an exporter.
+type CircuitBreakerConfig = internal.CircuitBreakerConfig
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+func NewDefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return internal.NewDefaultCircuitBreakerConfig()
+}
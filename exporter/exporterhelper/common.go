@@ -37,6 +37,12 @@ func WithRetry(config configretry.BackOffConfig) Option {
 	return internal.WithRetry(config)
 }
 
+// WithCircuitBreaker overrides the default CircuitBreakerConfig for an exporter.
+// The default CircuitBreakerConfig is to disable the circuit breaker.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return internal.WithCircuitBreaker(config)
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
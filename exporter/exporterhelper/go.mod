@@ -8,6 +8,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	go.opentelemetry.io/collector/client v1.41.0
 	go.opentelemetry.io/collector/component v1.41.0
+	go.opentelemetry.io/collector/component/componentstatus v0.135.0
 	go.opentelemetry.io/collector/component/componenttest v0.135.0
 	go.opentelemetry.io/collector/config/configoptional v0.135.0
 	go.opentelemetry.io/collector/config/configretry v1.41.0
@@ -18,8 +19,10 @@ require (
 	go.opentelemetry.io/collector/consumer/consumertest v0.135.0
 	go.opentelemetry.io/collector/exporter v0.135.0
 	go.opentelemetry.io/collector/exporter/exportertest v0.135.0
+	go.opentelemetry.io/collector/exporter/xexporter v0.135.0
 	go.opentelemetry.io/collector/extension/extensiontest v0.135.0
 	go.opentelemetry.io/collector/extension/xextension v0.135.0
+	go.opentelemetry.io/collector/extension/xextension/storage/storagetest v0.135.0
 	go.opentelemetry.io/collector/featuregate v1.41.0
 	go.opentelemetry.io/collector/pdata v1.41.0
 	go.opentelemetry.io/collector/pdata/pprofile v0.135.0
@@ -55,7 +58,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/consumer/xconsumer v0.135.0 // indirect
-	go.opentelemetry.io/collector/exporter/xexporter v0.135.0 // indirect
 	go.opentelemetry.io/collector/extension v1.41.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.135.0 // indirect
 	go.opentelemetry.io/collector/receiver v1.41.0 // indirect
@@ -113,6 +115,8 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
 
 replace go.opentelemetry.io/collector/internal/telemetry => ../../internal/telemetry
@@ -126,3 +130,5 @@ replace go.opentelemetry.io/collector/confmap => ../../confmap
 replace go.opentelemetry.io/collector/config/configoptional => ../../config/configoptional
 
 replace go.opentelemetry.io/collector/confmap/xconfmap => ../../confmap/xconfmap
+
+replace go.opentelemetry.io/collector/component/componentstatus => ../../component/componentstatus
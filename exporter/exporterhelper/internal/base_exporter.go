@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -39,15 +40,19 @@ type BaseExporter struct {
 	QueueSender sender.Sender[request.Request]
 	RetrySender sender.Sender[request.Request]
 
-	firstSender sender.Sender[request.Request]
+	circuitBreakerSender sender.Sender[request.Request]
+	concurrencySender    sender.Sender[request.Request]
+	firstSender          sender.Sender[request.Request]
 
 	ConsumerOptions []consumer.Option
 
-	timeoutCfg TimeoutConfig
-	retryCfg   configretry.BackOffConfig
+	timeoutCfg        TimeoutConfig
+	retryCfg          configretry.BackOffConfig
+	circuitBreakerCfg CircuitBreakerConfig
 
 	queueBatchSettings queuebatch.Settings[request.Request]
 	queueCfg           queuebatch.Config
+	prioritizer        queuebatch.Prioritizer[request.Request]
 }
 
 func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sender.SendFunc[request.Request], options ...Option) (*BaseExporter, error) {
@@ -71,12 +76,28 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 		be.firstSender = newTimeoutSender(be.timeoutCfg, be.firstSender)
 	}
 
+	var err error
+	// Next setup the concurrency Sender after the retry Sender, so it limits the individual attempts to send data.
+	if be.queueCfg.Enabled && be.queueCfg.AdaptiveConcurrency.HasValue() {
+		be.concurrencySender, err = newConcurrencySender(*be.queueCfg.AdaptiveConcurrency.Get(), be.queueCfg.NumConsumers, set, signal, be.firstSender)
+		if err != nil {
+			return nil, err
+		}
+		be.firstSender = be.concurrencySender
+	}
+
+	// Next setup the circuit breaker Sender after the retry Sender, so it observes the individual attempts to send data
+	// and every attempt is failed fast while the circuit is open.
+	if be.circuitBreakerCfg.Enabled {
+		be.circuitBreakerSender = newCircuitBreakerSender(be.circuitBreakerCfg, set, be.firstSender)
+		be.firstSender = be.circuitBreakerSender
+	}
+
 	if be.retryCfg.Enabled {
 		be.RetrySender = newRetrySender(be.retryCfg, set, be.firstSender)
 		be.firstSender = be.RetrySender
 	}
 
-	var err error
 	be.firstSender, err = newObsReportSender(set, signal, be.firstSender)
 	if err != nil {
 		return nil, err
@@ -88,6 +109,9 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 	}
 
 	if be.queueCfg.Enabled {
+		if be.prioritizer != nil {
+			be.queueBatchSettings.Prioritizer = be.prioritizer
+		}
 		qSet := queuebatch.AllSettings[request.Request]{
 			Settings:  be.queueBatchSettings,
 			Signal:    signal,
@@ -123,6 +147,13 @@ func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	// Then start the circuit breaker, so it can report its state using the host.
+	if be.circuitBreakerSender != nil {
+		if err := be.circuitBreakerSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	// Last start the QueueBatch.
 	if be.QueueSender != nil {
 		return be.QueueSender.Start(ctx, host)
@@ -144,10 +175,51 @@ func (be *BaseExporter) Shutdown(ctx context.Context) error {
 		err = multierr.Append(err, be.QueueSender.Shutdown(ctx))
 	}
 
+	// Then shutdown the concurrency and circuit breaker senders, after the queue is drained.
+	if be.concurrencySender != nil {
+		err = multierr.Append(err, be.concurrencySender.Shutdown(ctx))
+	}
+	if be.circuitBreakerSender != nil {
+		err = multierr.Append(err, be.circuitBreakerSender.Shutdown(ctx))
+	}
+
 	// Last shutdown the wrapped exporter itself.
 	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
 
+// Drain waits for the queue to be exported, or spilled according to the mode, before the exporter is shut down.
+// The retries are still enabled while draining.
+func (be *BaseExporter) Drain(ctx context.Context, mode xexporter.DrainMode) error {
+	if drainer, ok := be.QueueSender.(xexporter.Drainer); ok {
+		return drainer.Drain(ctx, mode)
+	}
+	return nil
+}
+
+// ValidateSpill returns an error if the queue cannot keep the requests left in its memory when drained in spill mode.
+func (be *BaseExporter) ValidateSpill() error {
+	if validator, ok := be.QueueSender.(xexporter.SpillValidator); ok {
+		return validator.ValidateSpill()
+	}
+	return nil
+}
+
+// InspectDeadLetters returns the requests stored in the dead letter queue of the queue.
+func (be *BaseExporter) InspectDeadLetters(ctx context.Context, limit int) ([]xexporter.DeadLetter, error) {
+	if dlq, ok := be.QueueSender.(xexporter.DeadLetterQueue); ok {
+		return dlq.InspectDeadLetters(ctx, limit)
+	}
+	return nil, xexporter.ErrNoDeadLetterQueue
+}
+
+// ReplayDeadLetters puts the requests stored in the dead letter queue of the queue back in the queue.
+func (be *BaseExporter) ReplayDeadLetters(ctx context.Context, indexes ...uint64) (int, error) {
+	if dlq, ok := be.QueueSender.(xexporter.DeadLetterQueue); ok {
+		return dlq.ReplayDeadLetters(ctx, indexes...)
+	}
+	return 0, xexporter.ErrNoDeadLetterQueue
+}
+
 // WithStart overrides the default Start function for an exporter.
 // The default start function does nothing and always returns nil.
 func WithStart(start component.StartFunc) Option {
@@ -188,6 +260,15 @@ func WithRetry(config configretry.BackOffConfig) Option {
 	}
 }
 
+// WithCircuitBreaker overrides the default CircuitBreakerConfig for an exporter.
+// The default CircuitBreakerConfig is to disable the circuit breaker.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(o *BaseExporter) error {
+		o.circuitBreakerCfg = config
+		return nil
+	}
+}
+
 // WithQueue overrides the default queuebatch.Config for an exporter.
 // The default queuebatch.Config is to disable queueing.
 // This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
@@ -213,12 +294,24 @@ func WithQueueBatch(cfg queuebatch.Config, set queuebatch.Settings[request.Reque
 		if cfg.StorageID != nil && set.Encoding == nil {
 			return errors.New("`Settings.Encoding` must not be nil when persistent queue is enabled")
 		}
+		if cfg.DeadLetter.HasValue() && set.Encoding == nil {
+			return errors.New("`Settings.Encoding` must not be nil when dead letter queue is enabled")
+		}
 		o.queueBatchSettings = set
 		o.queueCfg = cfg
 		return nil
 	}
 }
 
+// WithQueuePrioritizer sets the prioritizer returning the name of the priority class of a request, used by the queue
+// when priority classes are configured without a metadata key. It overrides the one of the queuebatch.Settings.
+func WithQueuePrioritizer(prioritizer queuebatch.Prioritizer[request.Request]) Option {
+	return func(o *BaseExporter) error {
+		o.prioritizer = prioritizer
+		return nil
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -6,7 +6,10 @@ package internal
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,11 +18,13 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -88,6 +93,128 @@ func TestBaseExporterLogging(t *testing.T) {
 	require.NoError(t, bs.Shutdown(context.Background()))
 }
 
+func TestBaseExporterAdaptiveConcurrency(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.WaitForResult = true
+	qCfg.AdaptiveConcurrency = configoptional.Some(queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5})
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NotNil(t, be.concurrencySender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporterCircuitBreaker(t *testing.T) {
+	cbCfg := NewDefaultCircuitBreakerConfig()
+	cbCfg.Enabled = true
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithRetry(configretry.NewDefaultBackOffConfig()),
+		WithCircuitBreaker(cbCfg))
+	require.NoError(t, err)
+	require.NotNil(t, be.circuitBreakerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporterDrain(t *testing.T) {
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	require.NoError(t, be.Drain(context.Background(), xexporter.DrainModeFlush))
+
+	var attempts atomic.Int64
+	retryCfg := configretry.NewDefaultBackOffConfig()
+	retryCfg.InitialInterval = 10 * time.Millisecond
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics,
+		func(context.Context, request.Request) error {
+			if attempts.Add(1) == 1 {
+				return errors.New("transient error")
+			}
+			return nil
+		},
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithRetry(retryCfg),
+		WithQueue(NewDefaultQueueConfig()))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	// The request is retried while draining.
+	require.NoError(t, be.Drain(context.Background(), xexporter.DrainModeFlush))
+	assert.EqualValues(t, 2, attempts.Load())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporterValidateSpill(t *testing.T) {
+	// Without a queue there is nothing to spill.
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	require.NoError(t, be.ValidateSpill())
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(NewDefaultQueueConfig()))
+	require.NoError(t, err)
+	require.Error(t, be.ValidateSpill())
+}
+
+func TestBaseExporterDeadLettersNotConfigured(t *testing.T) {
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	_, err = be.InspectDeadLetters(context.Background(), 0)
+	require.ErrorIs(t, err, xexporter.ErrNoDeadLetterQueue)
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(NewDefaultQueueConfig()))
+	require.NoError(t, err)
+	_, err = be.ReplayDeadLetters(context.Background())
+	require.ErrorIs(t, err, xexporter.ErrNoDeadLetterQueue)
+}
+
+func TestBaseExporterQueuePrioritizer(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.NumConsumers = 1
+	qCfg.Batch = configoptional.Optional[queuebatch.BatchConfig]{}
+	qCfg.Priority = configoptional.Some(queuebatch.PriorityConfig{Classes: []string{"high", "low"}})
+	var mu sync.Mutex
+	var exported []int
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics,
+		func(_ context.Context, req request.Request) error {
+			if req.ItemsCount() == 1 {
+				close(started)
+				<-unblock
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			exported = append(exported, req.ItemsCount())
+			return nil
+		},
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(qCfg),
+		WithQueuePrioritizer(queuebatch.GetClassFunc[request.Request](func(_ context.Context, req request.Request) string {
+			if req.ItemsCount() == 3 {
+				return "high"
+			}
+			return "low"
+		})))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first request blocks the consumer, the high priority request is read before the low priority one.
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	<-started
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	close(unblock)
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.Equal(t, []int{1, 3, 2}, exported)
+}
+
 func TestQueueRetryWithDisabledQueue(t *testing.T) {
 	tests := []struct {
 		name         string
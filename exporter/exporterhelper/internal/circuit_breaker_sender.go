@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+// retryBudgetMaxTokens is the maximum number of retries that can be accumulated in the retry budget,
+// it is also the initial number of tokens, so a small number of retries is allowed right after startup.
+const retryBudgetMaxTokens = 10
+
+var (
+	errCircuitBreakerOpen   = errors.New("circuit breaker is open")
+	errRetryBudgetExhausted = errors.New("retry budget exhausted")
+)
+
+// CircuitBreakerConfig defines configuration for the circuit breaker and the retry budget of an exporter.
+type CircuitBreakerConfig struct {
+	// Enabled indicates whether to use the circuit breaker or not.
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureThreshold is the ratio of failed attempts to send data, in the current interval, above which the
+	// circuit breaker opens.
+	FailureThreshold float64 `mapstructure:"failure_threshold"`
+
+	// MinimumRequests is the minimum number of attempts to send data, in the current interval, before the
+	// failure threshold is evaluated.
+	MinimumRequests int `mapstructure:"minimum_requests"`
+
+	// Interval is the duration after which the counts of attempts and failures are cleared while closed.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// OpenDuration is the duration the circuit breaker stays open, failing every attempt to send data, before
+	// allowing a single attempt to probe if the destination recovered.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// RetryBudget is the maximum ratio of retries to successful attempts to send data. Every successful attempt
+	// adds RetryBudget to the budget, and every retry consumes 1. A zero value disables the retry budget.
+	RetryBudget float64 `mapstructure:"retry_budget"`
+}
+
+func (cfg *CircuitBreakerConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FailureThreshold <= 0 || cfg.FailureThreshold > 1 {
+		return errors.New("'failure_threshold' must be greater than 0 and less than or equal to 1")
+	}
+	if cfg.MinimumRequests <= 0 {
+		return errors.New("'minimum_requests' must be positive")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("'interval' must be positive")
+	}
+	if cfg.OpenDuration <= 0 {
+		return errors.New("'open_duration' must be positive")
+	}
+	if cfg.RetryBudget < 0 {
+		return errors.New("'retry_budget' must be non-negative")
+	}
+	return nil
+}
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+func NewDefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Enabled:          false,
+		FailureThreshold: 0.5,
+		MinimumRequests:  10,
+		Interval:         time.Minute,
+		OpenDuration:     30 * time.Second,
+		RetryBudget:      0.2,
+	}
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreakerSender is a sender that stops sending data to a failing destination:
+//   - closed: every attempt is sent; when the ratio of failed attempts in the current interval is above the
+//     threshold the circuit opens;
+//   - open: every attempt fails immediately until OpenDuration elapses, without being retried, so the request goes
+//     back to the sending queue, if enabled, instead of being sent to the destination;
+//   - half-open: a single probe attempt is sent, the circuit closes if it succeeds or opens again if it fails.
+//
+// It also limits the number of retries to a ratio of the successful attempts (retry budget), so a failing
+// destination is not overwhelmed by retries from all queued requests at once.
+//
+// This sender is placed after the retry sender, so it observes every individual attempt.
+type circuitBreakerSender[T any] struct {
+	cfg    CircuitBreakerConfig
+	logger *zap.Logger
+	next   sender.Sender[T]
+	now    func() time.Time
+
+	// mu guards everything declared below.
+	mu            sync.Mutex
+	host          component.Host
+	state         circuitState
+	openUntil     time.Time
+	intervalEnd   time.Time
+	requests      int
+	failures      int
+	probeInFlight bool
+	retryTokens   float64
+}
+
+func newCircuitBreakerSender[T any](cfg CircuitBreakerConfig, set exporter.Settings, next sender.Sender[T]) *circuitBreakerSender[T] {
+	return &circuitBreakerSender[T]{
+		cfg:         cfg,
+		logger:      set.Logger,
+		next:        next,
+		now:         time.Now,
+		retryTokens: retryBudgetMaxTokens,
+	}
+}
+
+// Start records the host used to report the state of the circuit breaker as component status.
+func (cb *circuitBreakerSender[T]) Start(_ context.Context, host component.Host) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.host = host
+	return nil
+}
+
+func (cb *circuitBreakerSender[T]) Shutdown(context.Context) error {
+	return nil
+}
+
+func (cb *circuitBreakerSender[T]) Send(ctx context.Context, req T) error {
+	if err := cb.before(isRetryAttempt(ctx)); err != nil {
+		return err
+	}
+	err := cb.next.Send(ctx, req)
+	cb.after(err)
+	return err
+}
+
+// before checks if the attempt is allowed, returns an error if not.
+func (cb *circuitBreakerSender[T]) before(retry bool) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	switch cb.state {
+	case circuitOpen:
+		if now.Before(cb.openUntil) {
+			return experr.NewCircuitOpenErr(errCircuitBreakerOpen, cb.openUntil.Sub(now))
+		}
+		cb.state = circuitHalfOpen
+		cb.logger.Info("Circuit breaker is half-open, probing the destination.")
+		fallthrough
+	case circuitHalfOpen:
+		if cb.probeInFlight {
+			return experr.NewCircuitOpenErr(errCircuitBreakerOpen, 0)
+		}
+		cb.probeInFlight = true
+		return nil
+	}
+
+	if retry && cb.cfg.RetryBudget > 0 {
+		if cb.retryTokens < 1 {
+			return errRetryBudgetExhausted
+		}
+		cb.retryTokens--
+	}
+
+	if now.After(cb.intervalEnd) {
+		cb.requests, cb.failures = 0, 0
+		cb.intervalEnd = now.Add(cb.cfg.Interval)
+	}
+	return nil
+}
+
+// after records the result of an attempt and transitions the state of the circuit breaker if needed.
+func (cb *circuitBreakerSender[T]) after(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	failed := isOverloadSignal(err)
+	if err == nil && cb.cfg.RetryBudget > 0 {
+		cb.retryTokens = min(retryBudgetMaxTokens, cb.retryTokens+cb.cfg.RetryBudget)
+	}
+
+	switch cb.state {
+	case circuitHalfOpen:
+		cb.probeInFlight = false
+		if failed {
+			cb.open(err)
+			return
+		}
+		cb.state = circuitClosed
+		cb.requests, cb.failures = 0, 0
+		cb.intervalEnd = cb.now().Add(cb.cfg.Interval)
+		cb.logger.Info("Circuit breaker is closed, destination recovered.")
+		cb.reportStatus(componentstatus.NewEvent(componentstatus.StatusOK))
+	case circuitClosed:
+		cb.requests++
+		if !failed {
+			return
+		}
+		cb.failures++
+		if cb.requests >= cb.cfg.MinimumRequests &&
+			float64(cb.failures)/float64(cb.requests) >= cb.cfg.FailureThreshold {
+			cb.open(err)
+		}
+	}
+}
+
+// open transitions the circuit breaker to the open state. Callers MUST hold the mutex.
+func (cb *circuitBreakerSender[T]) open(err error) {
+	cb.state = circuitOpen
+	cb.openUntil = cb.now().Add(cb.cfg.OpenDuration)
+	cb.logger.Warn("Circuit breaker is open, failing requests until the destination recovers.",
+		zap.Error(err), zap.Duration("open_duration", cb.cfg.OpenDuration))
+	cb.reportStatus(componentstatus.NewRecoverableErrorEvent(errors.Join(errCircuitBreakerOpen, err)))
+}
+
+// reportStatus reports the status if the host is available. Callers MUST hold the mutex.
+func (cb *circuitBreakerSender[T]) reportStatus(ev *componentstatus.Event) {
+	if cb.host != nil {
+		componentstatus.ReportStatus(cb.host, ev)
+	}
+}
+
+type retryAttemptKey struct{}
+
+// contextWithRetryAttempt marks the context as used by a retry of a request that failed to be sent before.
+func contextWithRetryAttempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, true)
+}
+
+func isRetryAttempt(ctx context.Context) bool {
+	retry, _ := ctx.Value(retryAttemptKey{}).(bool)
+	return retry
+}
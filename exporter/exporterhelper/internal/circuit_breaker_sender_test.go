@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+type statusRecordingHost struct {
+	component.Host
+	mu       sync.Mutex
+	statuses []componentstatus.Status
+}
+
+func (h *statusRecordingHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = append(h.statuses, ev.Status())
+}
+
+func (h *statusRecordingHost) reported() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.statuses
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestCircuitBreakerSender(t *testing.T, cfg CircuitBreakerConfig, host component.Host, next sender.SendFunc[int64]) (*circuitBreakerSender[int64], *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	cb := newCircuitBreakerSender(cfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(next))
+	cb.now = clock.Now
+	require.NoError(t, cb.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, cb.Shutdown(context.Background())) })
+	return cb, clock
+}
+
+func TestCircuitBreakerConfig_Validate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.NoError(t, cfg.Validate())
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.FailureThreshold = 1.5
+	require.EqualError(t, cfg.Validate(), "'failure_threshold' must be greater than 0 and less than or equal to 1")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinimumRequests = 0
+	require.EqualError(t, cfg.Validate(), "'minimum_requests' must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.Interval = 0
+	require.EqualError(t, cfg.Validate(), "'interval' must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.OpenDuration = -time.Second
+	require.EqualError(t, cfg.Validate(), "'open_duration' must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.RetryBudget = -1
+	require.EqualError(t, cfg.Validate(), "'retry_budget' must be non-negative")
+
+	// Invalid values are ignored if disabled.
+	cfg.Enabled = false
+	require.NoError(t, cfg.Validate())
+}
+
+func TestCircuitBreakerSender_OpenHalfOpenClose(t *testing.T) {
+	host := &statusRecordingHost{Host: componenttest.NewNopHost()}
+	sendErr := errors.New("transient error")
+	var fail bool
+	var calls int
+	cfg := CircuitBreakerConfig{Enabled: true, FailureThreshold: 0.5, MinimumRequests: 4, Interval: time.Minute, OpenDuration: 10 * time.Second}
+	cb, clock := newTestCircuitBreakerSender(t, cfg, host, func(context.Context, int64) error {
+		calls++
+		if fail {
+			return sendErr
+		}
+		return nil
+	})
+
+	// Not enough requests to evaluate the threshold.
+	fail = true
+	for range 3 {
+		require.ErrorIs(t, cb.Send(context.Background(), 1), sendErr)
+	}
+	assert.Equal(t, circuitClosed, cb.state)
+
+	require.ErrorIs(t, cb.Send(context.Background(), 1), sendErr)
+	assert.Equal(t, circuitOpen, cb.state)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.reported())
+
+	// While open, requests fail fast until the open duration elapses.
+	clock.now = clock.now.Add(4 * time.Second)
+	err := cb.Send(context.Background(), 1)
+	require.ErrorIs(t, err, errCircuitBreakerOpen)
+	delay, ok := experr.CircuitOpenDelay(err)
+	require.True(t, ok)
+	assert.Equal(t, 6*time.Second, delay)
+	assert.Equal(t, 4, calls)
+
+	// A failed probe opens the circuit again.
+	clock.now = clock.now.Add(6 * time.Second)
+	require.ErrorIs(t, cb.Send(context.Background(), 1), sendErr)
+	assert.Equal(t, circuitOpen, cb.state)
+	assert.Equal(t, 5, calls)
+
+	// A successful probe closes the circuit.
+	fail = false
+	clock.now = clock.now.Add(10 * time.Second)
+	require.NoError(t, cb.Send(context.Background(), 1))
+	assert.Equal(t, circuitClosed, cb.state)
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusOK,
+	}, host.reported())
+}
+
+func TestCircuitBreakerSender_HalfOpenSingleProbe(t *testing.T) {
+	unblock := make(chan struct{})
+	cfg := CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, MinimumRequests: 1, Interval: time.Minute, OpenDuration: time.Second}
+	cb, clock := newTestCircuitBreakerSender(t, cfg, componenttest.NewNopHost(), func(_ context.Context, req int64) error {
+		if req == 0 {
+			return errors.New("transient error")
+		}
+		<-unblock
+		return nil
+	})
+	require.Error(t, cb.Send(context.Background(), 0))
+	assert.Equal(t, circuitOpen, cb.state)
+
+	clock.now = clock.now.Add(time.Second)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, cb.Send(context.Background(), 1))
+	}()
+	assert.Eventually(t, func() bool {
+		cb.mu.Lock()
+		defer cb.mu.Unlock()
+		return cb.probeInFlight
+	}, time.Second, 10*time.Millisecond)
+
+	// Only one probe is allowed at the time.
+	require.ErrorIs(t, cb.Send(context.Background(), 1), errCircuitBreakerOpen)
+	close(unblock)
+	<-done
+	assert.Equal(t, circuitClosed, cb.state)
+}
+
+func TestCircuitBreakerSender_IgnoresPermanentErrors(t *testing.T) {
+	cfg := CircuitBreakerConfig{Enabled: true, FailureThreshold: 0.5, MinimumRequests: 1, Interval: time.Minute, OpenDuration: time.Second}
+	cb, _ := newTestCircuitBreakerSender(t, cfg, componenttest.NewNopHost(), func(context.Context, int64) error {
+		return consumererror.NewPermanent(errors.New("bad data"))
+	})
+	for range 5 {
+		require.Error(t, cb.Send(context.Background(), 1))
+	}
+	assert.Equal(t, circuitClosed, cb.state)
+}
+
+func TestCircuitBreakerSender_IntervalResetsCounts(t *testing.T) {
+	cfg := CircuitBreakerConfig{Enabled: true, FailureThreshold: 0.5, MinimumRequests: 2, Interval: time.Minute, OpenDuration: time.Second}
+	cb, clock := newTestCircuitBreakerSender(t, cfg, componenttest.NewNopHost(), func(_ context.Context, req int64) error {
+		if req == 0 {
+			return errors.New("transient error")
+		}
+		return nil
+	})
+	require.NoError(t, cb.Send(context.Background(), 1))
+	require.NoError(t, cb.Send(context.Background(), 1))
+	require.NoError(t, cb.Send(context.Background(), 1))
+
+	// The failures from the previous interval are cleared.
+	clock.now = clock.now.Add(2 * time.Minute)
+	require.Error(t, cb.Send(context.Background(), 0))
+	assert.Equal(t, circuitClosed, cb.state)
+	require.Error(t, cb.Send(context.Background(), 0))
+	assert.Equal(t, circuitOpen, cb.state)
+}
+
+func TestCircuitBreakerSender_RetryBudget(t *testing.T) {
+	cfg := CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, MinimumRequests: 1000, Interval: time.Minute, OpenDuration: time.Second, RetryBudget: 0.5}
+	cb, _ := newTestCircuitBreakerSender(t, cfg, componenttest.NewNopHost(), func(_ context.Context, req int64) error {
+		if req == 0 {
+			return errors.New("transient error")
+		}
+		return nil
+	})
+
+	retryCtx := contextWithRetryAttempt(context.Background())
+	for range retryBudgetMaxTokens {
+		require.Error(t, cb.Send(retryCtx, 0))
+	}
+	require.ErrorIs(t, cb.Send(retryCtx, 0), errRetryBudgetExhausted)
+
+	// First attempts are never limited, and every success adds to the budget.
+	require.NoError(t, cb.Send(context.Background(), 1))
+	require.ErrorIs(t, cb.Send(retryCtx, 0), errRetryBudgetExhausted)
+	require.NoError(t, cb.Send(context.Background(), 1))
+	require.Error(t, cb.Send(retryCtx, 0))
+	require.ErrorIs(t, cb.Send(retryCtx, 0), errRetryBudgetExhausted)
+}
+
+func TestRetrySenderRetryBudgetExhausted(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 0
+	sendErr := errors.New("transient error")
+	cfg := CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, MinimumRequests: 1000, Interval: time.Minute, OpenDuration: time.Second, RetryBudget: 0.1}
+	var calls int
+	cb := newCircuitBreakerSender[request.Request](cfg, exportertest.NewNopSettings(exportertest.NopType),
+		sender.NewSender(func(context.Context, request.Request) error {
+			calls++
+			return sendErr
+		}))
+	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), cb)
+	require.NoError(t, cb.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+
+	err := rs.Send(context.Background(), &requesttest.FakeRequest{Items: 2})
+	require.ErrorIs(t, err, errRetryBudgetExhausted)
+	require.ErrorIs(t, err, sendErr)
+	assert.True(t, experr.IsRetriesExhaustedErr(err))
+	assert.Equal(t, 1+retryBudgetMaxTokens, calls)
+	require.NoError(t, rs.Shutdown(context.Background()))
+	require.NoError(t, cb.Shutdown(context.Background()))
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// concurrencySender is a sender that limits the number of concurrent calls to the next sender. The limit is adjusted
+// between the configured minimum and maximum using an additive increase/multiplicative decrease (AIMD) algorithm:
+//   - every successful call, faster than the latency threshold, increases the limit by 1/limit, so the limit grows
+//     by one after "limit" successful calls;
+//   - every failed call, that is not a permanent error, or slow call multiplies the limit by the decrease ratio.
+//
+// This sender is placed after the retry sender, so it observes the latency and errors of every individual attempt
+// and requests waiting for their next retry do not use any of the available concurrency.
+type concurrencySender[T any] struct {
+	component.StartFunc
+	cfg            queuebatch.AdaptiveConcurrencyConfig
+	maxConcurrency float64
+	tb             *metadata.TelemetryBuilder
+	next           sender.Sender[T]
+
+	// mu guards everything declared below.
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// released is closed and replaced every time a call completes or the limit is increased.
+	released chan struct{}
+}
+
+func newConcurrencySender[T any](cfg queuebatch.AdaptiveConcurrencyConfig, maxConcurrency int, set exporter.Settings, signal pipeline.Signal, next sender.Sender[T]) (sender.Sender[T], error) {
+	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &concurrencySender[T]{
+		cfg:            cfg,
+		maxConcurrency: float64(max(maxConcurrency, cfg.MinConcurrency)),
+		tb:             tb,
+		next:           next,
+		limit:          float64(cfg.MinConcurrency),
+		released:       make(chan struct{}),
+	}
+
+	metricAttr := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String(ExporterKey, set.ID.String()), attribute.String(DataTypeKey, signal.String())))
+	err = tb.RegisterExporterConcurrencyLimitCallback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(int64(cs.currentLimit()), metricAttr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+func (cs *concurrencySender[T]) Shutdown(context.Context) error {
+	cs.tb.Shutdown()
+	return nil
+}
+
+func (cs *concurrencySender[T]) Send(ctx context.Context, req T) error {
+	if err := cs.acquire(ctx); err != nil {
+		return err
+	}
+	start := time.Now()
+	err := cs.next.Send(ctx, req)
+	cs.release(time.Since(start), err)
+	return err
+}
+
+// acquire blocks until a call is allowed by the current limit or the context is done.
+func (cs *concurrencySender[T]) acquire(ctx context.Context) error {
+	cs.mu.Lock()
+	for cs.inFlight >= int(cs.limit) {
+		released := cs.released
+		cs.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+		cs.mu.Lock()
+	}
+	cs.inFlight++
+	cs.mu.Unlock()
+	return nil
+}
+
+// release records the result of a completed call and adjusts the limit accordingly.
+func (cs *concurrencySender[T]) release(latency time.Duration, err error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.inFlight--
+
+	switch {
+	case isOverloadSignal(err) || (cs.cfg.LatencyThreshold > 0 && latency > cs.cfg.LatencyThreshold):
+		cs.limit = math.Max(float64(cs.cfg.MinConcurrency), cs.limit*cs.cfg.DecreaseRatio)
+	case err == nil && float64(cs.inFlight+1)*2 >= cs.limit:
+		// Only increase the limit if it is actually being used, otherwise it grows without bound during low traffic.
+		cs.limit = math.Min(cs.maxConcurrency, cs.limit+1/cs.limit)
+	}
+
+	close(cs.released)
+	cs.released = make(chan struct{})
+}
+
+func (cs *concurrencySender[T]) currentLimit() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return int(cs.limit)
+}
+
+// isOverloadSignal returns true if the error indicates that the backend may be overloaded.
+// Permanent errors are caused by the data itself, and cancellations by the caller, so they are not taken into account.
+func isOverloadSignal(err error) bool {
+	if err == nil || consumererror.IsPermanent(err) {
+		return false
+	}
+	return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func newTestConcurrencySender(t *testing.T, cfg queuebatch.AdaptiveConcurrencyConfig, maxConcurrency int, next sender.SendFunc[int64]) *concurrencySender[int64] {
+	cs, err := newConcurrencySender(cfg, maxConcurrency, exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalTraces, sender.NewSender(next))
+	require.NoError(t, err)
+	require.NoError(t, cs.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, cs.Shutdown(context.Background())) })
+	return cs.(*concurrencySender[int64])
+}
+
+func TestConcurrencySender_AdditiveIncrease(t *testing.T) {
+	cs := newTestConcurrencySender(t, queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5}, 3,
+		func(context.Context, int64) error { return nil })
+
+	assert.Equal(t, 1, cs.currentLimit())
+	require.NoError(t, cs.Send(context.Background(), 1))
+	assert.Equal(t, 2, cs.currentLimit())
+
+	// Sequential calls only use one slot, so the limit does not grow more than twice the usage.
+	for range 10 {
+		require.NoError(t, cs.Send(context.Background(), 1))
+	}
+	assert.Equal(t, 2, cs.currentLimit())
+
+	// When the limit is used, it grows up to the maximum.
+	cs.inFlight = 2
+	for range 10 {
+		cs.release(0, nil)
+		cs.inFlight++
+	}
+	assert.Equal(t, 3, cs.currentLimit())
+}
+
+func TestConcurrencySender_MultiplicativeDecrease(t *testing.T) {
+	sendErr := errors.New("transient error")
+	cs := newTestConcurrencySender(t, queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 2, DecreaseRatio: 0.5}, 10,
+		func(_ context.Context, req int64) error {
+			switch req {
+			case 1:
+				return sendErr
+			case 2:
+				return consumererror.NewPermanent(sendErr)
+			}
+			return nil
+		})
+	cs.limit = 8
+
+	require.ErrorIs(t, cs.Send(context.Background(), 1), sendErr)
+	assert.Equal(t, 4, cs.currentLimit())
+
+	// Permanent errors do not change the limit.
+	require.ErrorIs(t, cs.Send(context.Background(), 2), sendErr)
+	assert.Equal(t, 4, cs.currentLimit())
+
+	// Never decreases below the minimum.
+	for range 5 {
+		require.ErrorIs(t, cs.Send(context.Background(), 1), sendErr)
+	}
+	assert.Equal(t, 2, cs.currentLimit())
+}
+
+func TestConcurrencySender_LatencyThreshold(t *testing.T) {
+	cs := newTestConcurrencySender(t,
+		queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5, LatencyThreshold: 10 * time.Millisecond}, 10,
+		func(_ context.Context, delay int64) error {
+			time.Sleep(time.Duration(delay))
+			return nil
+		})
+	cs.limit = 4
+
+	require.NoError(t, cs.Send(context.Background(), int64(50*time.Millisecond)))
+	assert.Equal(t, 2, cs.currentLimit())
+}
+
+func TestConcurrencySender_LimitsInFlight(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int64
+	unblock := make(chan struct{})
+	cs := newTestConcurrencySender(t, queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 2, DecreaseRatio: 0.5}, 10,
+		func(context.Context, int64) error {
+			cur := inFlight.Add(1)
+			for {
+				prev := maxInFlight.Load()
+				if cur <= prev || maxInFlight.CompareAndSwap(prev, cur) {
+					break
+				}
+			}
+			<-unblock
+			inFlight.Add(-1)
+			return errors.New("transient error")
+		})
+
+	wg := sync.WaitGroup{}
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Error(t, cs.Send(context.Background(), 1))
+		}()
+	}
+	assert.Eventually(t, func() bool { return inFlight.Load() == 2 }, 1*time.Second, 10*time.Millisecond)
+	close(unblock)
+	wg.Wait()
+	assert.Equal(t, int64(2), maxInFlight.Load())
+}
+
+func TestConcurrencySender_ContextCanceled(t *testing.T) {
+	unblock := make(chan struct{})
+	cs := newTestConcurrencySender(t, queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5}, 10,
+		func(context.Context, int64) error {
+			<-unblock
+			return nil
+		})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, cs.Send(context.Background(), 1))
+	}()
+	assert.Eventually(t, func() bool {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		return cs.inFlight == 1
+	}, 1*time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, cs.Send(ctx, 1), context.Canceled)
+	close(unblock)
+	<-done
+}
+
+func TestConcurrencySender_Metric(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	// An exporter supporting two signals has a concurrency limit per signal.
+	set := exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()}
+	tracesSender, err := newConcurrencySender(queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 3, DecreaseRatio: 0.5}, 10,
+		set, pipeline.SignalTraces, sender.NewSender(func(context.Context, int64) error { return nil }))
+	require.NoError(t, err)
+	metricsSender, err := newConcurrencySender(queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 5, DecreaseRatio: 0.5}, 10,
+		set, pipeline.SignalMetrics, sender.NewSender(func(context.Context, int64) error { return nil }))
+	require.NoError(t, err)
+
+	metadatatest.AssertEqualExporterConcurrencyLimit(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", exporterID.String()),
+					attribute.String(DataTypeKey, pipeline.SignalTraces.String())),
+				Value: 3,
+			},
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", exporterID.String()),
+					attribute.String(DataTypeKey, pipeline.SignalMetrics.String())),
+				Value: 5,
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tracesSender.Shutdown(context.Background()))
+	require.NoError(t, metricsSender.Shutdown(context.Background()))
+}
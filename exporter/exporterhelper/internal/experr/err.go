@@ -5,6 +5,7 @@ package experr // import "go.opentelemetry.io/collector/exporter/exporterhelper/
 
 import (
 	"errors"
+	"time"
 )
 
 type shutdownErr struct {
@@ -27,3 +28,56 @@ func IsShutdownErr(err error) bool {
 	var sdErr shutdownErr
 	return errors.As(err, &sdErr)
 }
+
+type circuitOpenErr struct {
+	err   error
+	delay time.Duration
+}
+
+// NewCircuitOpenErr returns an error signaling that the request was not sent because the circuit breaker is open,
+// and that it can be sent again after the given delay.
+func NewCircuitOpenErr(err error, delay time.Duration) error {
+	return circuitOpenErr{err: err, delay: delay}
+}
+
+func (c circuitOpenErr) Error() string {
+	return "not sent: " + c.err.Error()
+}
+
+func (c circuitOpenErr) Unwrap() error {
+	return c.err
+}
+
+// CircuitOpenDelay returns the delay after which the request can be sent again if the error signals that the
+// circuit breaker is open.
+func CircuitOpenDelay(err error) (time.Duration, bool) {
+	var coErr circuitOpenErr
+	if !errors.As(err, &coErr) {
+		return 0, false
+	}
+	return coErr.delay, true
+}
+
+type retriesExhaustedErr struct {
+	err error
+}
+
+// NewRetriesExhaustedErr returns an error signaling that the request failed after all the retries allowed by the
+// retry settings, or by the retry budget, were attempted.
+func NewRetriesExhaustedErr(err error) error {
+	return retriesExhaustedErr{err: err}
+}
+
+func (r retriesExhaustedErr) Error() string {
+	return "no more retries left: " + r.err.Error()
+}
+
+func (r retriesExhaustedErr) Unwrap() error {
+	return r.err
+}
+
+// IsRetriesExhaustedErr returns true if the error signals that the retries of the request are exhausted.
+func IsRetriesExhaustedErr(err error) bool {
+	var reErr retriesExhaustedErr
+	return errors.As(err, &reErr)
+}
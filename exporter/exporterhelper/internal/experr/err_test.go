@@ -5,7 +5,9 @@ package experr
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,3 +24,22 @@ func TestIsShutdownErr(t *testing.T) {
 	err = NewShutdownErr(err)
 	require.True(t, IsShutdownErr(err))
 }
+
+func TestCircuitOpenDelay(t *testing.T) {
+	err := errors.New("testError")
+	_, ok := CircuitOpenDelay(err)
+	require.False(t, ok)
+	err = NewCircuitOpenErr(err, time.Second)
+	assert.Equal(t, "not sent: testError", err.Error())
+	delay, ok := CircuitOpenDelay(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+}
+
+func TestIsRetriesExhaustedErr(t *testing.T) {
+	err := errors.New("testError")
+	require.False(t, IsRetriesExhaustedErr(err))
+	err = NewRetriesExhaustedErr(err)
+	assert.Equal(t, "no more retries left: testError", err.Error())
+	require.True(t, IsRetriesExhaustedErr(fmt.Errorf("wrapped: %w", err)))
+}
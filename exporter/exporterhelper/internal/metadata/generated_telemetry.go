@@ -28,6 +28,7 @@ type TelemetryBuilder struct {
 	meter                             metric.Meter
 	mu                                sync.Mutex
 	registrations                     []metric.Registration
+	ExporterConcurrencyLimit          metric.Int64ObservableGauge
 	ExporterEnqueueFailedLogRecords   metric.Int64Counter
 	ExporterEnqueueFailedMetricPoints metric.Int64Counter
 	ExporterEnqueueFailedSpans        metric.Int64Counter
@@ -35,6 +36,7 @@ type TelemetryBuilder struct {
 	ExporterQueueBatchSendSizeBytes   metric.Int64Histogram
 	ExporterQueueCapacity             metric.Int64ObservableGauge
 	ExporterQueueSize                 metric.Int64ObservableGauge
+	ExporterQueueStorageSize          metric.Int64ObservableGauge
 	ExporterSendFailedLogRecords      metric.Int64Counter
 	ExporterSendFailedMetricPoints    metric.Int64Counter
 	ExporterSendFailedSpans           metric.Int64Counter
@@ -54,6 +56,21 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterExporterConcurrencyLimitCallback sets callback for observable ExporterConcurrencyLimit metric.
+func (builder *TelemetryBuilder) RegisterExporterConcurrencyLimitCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterConcurrencyLimit, obs: o})
+		return nil
+	}, builder.ExporterConcurrencyLimit)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueCapacityCallback sets callback for observable ExporterQueueCapacity metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -84,6 +101,21 @@ func (builder *TelemetryBuilder) RegisterExporterQueueSizeCallback(cb metric.Int
 	return nil
 }
 
+// RegisterExporterQueueStorageSizeCallback sets callback for observable ExporterQueueStorageSize metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueStorageSizeCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueStorageSize, obs: o})
+		return nil
+	}, builder.ExporterQueueStorageSize)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 type observerInt64 struct {
 	embedded.Int64Observer
 	inst metric.Int64Observable
@@ -112,6 +144,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ExporterConcurrencyLimit, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_concurrency_limit",
+		metric.WithDescription("Current limit of concurrent export requests when the adaptive concurrency is enabled. [alpha]"),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterEnqueueFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_enqueue_failed_log_records",
 		metric.WithDescription("Number of log records failed to be added to the sending queue. [alpha]"),
@@ -156,6 +194,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{batches}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueStorageSize, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_storage_size",
+		metric.WithDescription("Current size of the requests stored by the persistent queue (in bytes). [alpha]"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSendFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_send_failed_log_records",
 		metric.WithDescription("Number of log records in failed attempts to send to destination. [alpha]"),
@@ -0,0 +1,316 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	// deadLetterClientSuffix is appended to the signal name when requesting the storage client, so the dead letter
+	// queue does not share the key space with a persistent sending queue configured with the same extension.
+	deadLetterClientSuffix = "_dead_letter"
+
+	// deadLetterMetadataKey stores the read and write indexes of the dead letter queue.
+	deadLetterMetadataKey = "dlmv0"
+	// deadLetterItemKeyPrefix is prepended to the index of every dead lettered item.
+	deadLetterItemKeyPrefix = "dl_"
+)
+
+var errDeadLetterQueueStopped = errors.New("dead letter queue is not started")
+
+// DeadLetterQueue stores requests that permanently failed to be exported using a storage extension, so they can be
+// inspected or replayed later. Requests are stored using the same Encoding as the persistent queue.
+//
+// The dead letter queue is a simple FIFO:
+//   - Write index describes the position at which next item is going to be stored.
+//   - Read index describes which item needs to be replayed next.
+type DeadLetterQueue[T any] struct {
+	logger    *zap.Logger
+	encoding  Encoding[T]
+	storageID component.ID
+	id        component.ID
+	signal    pipeline.Signal
+
+	// replayMu ensures only one replay runs at a time.
+	replayMu sync.Mutex
+
+	// mu guards everything declared below.
+	mu         sync.Mutex
+	client     storage.Client
+	readIndex  uint64
+	writeIndex uint64
+}
+
+// NewDeadLetterQueue creates a new DeadLetterQueue backed by the storage extension identified by storageID.
+func NewDeadLetterQueue[T any](set Settings[T], storageID component.ID) *DeadLetterQueue[T] {
+	return &DeadLetterQueue[T]{
+		logger:    set.Telemetry.Logger,
+		encoding:  set.Encoding,
+		storageID: storageID,
+		id:        set.ID,
+		signal:    set.Signal,
+	}
+}
+
+// Start obtains the storage client and loads the dead letter queue metadata.
+func (dq *DeadLetterQueue[T]) Start(ctx context.Context, host component.Host) error {
+	client, err := toStorageClient(ctx, dq.storageID, host, dq.id, dq.signal.String()+deadLetterClientSuffix)
+	if err != nil {
+		return err
+	}
+
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	dq.client = client
+	buf, err := client.Get(ctx, deadLetterMetadataKey)
+	if err == nil {
+		dq.readIndex, dq.writeIndex, err = bytesToDeadLetterIndexes(buf)
+	}
+	switch {
+	case err == nil:
+		if size := dq.writeIndex - dq.readIndex; size > 0 {
+			dq.logger.Warn("Dead letter queue contains requests that failed to be exported",
+				zap.Uint64(zapNumberOfItems, size))
+		}
+	case errors.Is(err, errValueNotSet):
+		dq.logger.Debug("Initializing new dead letter queue")
+	default:
+		dq.logger.Error("Failed getting dead letter queue metadata, starting with new ones", zap.Error(err))
+		dq.readIndex, dq.writeIndex = 0, 0
+	}
+	return nil
+}
+
+// Shutdown closes the storage client.
+func (dq *DeadLetterQueue[T]) Shutdown(ctx context.Context) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.client == nil {
+		return nil
+	}
+	err := dq.client.Close(ctx)
+	dq.client = nil
+	return err
+}
+
+// Size returns the number of requests currently stored in the dead letter queue.
+func (dq *DeadLetterQueue[T]) Size() int64 {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	//nolint:gosec
+	return int64(dq.writeIndex - dq.readIndex)
+}
+
+// Put stores the request at the end of the dead letter queue.
+func (dq *DeadLetterQueue[T]) Put(ctx context.Context, req T) error {
+	reqBuf, err := dq.encoding.Marshal(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.client == nil {
+		return errDeadLetterQueueStopped
+	}
+
+	// Carry out a transaction where we both add the item and update the write index.
+	if err = dq.client.Batch(ctx,
+		storage.SetOperation(deadLetterMetadataKey, deadLetterIndexesToBytes(dq.readIndex, dq.writeIndex+1)),
+		storage.SetOperation(getDeadLetterItemKey(dq.writeIndex), reqBuf)); err != nil {
+		return err
+	}
+	dq.writeIndex++
+	return nil
+}
+
+// Inspect calls the given function with the index of every request in the dead letter queue, in the order they were
+// added, without removing them. It stops at the first error returned by the function.
+func (dq *DeadLetterQueue[T]) Inspect(ctx context.Context, fn func(context.Context, uint64, T) error) error {
+	dq.mu.Lock()
+	readIndex, writeIndex := dq.readIndex, dq.writeIndex
+	dq.mu.Unlock()
+
+	for index := readIndex; index < writeIndex; index++ {
+		reqCtx, req, err := dq.getItem(ctx, index)
+		if errors.Is(err, errDeadLetterQueueStopped) {
+			return err
+		}
+		if err != nil {
+			dq.logger.Warn("Failed retrieving dead lettered item",
+				zap.String(zapKey, getDeadLetterItemKey(index)), zap.Error(err))
+			continue
+		}
+		if err = fn(reqCtx, index, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replay calls the given function for the requests in the dead letter queue with the given indexes, or for every
+// request if no index is given, in the order they were added, and removes every request for which the function returns
+// no error. It stops at the first error returned by the function, leaving that request and all the following ones in
+// the dead letter queue.
+//
+// The requests added before the last given index which are not selected are moved, in order, to the end of the dead
+// letter queue, so they get new indexes.
+//
+// The function is called without holding any lock, so it is safe to call Put from it (e.g. by sending the request
+// back to the exporter), requests added during the replay are not replayed.
+func (dq *DeadLetterQueue[T]) Replay(ctx context.Context, fn func(context.Context, T) error, indexes ...uint64) error {
+	dq.replayMu.Lock()
+	defer dq.replayMu.Unlock()
+
+	dq.mu.Lock()
+	endIndex := dq.writeIndex
+	dq.mu.Unlock()
+
+	var selected map[uint64]struct{}
+	if len(indexes) > 0 {
+		selected = make(map[uint64]struct{}, len(indexes))
+		selectedEnd := uint64(0)
+		for _, index := range indexes {
+			selected[index] = struct{}{}
+			if index < endIndex {
+				selectedEnd = max(selectedEnd, index+1)
+			}
+		}
+		// Stop after the last selected request, unknown indexes are ignored.
+		endIndex = selectedEnd
+	}
+
+	for {
+		dq.mu.Lock()
+		index := dq.readIndex
+		dq.mu.Unlock()
+		if index >= endIndex {
+			return nil
+		}
+		if _, ok := selected[index]; selected != nil && !ok {
+			if err := dq.moveItem(ctx, index); err != nil {
+				return err
+			}
+			continue
+		}
+
+		reqCtx, req, err := dq.getItem(ctx, index)
+		switch {
+		case errors.Is(err, errDeadLetterQueueStopped):
+			return err
+		case err != nil:
+			// Items that cannot be retrieved will never be replayable, so drop them.
+			dq.logger.Warn("Failed retrieving dead lettered item, dropping it",
+				zap.String(zapKey, getDeadLetterItemKey(index)), zap.Error(err))
+		default:
+			if err = fn(reqCtx, req); err != nil {
+				return err
+			}
+		}
+
+		if err = dq.removeItem(ctx, index); err != nil {
+			return err
+		}
+	}
+}
+
+// removeItem deletes the item at the given index, which must be the current read index, and advances the read index.
+func (dq *DeadLetterQueue[T]) removeItem(ctx context.Context, index uint64) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.client == nil {
+		return errDeadLetterQueueStopped
+	}
+
+	if err := dq.client.Batch(ctx,
+		storage.SetOperation(deadLetterMetadataKey, deadLetterIndexesToBytes(index+1, dq.writeIndex)),
+		storage.DeleteOperation(getDeadLetterItemKey(index))); err != nil {
+		return err
+	}
+	dq.readIndex = index + 1
+	return nil
+}
+
+// moveItem moves the item at the given index, which must be the current read index, to the end of the queue, and
+// advances the read index. The item is dropped if it does not exist.
+func (dq *DeadLetterQueue[T]) moveItem(ctx context.Context, index uint64) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.client == nil {
+		return errDeadLetterQueueStopped
+	}
+
+	buf, err := dq.client.Get(ctx, getDeadLetterItemKey(index))
+	if err != nil {
+		return err
+	}
+	writeIndex := dq.writeIndex
+	ops := []*storage.Operation{storage.DeleteOperation(getDeadLetterItemKey(index))}
+	if buf != nil {
+		ops = append(ops, storage.SetOperation(getDeadLetterItemKey(writeIndex), buf))
+		writeIndex++
+	}
+	ops = append(ops, storage.SetOperation(deadLetterMetadataKey, deadLetterIndexesToBytes(index+1, writeIndex)))
+	if err = dq.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	dq.readIndex, dq.writeIndex = index+1, writeIndex
+	return nil
+}
+
+// getItem retrieves and decodes the item at the given index.
+func (dq *DeadLetterQueue[T]) getItem(ctx context.Context, index uint64) (context.Context, T, error) {
+	var req T
+	dq.mu.Lock()
+	if dq.client == nil {
+		dq.mu.Unlock()
+		return context.Background(), req, errDeadLetterQueueStopped
+	}
+	buf, err := dq.client.Get(ctx, getDeadLetterItemKey(index))
+	dq.mu.Unlock()
+	if err != nil {
+		return context.Background(), req, err
+	}
+	if buf == nil {
+		return context.Background(), req, errValueNotSet
+	}
+	return dq.encoding.Unmarshal(buf)
+}
+
+func getDeadLetterItemKey(index uint64) string {
+	return deadLetterItemKeyPrefix + getItemKey(index)
+}
+
+func deadLetterIndexesToBytes(readIndex, writeIndex uint64) []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf, readIndex)
+	binary.LittleEndian.PutUint64(buf[8:], writeIndex)
+	return buf
+}
+
+func bytesToDeadLetterIndexes(buf []byte) (uint64, uint64, error) {
+	if buf == nil {
+		return 0, 0, errValueNotSet
+	}
+	// The sizeof two uint64 in binary is 16.
+	if len(buf) < 16 {
+		return 0, 0, errInvalidValue
+	}
+	readIndex := binary.LittleEndian.Uint64(buf)
+	writeIndex := binary.LittleEndian.Uint64(buf[8:])
+	if readIndex > writeIndex {
+		return 0, 0, errInvalidValue
+	}
+	return readIndex, writeIndex, nil
+}
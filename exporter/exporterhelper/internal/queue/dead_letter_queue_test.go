@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func createTestDeadLetterQueue(tb testing.TB, ext storage.Extension) *DeadLetterQueue[int64] {
+	storageID := component.MustNewID("file_storage")
+	dq := NewDeadLetterQueue(newSettings(request.SizerTypeRequests, 1000), storageID)
+	require.NoError(tb, dq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{storageID: ext})))
+	return dq
+}
+
+func TestDeadLetterQueue_PutInspectReplay(t *testing.T) {
+	dq := createTestDeadLetterQueue(t, storagetest.NewMockStorageExtension(nil))
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(t, dq.Put(context.Background(), i))
+	}
+	assert.Equal(t, int64(3), dq.Size())
+
+	var inspected []int64
+	var indexes []uint64
+	require.NoError(t, dq.Inspect(context.Background(), func(_ context.Context, index uint64, req int64) error {
+		inspected = append(inspected, req)
+		indexes = append(indexes, index)
+		return nil
+	}))
+	assert.Equal(t, []int64{1, 2, 3}, inspected)
+	assert.Equal(t, []uint64{0, 1, 2}, indexes)
+	assert.Equal(t, int64(3), dq.Size())
+
+	var replayed []int64
+	require.NoError(t, dq.Replay(context.Background(), func(_ context.Context, req int64) error {
+		replayed = append(replayed, req)
+		return nil
+	}))
+	assert.Equal(t, []int64{1, 2, 3}, replayed)
+	assert.Equal(t, int64(0), dq.Size())
+	require.NoError(t, dq.Shutdown(context.Background()))
+}
+
+func TestDeadLetterQueue_ReplayStopsOnError(t *testing.T) {
+	dq := createTestDeadLetterQueue(t, storagetest.NewMockStorageExtension(nil))
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(t, dq.Put(context.Background(), i))
+	}
+
+	replayErr := errors.New("queue is full")
+	var replayed []int64
+	require.ErrorIs(t, dq.Replay(context.Background(), func(_ context.Context, req int64) error {
+		if req == 2 {
+			return replayErr
+		}
+		replayed = append(replayed, req)
+		return nil
+	}), replayErr)
+	assert.Equal(t, []int64{1}, replayed)
+	assert.Equal(t, int64(2), dq.Size())
+	require.NoError(t, dq.Shutdown(context.Background()))
+}
+
+func TestDeadLetterQueue_ReplayIndexes(t *testing.T) {
+	dq := createTestDeadLetterQueue(t, storagetest.NewMockStorageExtension(nil))
+	for i := int64(0); i < 5; i++ {
+		require.NoError(t, dq.Put(context.Background(), i))
+	}
+
+	var replayed []int64
+	replay := func(_ context.Context, req int64) error {
+		replayed = append(replayed, req)
+		return nil
+	}
+	// The requests before the last selected one are moved to the end, the following ones are kept in place.
+	require.NoError(t, dq.Replay(context.Background(), replay, 3, 1, 42))
+	assert.Equal(t, []int64{1, 3}, replayed)
+	assert.Equal(t, int64(3), dq.Size())
+
+	inspected := map[uint64]int64{}
+	require.NoError(t, dq.Inspect(context.Background(), func(_ context.Context, index uint64, req int64) error {
+		inspected[index] = req
+		return nil
+	}))
+	assert.Equal(t, map[uint64]int64{4: 4, 5: 0, 6: 2}, inspected)
+
+	replayed = nil
+	require.NoError(t, dq.Replay(context.Background(), replay, 6))
+	assert.Equal(t, []int64{2}, replayed)
+	replayed = nil
+	require.NoError(t, dq.Replay(context.Background(), replay))
+	assert.Equal(t, []int64{4, 0}, replayed)
+	assert.Zero(t, dq.Size())
+	require.NoError(t, dq.Shutdown(context.Background()))
+}
+
+func TestDeadLetterQueue_PutDuringReplay(t *testing.T) {
+	dq := createTestDeadLetterQueue(t, storagetest.NewMockStorageExtension(nil))
+	require.NoError(t, dq.Put(context.Background(), 1))
+
+	// Requests that fail again during the replay are stored again but not replayed in the same run.
+	require.NoError(t, dq.Replay(context.Background(), func(ctx context.Context, req int64) error {
+		return dq.Put(ctx, req+10)
+	}))
+	assert.Equal(t, int64(1), dq.Size())
+	require.NoError(t, dq.Shutdown(context.Background()))
+}
+
+func TestDeadLetterQueue_Restart(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	dq := createTestDeadLetterQueue(t, ext)
+	require.NoError(t, dq.Put(context.Background(), 1))
+	require.NoError(t, dq.Put(context.Background(), 2))
+	require.Error(t, dq.Replay(context.Background(), func(_ context.Context, req int64) error {
+		if req == 2 {
+			return errors.New("not now")
+		}
+		return nil
+	}))
+	require.NoError(t, dq.Shutdown(context.Background()))
+
+	dq = createTestDeadLetterQueue(t, ext)
+	assert.Equal(t, int64(1), dq.Size())
+	var replayed []int64
+	require.NoError(t, dq.Replay(context.Background(), func(_ context.Context, req int64) error {
+		replayed = append(replayed, req)
+		return nil
+	}))
+	assert.Equal(t, []int64{2}, replayed)
+	require.NoError(t, dq.Shutdown(context.Background()))
+}
+
+func TestDeadLetterQueue_NotStarted(t *testing.T) {
+	dq := NewDeadLetterQueue(newSettings(request.SizerTypeRequests, 1000), component.MustNewID("file_storage"))
+	require.ErrorIs(t, dq.Put(context.Background(), 1), errDeadLetterQueueStopped)
+	require.NoError(t, dq.Shutdown(context.Background()))
+}
+
+func TestDeadLetterQueue_StartFailure(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	dq := NewDeadLetterQueue(newSettings(request.SizerTypeRequests, 1000), storageID)
+	require.ErrorIs(t, dq.Start(context.Background(), hosttest.NewHost(nil)), errNoStorageClient)
+
+	storageErr := errors.New("could not get storage client")
+	require.ErrorIs(t, dq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(storageErr),
+	})), storageErr)
+}
+
+func TestBytesToDeadLetterIndexes(t *testing.T) {
+	_, _, err := bytesToDeadLetterIndexes(nil)
+	require.ErrorIs(t, err, errValueNotSet)
+
+	_, _, err = bytesToDeadLetterIndexes([]byte{1, 2})
+	require.ErrorIs(t, err, errInvalidValue)
+
+	_, _, err = bytesToDeadLetterIndexes(deadLetterIndexesToBytes(5, 3))
+	require.ErrorIs(t, err, errInvalidValue)
+
+	ri, wi, err := bytesToDeadLetterIndexes(deadLetterIndexesToBytes(3, 5))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), ri)
+	assert.Equal(t, uint64(5), wi)
+}
@@ -6,9 +6,11 @@ package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/i
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
@@ -23,19 +25,27 @@ var blockingDonePool = sync.Pool{
 var (
 	errInvalidSize  = errors.New("invalid element size")
 	errSizeTooLarge = errors.New("element size too large")
+	errSpilled      = errors.New("spilled from the queue")
 )
 
 // memoryQueue is an in-memory implementation of a Queue.
+//
+// When a PriorityFunc is configured, the elements are stored in separate lanes, one per priority class, where lane 0
+// has the highest priority. Elements are read from the highest priority lane that is not empty, and when the queue is
+// full, the oldest elements from the lowest priority lanes are dropped to make space for higher priority elements.
 type memoryQueue[T any] struct {
 	component.StartFunc
-	refCounter ReferenceCounter[T]
-	sizer      request.Sizer[T]
-	cap        int64
+	refCounter   ReferenceCounter[T]
+	sizer        request.Sizer[T]
+	cap          int64
+	priorityFunc PriorityFunc[T]
+	// onShed is called, holding the mutex, with every element dropped to make space for a higher priority element.
+	onShed func(context.Context, T)
 
 	mu              sync.Mutex
 	hasMoreElements *sync.Cond
 	hasMoreSpace    *cond
-	items           *linkedQueue[T]
+	lanes           []*linkedQueue[T]
 	size            int64
 	stopped         bool
 	waitForResult   bool
@@ -45,14 +55,25 @@ type memoryQueue[T any] struct {
 // newMemoryQueue creates a sized elements channel. Each element is assigned a size by the provided sizer.
 // capacity is the capacity of the queue.
 func newMemoryQueue[T any](set Settings[T]) readableQueue[T] {
+	numLanes := 1
+	if set.PriorityFunc != nil && set.NumPriorities > 1 {
+		numLanes = set.NumPriorities
+	}
+	lanes := make([]*linkedQueue[T], numLanes)
+	for i := range lanes {
+		lanes[i] = &linkedQueue[T]{}
+	}
 	sq := &memoryQueue[T]{
 		refCounter:      set.ReferenceCounter,
 		sizer:           set.activeSizer(),
 		cap:             set.Capacity,
-		items:           &linkedQueue[T]{},
+		lanes:           lanes,
 		waitForResult:   set.WaitForResult,
 		blockOnOverflow: set.BlockOnOverflow,
 	}
+	if numLanes > 1 {
+		sq.priorityFunc = set.PriorityFunc
+	}
 	sq.hasMoreElements = sync.NewCond(&sq.mu)
 	sq.hasMoreSpace = newCond(&sq.mu)
 	return sq
@@ -105,10 +126,15 @@ func (mq *memoryQueue[T]) Offer(ctx context.Context, el T) error {
 }
 
 func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockingDone, error) {
+	lane := mq.laneOf(ctx, el)
+
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
 	for mq.size+elSize > mq.cap {
+		if mq.shedLowerPriority(lane, elSize) {
+			break
+		}
 		if !mq.blockOnOverflow {
 			return nil, ErrQueueIsFull
 		}
@@ -128,7 +154,7 @@ func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockin
 		ctx = context.WithoutCancel(ctx)
 	}
 
-	mq.items.push(ctx, el, done)
+	mq.lanes[lane].push(ctx, el, done)
 	// Signal one consumer if any.
 	mq.hasMoreElements.Signal()
 	return done, nil
@@ -142,9 +168,11 @@ func (mq *memoryQueue[T]) Read(context.Context) (context.Context, T, Done, bool)
 	defer mq.mu.Unlock()
 
 	for {
-		if mq.items.hasElements() {
-			elCtx, el, done := mq.items.pop()
-			return elCtx, el, done, true
+		for _, items := range mq.lanes {
+			if items.hasElements() {
+				elCtx, el, done := items.pop()
+				return elCtx, el, done, true
+			}
 		}
 
 		if mq.stopped {
@@ -158,9 +186,78 @@ func (mq *memoryQueue[T]) Read(context.Context) (context.Context, T, Done, bool)
 	}
 }
 
+// Spill removes the elements from the queue, starting with the highest priority ones, and passes them to fn.
+// The function is called without holding the lock, so the consumers can keep reading elements concurrently.
+// The callers waiting for the result of a spilled element get a shutdown error, since it is not exported yet.
+func (mq *memoryQueue[T]) Spill(_ context.Context, fn func(context.Context, T) error) error {
+	for {
+		mq.mu.Lock()
+		lane := slices.IndexFunc(mq.lanes, (*linkedQueue[T]).hasElements)
+		if lane < 0 {
+			mq.mu.Unlock()
+			return nil
+		}
+		elCtx, el, done := mq.lanes[lane].pop()
+		mq.mu.Unlock()
+
+		if err := fn(elCtx, el); err != nil {
+			mq.mu.Lock()
+			mq.lanes[lane].pushFront(elCtx, el, done)
+			mq.hasMoreElements.Signal()
+			mq.mu.Unlock()
+			return err
+		}
+		if mq.refCounter != nil {
+			mq.refCounter.Unref(el)
+		}
+		done.OnDone(experr.NewShutdownErr(errSpilled))
+	}
+}
+
+// laneOf returns the lane where the element must be stored.
+func (mq *memoryQueue[T]) laneOf(ctx context.Context, el T) int {
+	if mq.priorityFunc == nil {
+		return 0
+	}
+	return min(max(mq.priorityFunc(ctx, el), 0), len(mq.lanes)-1)
+}
+
+// shedLowerPriority drops the oldest elements from the lanes with a lower priority than the given lane, starting with
+// the lowest priority lane, until there is enough space for an element of the given size.
+// It returns false without dropping anything if there is not enough space even after dropping all of them.
+// Callers MUST hold the mutex.
+func (mq *memoryQueue[T]) shedLowerPriority(lane int, elSize int64) bool {
+	var lowerSize int64
+	for _, items := range mq.lanes[lane+1:] {
+		lowerSize += items.size
+	}
+	if lowerSize == 0 || mq.size-lowerSize+elSize > mq.cap {
+		return false
+	}
+
+	for i := len(mq.lanes) - 1; i > lane && mq.size+elSize > mq.cap; i-- {
+		for mq.lanes[i].hasElements() && mq.size+elSize > mq.cap {
+			elCtx, el, done := mq.lanes[i].pop()
+			if mq.onShed != nil {
+				mq.onShed(elCtx, el)
+			}
+			if mq.refCounter != nil {
+				mq.refCounter.Unref(el)
+			}
+			mq.onDoneLocked(done.(*blockingDone), ErrQueueIsFull)
+		}
+	}
+	return true
+}
+
 func (mq *memoryQueue[T]) onDone(bd *blockingDone, err error) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
+	mq.onDoneLocked(bd, err)
+}
+
+// onDoneLocked releases the space used by the element. Callers MUST hold the mutex.
+func (mq *memoryQueue[T]) onDoneLocked(bd *blockingDone, err error) {
 	mq.size -= bd.elSize
 	mq.hasMoreSpace.Signal()
 	if mq.waitForResult {
@@ -200,10 +297,15 @@ type node[T any] struct {
 type linkedQueue[T any] struct {
 	head *node[T]
 	tail *node[T]
+	// size is the total size of the elements in the list, only tracked for blockingDone elements.
+	size int64
 }
 
 func (l *linkedQueue[T]) push(ctx context.Context, data T, done Done) {
 	n := &node[T]{ctx: ctx, data: data, done: done}
+	if bd, ok := done.(*blockingDone); ok {
+		l.size += bd.elSize
+	}
 	// If tail is nil means list is empty so update both head and tail to point to same element.
 	if l.tail == nil {
 		l.head = n
@@ -214,6 +316,18 @@ func (l *linkedQueue[T]) push(ctx context.Context, data T, done Done) {
 	l.tail = n
 }
 
+// pushFront puts back an element popped from the list at its head.
+func (l *linkedQueue[T]) pushFront(ctx context.Context, data T, done Done) {
+	n := &node[T]{ctx: ctx, data: data, done: done, next: l.head}
+	if bd, ok := done.(*blockingDone); ok {
+		l.size += bd.elSize
+	}
+	l.head = n
+	if l.tail == nil {
+		l.tail = n
+	}
+}
+
 func (l *linkedQueue[T]) hasElements() bool {
 	return l.head != nil
 }
@@ -226,6 +340,9 @@ func (l *linkedQueue[T]) pop() (context.Context, T, Done) {
 		l.tail = nil
 	}
 	n.next = nil
+	if bd, ok := n.done.(*blockingDone); ok {
+		l.size -= bd.elSize
+	}
 	return n.ctx, n.data, n.done
 }
 
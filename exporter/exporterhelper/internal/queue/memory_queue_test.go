@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
@@ -246,6 +247,138 @@ func BenchmarkMemoryQueueWaitForResult(b *testing.B) {
 	assert.Equal(b, int64(b.N), consumed.Load())
 }
 
+func newPrioritySettings(capacity int64) Settings[int64] {
+	set := newSettings(request.SizerTypeItems, capacity)
+	// Use the value modulo 10 as priority, so 10 and 20 have the highest priority 0, 11 and 21 priority 1, etc.
+	set.PriorityFunc = func(_ context.Context, el int64) int { return int(el % 10) }
+	set.NumPriorities = 3
+	return set
+}
+
+func TestMemoryQueuePriority(t *testing.T) {
+	q := newMemoryQueue[int64](newPrioritySettings(1000))
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	for _, el := range []int64{12, 11, 10, 22, 21, 20, 19} {
+		require.NoError(t, q.Offer(context.Background(), el))
+	}
+
+	// Priorities larger than the number of lanes are put in the lowest priority lane.
+	var consumed []int64
+	for range 7 {
+		assert.True(t, consume(q, func(_ context.Context, el int64) error {
+			consumed = append(consumed, el)
+			return nil
+		}))
+	}
+	assert.Equal(t, []int64{10, 20, 11, 21, 12, 22, 19}, consumed)
+	assert.EqualValues(t, 0, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueuePriorityShedding(t *testing.T) {
+	set := newPrioritySettings(60)
+	q := newMemoryQueue[int64](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, q.Offer(context.Background(), 10))
+	require.NoError(t, q.Offer(context.Background(), 22))
+	require.NoError(t, q.Offer(context.Background(), 12))
+	assert.EqualValues(t, 44, q.Size())
+
+	// Lower priority elements cannot shed higher priority elements.
+	require.ErrorIs(t, q.Offer(context.Background(), 32), ErrQueueIsFull)
+	// Not enough space even after shedding all the lower priority elements.
+	require.ErrorIs(t, q.Offer(context.Background(), 51), ErrQueueIsFull)
+	assert.EqualValues(t, 44, q.Size())
+
+	// Sheds the oldest lowest priority element.
+	require.NoError(t, q.Offer(context.Background(), 30))
+	assert.EqualValues(t, 52, q.Size())
+
+	var consumed []int64
+	for range 3 {
+		assert.True(t, consume(q, func(_ context.Context, el int64) error {
+			consumed = append(consumed, el)
+			return nil
+		}))
+	}
+	assert.Equal(t, []int64{10, 30, 12}, consumed)
+	assert.EqualValues(t, 0, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+	// Only the consumed elements are still referenced, the shed element was unreferenced by the queue.
+	assert.EqualValues(t, 3, set.ReferenceCounter.(*fakeReferenceCounter).ref)
+}
+
+func TestMemoryQueuePrioritySheddingWaitForResult(t *testing.T) {
+	set := newPrioritySettings(30)
+	set.WaitForResult = true
+	q := newMemoryQueue[int64](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- q.Offer(context.Background(), 22)
+	}()
+	assert.Eventually(t, func() bool { return q.Size() == 22 }, 1*time.Second, 10*time.Millisecond)
+
+	go func() {
+		assert.True(t, consume(q, func(context.Context, int64) error { return nil }))
+	}()
+	require.NoError(t, q.Offer(context.Background(), 20))
+	require.ErrorIs(t, <-errCh, ErrQueueIsFull)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueueSpill(t *testing.T) {
+	set := newPrioritySettings(100)
+	q := newMemoryQueue[int64](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	for _, el := range []int64{20, 10, 21, 11} {
+		require.NoError(t, q.Offer(context.Background(), el))
+	}
+
+	var spilled []int64
+	spillErr := errors.New("storage is full")
+	require.ErrorIs(t, q.Spill(context.Background(), func(_ context.Context, el int64) error {
+		if len(spilled) == 3 {
+			return spillErr
+		}
+		spilled = append(spilled, el)
+		return nil
+	}), spillErr)
+	assert.Equal(t, []int64{20, 10, 21}, spilled)
+	// The element that failed to be spilled is still in the queue.
+	assert.EqualValues(t, 11, q.Size())
+	assert.EqualValues(t, 1, set.ReferenceCounter.(*fakeReferenceCounter).ref)
+
+	require.NoError(t, q.Shutdown(context.Background()))
+	assert.True(t, consume(q, func(_ context.Context, el int64) error {
+		assert.EqualValues(t, 11, el)
+		return nil
+	}))
+	require.NoError(t, q.Spill(context.Background(), func(context.Context, int64) error { t.FailNow(); return nil }))
+	assert.EqualValues(t, 0, q.Size())
+}
+
+func TestMemoryQueueSpillWaitForResult(t *testing.T) {
+	set := newSettings(request.SizerTypeItems, 100)
+	set.WaitForResult = true
+	q := newMemoryQueue[int64](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	offerErr := make(chan error, 1)
+	go func() {
+		offerErr <- q.Offer(context.Background(), int64(1))
+	}()
+	assert.Eventually(t, func() bool { return q.Size() == 1 }, time.Second, 10*time.Millisecond)
+
+	// The caller waiting for the result is not told the spilled element was exported.
+	require.NoError(t, q.Spill(context.Background(), func(context.Context, int64) error { return nil }))
+	err := <-offerErr
+	require.ErrorIs(t, err, errSpilled)
+	assert.True(t, experr.IsShutdownErr(err))
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
 func consume[T any](q readableQueue[T], consumeFunc func(context.Context, T) error) bool {
 	ctx, req, done, ok := q.Read(context.Background())
 	if !ok {
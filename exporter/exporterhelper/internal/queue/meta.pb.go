@@ -38,6 +38,10 @@ type PersistentMetadata struct {
 	WriteIndex uint64 `protobuf:"fixed64,4,opt,name=write_index,json=writeIndex,proto3" json:"write_index,omitempty"`
 	// List of item indices currently being processed by consumers.
 	CurrentlyDispatchedItems []uint64 `protobuf:"fixed64,5,rep,packed,name=currently_dispatched_items,json=currentlyDispatchedItems,proto3" json:"currently_dispatched_items,omitempty"`
+	// Current total size in bytes of the serialized items stored by the queue.
+	StorageSize int64 `protobuf:"fixed64,6,opt,name=storage_size,json=storageSize,proto3" json:"storage_size,omitempty"`
+	// Index below which the storage was cleaned of the items left behind, for example after a crash.
+	CompactedIndex uint64 `protobuf:"fixed64,7,opt,name=compacted_index,json=compactedIndex,proto3" json:"compacted_index,omitempty"`
 }
 
 func (m *PersistentMetadata) Reset()         { *m = PersistentMetadata{} }
@@ -108,6 +112,20 @@ func (m *PersistentMetadata) GetCurrentlyDispatchedItems() []uint64 {
 	return nil
 }
 
+func (m *PersistentMetadata) GetStorageSize() int64 {
+	if m != nil {
+		return m.StorageSize
+	}
+	return 0
+}
+
+func (m *PersistentMetadata) GetCompactedIndex() uint64 {
+	if m != nil {
+		return m.CompactedIndex
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*PersistentMetadata)(nil), "opentelemetry.collector.exporter.exporterhelper.internal.queue.PersistentMetadata")
 }
@@ -117,25 +135,28 @@ func init() {
 }
 
 var fileDescriptor_8ad1b29fca6d4f37 = []byte{
-	// 287 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xb1, 0x4a, 0x03, 0x41,
-	0x14, 0x45, 0x33, 0x46, 0x03, 0x19, 0x1b, 0xd9, 0x6a, 0x11, 0x5c, 0x83, 0x55, 0xaa, 0x59, 0xc4,
-	0x56, 0x2c, 0x24, 0x4d, 0x0a, 0x41, 0x62, 0x67, 0xe1, 0x32, 0xd9, 0xbd, 0x98, 0x81, 0xc9, 0xcc,
-	0xfa, 0xf6, 0x05, 0x93, 0x7c, 0x85, 0x9f, 0x65, 0x99, 0xd2, 0x52, 0x92, 0x1f, 0x91, 0x9d, 0x64,
-	0x17, 0xd2, 0xd9, 0x3d, 0xce, 0xb9, 0x5c, 0x1e, 0x57, 0xde, 0x62, 0x59, 0x7a, 0x62, 0x50, 0xda,
-	0x1c, 0x33, 0xd8, 0x12, 0x94, 0x1a, 0xc7, 0x20, 0xa7, 0x6d, 0xfa, 0xb1, 0xc0, 0x02, 0xe9, 0x1c,
-	0xac, 0x55, 0x49, 0x9e, 0x7d, 0xf4, 0xe0, 0x4b, 0x38, 0x86, 0xc5, 0x1c, 0x4c, 0x2b, 0x95, 0x7b,
-	0x6b, 0x91, 0xb3, 0x27, 0xd5, 0x34, 0xa8, 0xe3, 0x2a, 0xd5, 0x54, 0xa9, 0x50, 0x75, 0xb3, 0x11,
-	0x32, 0x7a, 0x06, 0x55, 0xa6, 0x62, 0x38, 0x7e, 0x02, 0xeb, 0x42, 0xb3, 0x8e, 0xae, 0xa4, 0x34,
-	0x8c, 0x79, 0x95, 0x55, 0x66, 0x8d, 0x58, 0x0c, 0xc4, 0xf0, 0x62, 0xd2, 0x0f, 0xe4, 0xc5, 0xac,
-	0x51, 0xeb, 0xe9, 0x8a, 0x71, 0xd0, 0x27, 0x7b, 0x1d, 0x48, 0xa3, 0x09, 0xba, 0xc8, 0x8c, 0x2b,
-	0xb0, 0x8c, 0xbb, 0x03, 0x31, 0xec, 0x4d, 0xfa, 0x35, 0x19, 0xd7, 0x20, 0xba, 0x96, 0xe7, 0x9f,
-	0x64, 0x18, 0x07, 0x7f, 0x1a, 0xbc, 0x0c, 0x68, 0x1f, 0xb8, 0x97, 0x97, 0xf9, 0x82, 0x08, 0x8e,
-	0xed, 0x2a, 0x2b, 0x4c, 0x55, 0x6a, 0xce, 0x67, 0x28, 0xb2, 0xf0, 0x40, 0x7c, 0x36, 0xe8, 0x0e,
-	0x7b, 0x93, 0xb8, 0x4d, 0x8c, 0xda, 0xc0, 0xb8, 0xf6, 0x8f, 0x6f, 0xdf, 0xdb, 0x44, 0x6c, 0xb6,
-	0x89, 0xf8, 0xdd, 0x26, 0xe2, 0x6b, 0x97, 0x74, 0x36, 0xbb, 0xa4, 0xf3, 0xb3, 0x4b, 0x3a, 0xaf,
-	0xa3, 0x77, 0xaf, 0x8e, 0xf7, 0x32, 0x3e, 0x6d, 0x27, 0x4b, 0xff, 0xb7, 0xfe, 0xb4, 0x17, 0x96,
-	0xbf, 0xfb, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x15, 0x90, 0x67, 0x5e, 0xae, 0x01, 0x00, 0x00,
+	// 324 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xc1, 0x4a, 0xeb, 0x40,
+	0x14, 0x86, 0x9b, 0xf6, 0xde, 0x5c, 0x3a, 0xbd, 0xa8, 0x64, 0x15, 0x04, 0x63, 0x75, 0x63, 0x57,
+	0x13, 0xc4, 0xad, 0xb8, 0x90, 0x6e, 0xba, 0x10, 0xa4, 0xee, 0x5c, 0x18, 0xa6, 0xc9, 0x4f, 0x3b,
+	0x90, 0x64, 0xe2, 0xc9, 0x29, 0xb6, 0x7d, 0x0a, 0x1f, 0xc0, 0x07, 0x72, 0xd9, 0xa5, 0x4b, 0x69,
+	0x5f, 0x44, 0x32, 0x6d, 0x02, 0xdd, 0xb9, 0x1b, 0xbe, 0xef, 0x70, 0xf8, 0x98, 0x23, 0xae, 0xb1,
+	0x28, 0x0c, 0x31, 0x28, 0xac, 0x1f, 0x33, 0xa4, 0x05, 0x28, 0xd4, 0x39, 0x83, 0x72, 0x95, 0x86,
+	0xaf, 0x73, 0xcc, 0x11, 0x66, 0x60, 0x25, 0x0b, 0x32, 0x6c, 0xbc, 0x3b, 0x53, 0x20, 0x67, 0xa4,
+	0xc8, 0xc0, 0xb4, 0x94, 0xb1, 0x49, 0x53, 0xc4, 0x6c, 0x48, 0xd6, 0x1b, 0xe4, 0xe1, 0x2a, 0x59,
+	0xaf, 0x92, 0x76, 0xd5, 0xe5, 0x47, 0x5b, 0x78, 0x8f, 0xa0, 0x52, 0x97, 0x8c, 0x9c, 0x1f, 0xc0,
+	0x2a, 0x51, 0xac, 0xbc, 0x33, 0x21, 0x34, 0x23, 0x2b, 0xa3, 0x52, 0xaf, 0xe0, 0x3b, 0x7d, 0x67,
+	0x70, 0x32, 0xee, 0x5a, 0xf2, 0xa4, 0x57, 0xa8, 0xf4, 0x64, 0xc9, 0xd8, 0xeb, 0xf6, 0x4e, 0x5b,
+	0x52, 0x6b, 0x82, 0x4a, 0x22, 0x9d, 0x27, 0x58, 0xf8, 0x9d, 0xbe, 0x33, 0x70, 0xc7, 0xdd, 0x8a,
+	0x8c, 0x2a, 0xe0, 0x9d, 0x8b, 0xde, 0x1b, 0x69, 0xc6, 0xde, 0xff, 0xb1, 0x5e, 0x58, 0xb4, 0x1b,
+	0xb8, 0x15, 0xa7, 0xf1, 0x9c, 0x08, 0x39, 0xa7, 0xcb, 0x28, 0xd1, 0x65, 0xa1, 0x38, 0x9e, 0x21,
+	0x89, 0x6c, 0x80, 0xff, 0xb7, 0xdf, 0x19, 0xb8, 0x63, 0xbf, 0x99, 0x18, 0x36, 0x03, 0xa3, 0xca,
+	0x7b, 0x17, 0xe2, 0x7f, 0xc9, 0x86, 0xd4, 0x14, 0xbb, 0x3c, 0xd7, 0xe6, 0xf5, 0xf6, 0xcc, 0x06,
+	0x5e, 0x89, 0xe3, 0xd8, 0x64, 0x85, 0x8a, 0x19, 0x75, 0xe5, 0x3f, 0x5b, 0x71, 0xd4, 0x60, 0x5b,
+	0x72, 0xff, 0xf2, 0xb9, 0x09, 0x9c, 0xf5, 0x26, 0x70, 0xbe, 0x37, 0x81, 0xf3, 0xbe, 0x0d, 0x5a,
+	0xeb, 0x6d, 0xd0, 0xfa, 0xda, 0x06, 0xad, 0xe7, 0xe1, 0xd4, 0xc8, 0xc3, 0xbf, 0xd7, 0x26, 0x6c,
+	0xbe, 0x3f, 0xfc, 0xdd, 0x25, 0x27, 0xae, 0xbd, 0xe2, 0xcd, 0xcf, 0x00, 0x67, 0xd2, 0x88, 0xe2,
+	0xfa, 0x01, 0x00, 0x00,
 }
 
 func (m *PersistentMetadata) Marshal() (dAtA []byte, err error) {
@@ -158,6 +179,18 @@ func (m *PersistentMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.CompactedIndex != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CompactedIndex))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.StorageSize != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.StorageSize))
+		i--
+		dAtA[i] = 0x31
+	}
 	if len(m.CurrentlyDispatchedItems) > 0 {
 		for iNdEx := len(m.CurrentlyDispatchedItems) - 1; iNdEx >= 0; iNdEx-- {
 			i -= 8
@@ -226,6 +259,12 @@ func (m *PersistentMetadata) Size() (n int) {
 	if len(m.CurrentlyDispatchedItems) > 0 {
 		n += 1 + sovMeta(uint64(len(m.CurrentlyDispatchedItems)*8)) + len(m.CurrentlyDispatchedItems)*8
 	}
+	if m.StorageSize != 0 {
+		n += 9
+	}
+	if m.CompactedIndex != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -356,6 +395,26 @@ func (m *PersistentMetadata) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field CurrentlyDispatchedItems", wireType)
 			}
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageSize", wireType)
+			}
+			m.StorageSize = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageSize = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactedIndex", wireType)
+			}
+			m.CompactedIndex = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompactedIndex = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
@@ -32,9 +33,10 @@ type obsQueue[T request.Request] struct {
 	queueBatchSizeInst      metric.Int64Histogram
 	queueBatchSizeBytesInst metric.Int64Histogram
 	tracer                  trace.Tracer
+	logger                  *zap.Logger
 }
 
-func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T], error) {
+func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (*obsQueue[T], error) {
 	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
 	if err != nil {
 		return nil, err
@@ -65,6 +67,7 @@ func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T
 		tb:         tb,
 		metricAttr: metric.WithAttributeSet(attribute.NewSet(exporterAttr)),
 		tracer:     tracer,
+		logger:     set.Telemetry.Logger,
 	}
 
 	switch set.Signal {
@@ -105,3 +108,13 @@ func (or *obsQueue[T]) Offer(ctx context.Context, req T) error {
 	}
 	return err
 }
+
+// onShed records the items of a request dropped from the full queue to make space for a higher priority request as
+// failed to be enqueued.
+func (or *obsQueue[T]) onShed(ctx context.Context, req T) {
+	numItems := req.ItemsCount()
+	if or.enqueueFailedInst != nil {
+		or.enqueueFailedInst.Add(ctx, int64(numItems), or.metricAttr)
+	}
+	or.logger.Warn("Dropping lower priority request from the full sending queue", zap.Int("dropped_items", numItems))
+}
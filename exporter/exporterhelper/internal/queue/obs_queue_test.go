@@ -12,6 +12,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -99,6 +101,45 @@ func TestObsQueueLogsFailure(t *testing.T) {
 		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
 }
 
+func TestObsQueueShedLowerPriority(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	core, observed := observer.New(zap.WarnLevel)
+	set := Settings[request.Request]{
+		SizerType:  request.SizerTypeItems,
+		ItemsSizer: request.NewItemsSizer(),
+		Capacity:   6,
+		Signal:     pipeline.SignalLogs,
+		ID:         exporterID,
+		Telemetry:  tt.NewTelemetrySettings(),
+		PriorityFunc: func(_ context.Context, req request.Request) int {
+			// The largest requests have the highest priority.
+			return 3 - req.ItemsCount()
+		},
+		NumPriorities: 2,
+	}
+	set.Telemetry.Logger = zap.New(core)
+	// No consumers, so the requests stay in the queue.
+	q, err := NewQueue(set, func(context.Context, request.Request, Done) {})
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, q.Offer(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, q.Offer(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	// The lower priority request is dropped to make space for the new one.
+	require.NoError(t, q.Offer(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	metadatatest.AssertEqualExporterEnqueueFailedLogRecords(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(exporterKey, exporterID.String())),
+				Value: int64(2),
+			},
+		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	require.Equal(t, 1, observed.FilterMessage("Dropping lower priority request from the full sending queue").Len())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
 func TestObsQueueTracesSizeCapacity(t *testing.T) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
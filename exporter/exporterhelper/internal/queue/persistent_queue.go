@@ -11,10 +11,13 @@ import (
 	"strconv"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pipeline"
@@ -31,6 +34,11 @@ const (
 
 	// metadataKey is the new single key for all queue metadata.
 	metadataKey = "qmv0"
+
+	// maxCompactionRange is the maximum number of item keys, behind the read index, checked by a compaction pass.
+	maxCompactionRange = 100_000
+	// compactionBatchSize is the number of item keys deleted in a single storage batch during compaction.
+	compactionBatchSize = 1_000
 )
 
 var (
@@ -68,18 +76,34 @@ var indexDonePool = sync.Pool{
 //	 write          read    x     └── currently dispatched item
 //	 index          index   x
 //	                        xxxx deleted
+//
+// When waitForResult is set, Offer returns only after the item is durably written to the storage and the result of
+// its processing is known, or the queue is shut down while the item is kept in the storage (write-ahead acknowledgement).
+//
+// If the storage client implements storage.TransactionalClient, every update of the metadata is applied atomically
+// with the related items, so the storage is always consistent, even after a crash.
+//
+// The total size of the serialized items is recorded, so the storage used by the queue can be limited.
+// Items left behind in the storage, for example if the collector crashed while deleting them, are deleted by a
+// compaction pass running in the background every time the queue starts.
 type persistentQueue[T any] struct {
-	logger      *zap.Logger
-	client      storage.Client
-	encoding    Encoding[T]
-	capacity    int64
-	sizerType   request.SizerType
-	activeSizer request.Sizer[T]
-	itemsSizer  request.Sizer[T]
-	bytesSizer  request.Sizer[T]
-	storageID   component.ID
-	id          component.ID
-	signal      pipeline.Signal
+	logger         *zap.Logger
+	telemetry      component.TelemetrySettings
+	tb             *metadata.TelemetryBuilder
+	client         storage.Client
+	txClient       storage.TransactionalClient // client, if it implements storage.TransactionalClient.
+	encoding       Encoding[T]
+	capacity       int64
+	maxStorageSize int64
+	sizerType      request.SizerType
+	activeSizer    request.Sizer[T]
+	itemsSizer     request.Sizer[T]
+	bytesSizer     request.Sizer[T]
+	storageID      component.ID
+	id             component.ID
+	signal         pipeline.Signal
+	// stopCompaction stops the compaction running in the background, and waits until it returns.
+	stopCompaction func()
 
 	// mu guards everything declared below.
 	mu              sync.Mutex
@@ -88,7 +112,10 @@ type persistentQueue[T any] struct {
 	metadata        PersistentMetadata
 	refClient       int64
 	stopped         bool
+	// waiters holds, by item index, the channels of the Offer calls waiting for the result of their item.
+	waiters map[uint64]chan error
 
+	waitForResult   bool
 	blockOnOverflow bool
 }
 
@@ -96,8 +123,10 @@ type persistentQueue[T any] struct {
 func newPersistentQueue[T any](set Settings[T]) readableQueue[T] {
 	pq := &persistentQueue[T]{
 		logger:          set.Telemetry.Logger,
+		telemetry:       set.Telemetry,
 		encoding:        set.Encoding,
 		capacity:        set.Capacity,
+		maxStorageSize:  set.MaxStorageSize,
 		sizerType:       set.SizerType,
 		activeSizer:     set.activeSizer(),
 		itemsSizer:      set.ItemsSizer,
@@ -105,6 +134,8 @@ func newPersistentQueue[T any](set Settings[T]) readableQueue[T] {
 		storageID:       *set.StorageID,
 		id:              set.ID,
 		signal:          set.Signal,
+		waiters:         map[uint64]chan error{},
+		waitForResult:   set.WaitForResult,
 		blockOnOverflow: set.BlockOnOverflow,
 	}
 	pq.hasMoreElements = sync.NewCond(&pq.mu)
@@ -114,14 +145,36 @@ func newPersistentQueue[T any](set Settings[T]) readableQueue[T] {
 
 // Start starts the persistentQueue with the given number of consumers.
 func (pq *persistentQueue[T]) Start(ctx context.Context, host component.Host) error {
-	storageClient, err := toStorageClient(ctx, pq.storageID, host, pq.id, pq.signal)
+	storageClient, err := toStorageClient(ctx, pq.storageID, host, pq.id, pq.signal.String())
 	if err != nil {
 		return err
 	}
+
+	pq.tb, err = metadata.NewTelemetryBuilder(pq.telemetry)
+	if err != nil {
+		return err
+	}
+	metricAttr := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String(exporterKey, pq.id.String()), attribute.String(dataTypeKey, pq.signal.String())))
+	err = pq.tb.RegisterExporterQueueStorageSizeCallback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(pq.StorageSize(), metricAttr)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
 	pq.initClient(ctx, storageClient)
 	return nil
 }
 
+// StorageSize returns the current size in bytes of the serialized items stored by the queue.
+func (pq *persistentQueue[T]) StorageSize() int64 {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.metadata.StorageSize
+}
+
 func (pq *persistentQueue[T]) Size() int64 {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
@@ -144,12 +197,18 @@ func (pq *persistentQueue[T]) requestSize() int64 {
 	return int64(pq.metadata.WriteIndex-pq.metadata.ReadIndex) + int64(len(pq.metadata.CurrentlyDispatchedItems))
 }
 
+// Spill does nothing, the elements are already in the persistent storage.
+func (*persistentQueue[T]) Spill(context.Context, func(context.Context, T) error) error {
+	return nil
+}
+
 func (pq *persistentQueue[T]) Capacity() int64 {
 	return pq.capacity
 }
 
 func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Client) {
 	pq.client = client
+	pq.txClient, _ = client.(storage.TransactionalClient)
 	// Start with a reference 1 which is the reference we use for the producer goroutines and initialization.
 	pq.refClient = 1
 
@@ -166,6 +225,133 @@ func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Cli
 		pq.logger.Info("New queue metadata key not found, attempting to load legacy format.")
 		pq.loadLegacyMetadata(ctx)
 	}
+
+	pq.startCompaction()
+}
+
+// startCompaction deletes the items left behind the read index in the background, so starting the queue is not
+// delayed by a large number of items to delete. The read index is captured before any item is dispatched, so only
+// orphaned items are deleted.
+func (pq *persistentQueue[T]) startCompaction() {
+	end := pq.metadata.ReadIndex
+	_, iterable := pq.client.(storage.IterableClient)
+	if end == 0 || (!iterable && pq.metadata.CompactedIndex >= end) {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	pq.stopCompaction = func() {
+		cancel()
+		<-done
+	}
+	go func() {
+		defer close(done)
+		pq.compact(ctx, end)
+	}()
+}
+
+// compact deletes the items left behind the given read index, for example by a crash while an item was being deleted.
+// All the items dispatched before the start are moved back to the queue, so every item key below the read index
+// is orphaned. If the storage client implements storage.IterableClient, the orphaned keys are found by iterating
+// over the stored keys. Otherwise, only up to maxCompactionRange keys are checked, starting from the index where the
+// previous compaction stopped.
+//
+// The items are deleted in batches of compactionBatchSize keys without holding the mutex, so the queue can be used
+// meanwhile: the keys below the read index are never written again.
+func (pq *persistentQueue[T]) compact(ctx context.Context, end uint64) {
+	pq.mu.Lock()
+	start := pq.metadata.CompactedIndex
+	pq.mu.Unlock()
+
+	iterated := false
+	var err error
+	if iterClient, ok := pq.client.(storage.IterableClient); ok {
+		if iterated, err = pq.deleteStoredItems(ctx, iterClient, end); !iterated {
+			pq.logger.Warn("Failed iterating over the keys of the persistent queue, checking a limited range", zap.Error(err))
+		}
+	}
+	if !iterated {
+		err = pq.deleteItemRange(ctx, start, end)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		pq.logger.Warn("Failed compacting the persistent queue", zap.Error(err))
+		return
+	}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.metadata.CompactedIndex >= end {
+		return
+	}
+	pq.metadata.CompactedIndex = end
+	metadataBytes, err := pq.metadata.Marshal()
+	if err == nil {
+		err = pq.client.Set(ctx, metadataKey, metadataBytes)
+	}
+	if err != nil {
+		pq.logger.Warn("Failed to persist the compacted index", zap.Error(err))
+		return
+	}
+	pq.logger.Debug("Compacted the persistent queue", zap.Uint64("toIndex", end))
+}
+
+// deleteStoredItems deletes the stored items below the end index, iterating over the stored keys one page of
+// compactionBatchSize orphaned keys at a time, so the keys are not all loaded in memory. It returns false if the
+// keys could not be iterated over.
+func (pq *persistentQueue[T]) deleteStoredItems(ctx context.Context, client storage.IterableClient, end uint64) (bool, error) {
+	from := ""
+	for {
+		var keys []string
+		err := client.Range(ctx, from, "", func(key string, _ []byte) bool {
+			// Keys that are not item indexes are metadata.
+			if index, parseErr := strconv.ParseUint(key, 10, 64); parseErr == nil && index < end {
+				keys = append(keys, key)
+			}
+			// The next page starts right after the last key iterated over.
+			from = key + "\x00"
+			return len(keys) < compactionBatchSize
+		})
+		if err != nil {
+			return false, err
+		}
+		if err = pq.deleteItems(ctx, keys); err != nil || len(keys) < compactionBatchSize {
+			return true, err
+		}
+	}
+}
+
+// deleteItemRange deletes the items from the start index to the end index, at most maxCompactionRange of them.
+func (pq *persistentQueue[T]) deleteItemRange(ctx context.Context, start, end uint64) error {
+	if end > maxCompactionRange {
+		start = max(start, end-maxCompactionRange)
+	}
+	for start < end {
+		batchEnd := min(end, start+compactionBatchSize)
+		keys := make([]string, 0, batchEnd-start)
+		for index := start; index < batchEnd; index++ {
+			keys = append(keys, getItemKey(index))
+		}
+		if err := pq.deleteItems(ctx, keys); err != nil {
+			return err
+		}
+		start = batchEnd
+	}
+	return nil
+}
+
+// deleteItems deletes the items with the given keys in a single storage batch.
+func (pq *persistentQueue[T]) deleteItems(ctx context.Context, keys []string) error {
+	if err := ctx.Err(); err != nil || len(keys) == 0 {
+		return err
+	}
+	ops := make([]*storage.Operation, len(keys))
+	for i, key := range keys {
+		ops[i] = storage.DeleteOperation(key)
+	}
+	return pq.client.Batch(ctx, ops...)
 }
 
 // loadQueueMetadata loads queue metadata from the consolidated key
@@ -189,6 +375,7 @@ func (pq *persistentQueue[T]) loadQueueMetadata(ctx context.Context) error {
 		zap.Uint64("writeIndex", pq.metadata.WriteIndex),
 		zap.Int64("itemsSize", pq.metadata.ItemsSize),
 		zap.Int64("bytesSize", pq.metadata.BytesSize),
+		zap.Int64("storageSize", pq.metadata.StorageSize),
 		zap.Int("dispatchedItems", len(pq.metadata.CurrentlyDispatchedItems)))
 
 	return nil
@@ -249,11 +436,22 @@ func (pq *persistentQueue[T]) Shutdown(ctx context.Context) error {
 		return nil
 	}
 
+	if pq.tb != nil {
+		pq.tb.Shutdown()
+	}
+	if pq.stopCompaction != nil {
+		pq.stopCompaction()
+	}
+
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 	// Mark this queue as stopped, so consumer don't start any more work.
 	pq.stopped = true
 	pq.hasMoreElements.Broadcast()
+	// The items are kept in the storage and processed after restart, so the waiting callers are released.
+	for index := range pq.waiters {
+		pq.notifyWaiter(index, nil)
+	}
 	return pq.unrefClient(ctx)
 }
 
@@ -271,46 +469,127 @@ func (pq *persistentQueue[T]) unrefClient(ctx context.Context) error {
 // without violating capacity restrictions. If success returns no error.
 // It returns ErrQueueIsFull if no space is currently available.
 func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
+	size := pq.activeSizer.Sizeof(req)
+	// Ignore empty requests, see https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#empty-telemetry-envelopes
+	if size == 0 {
+		return nil
+	}
+
+	if size < 0 {
+		return errInvalidSize
+	}
+
+	// If element larger than the capacity, will never been able to add it.
+	if size > pq.capacity {
+		return errSizeTooLarge
+	}
+
+	reqBuf, err := pq.encoding.Marshal(ctx, req)
+	if err != nil {
+		return err
+	}
+	storageSize := int64(len(reqBuf))
+	// If element larger than the maximum storage size, will never been able to add it.
+	if pq.maxStorageSize > 0 && storageSize > pq.maxStorageSize {
+		return errSizeTooLarge
+	}
+
+	index, waiter, err := pq.add(ctx, req, reqBuf, size)
+	if err != nil || waiter == nil {
+		return err
+	}
+
+	select {
+	case doneErr := <-waiter:
+		return doneErr
+	case <-ctx.Done():
+		// The item is durably stored, so it is still processed, but the result is no longer awaited.
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+		delete(pq.waiters, index)
+		return ctx.Err()
+	}
+}
+
+// add writes the serialized request to the storage once there is enough space available. If waitForResult is set,
+// it returns the index of the request and the channel where the result of processing the request is sent.
+func (pq *persistentQueue[T]) add(ctx context.Context, req T, reqBuf []byte, size int64) (uint64, chan error, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
-	size := pq.activeSizer.Sizeof(req)
-	for pq.internalSize()+size > pq.capacity {
+	storageSize := int64(len(reqBuf))
+	for pq.internalSize()+size > pq.capacity || pq.exceedsMaxStorageSize(storageSize) {
 		if !pq.blockOnOverflow {
-			return ErrQueueIsFull
+			return 0, nil, ErrQueueIsFull
 		}
 		if err := pq.hasMoreSpace.Wait(ctx); err != nil {
-			return err
+			return 0, nil, err
 		}
 	}
 
-	pq.metadata.ItemsSize += pq.itemsSizer.Sizeof(req)
-	pq.metadata.BytesSize += pq.bytesSizer.Sizeof(req)
+	if err := pq.putBytes(ctx, reqBuf, pq.itemsSizer.Sizeof(req), pq.bytesSizer.Sizeof(req)); err != nil {
+		return 0, nil, err
+	}
+
+	index := pq.metadata.WriteIndex - 1
+	if !pq.waitForResult {
+		return index, nil, nil
+	}
+	waiter := make(chan error, 1)
+	pq.waiters[index] = waiter
+	return index, waiter, nil
+}
+
+// notifyWaiter sends the result of processing the item of the given index to the Offer call waiting for it, if any.
+// Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) notifyWaiter(index uint64, err error) {
+	if waiter, ok := pq.waiters[index]; ok {
+		delete(pq.waiters, index)
+		waiter <- err
+	}
+}
 
-	return pq.putInternal(ctx, req)
+// exceedsMaxStorageSize returns true if adding an item of the given serialized size exceeds the storage limit.
+// Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) exceedsMaxStorageSize(storageSize int64) bool {
+	return pq.maxStorageSize > 0 && pq.metadata.StorageSize+storageSize > pq.maxStorageSize
 }
 
 // putInternal adds the request to the storage without updating items/bytes sizes.
 func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
-	pq.metadata.WriteIndex++
-
-	metadataBuf, err := pq.metadata.Marshal()
+	reqBuf, err := pq.encoding.Marshal(ctx, req)
 	if err != nil {
 		return err
 	}
+	return pq.putBytes(ctx, reqBuf, 0, 0)
+}
 
-	reqBuf, err := pq.encoding.Marshal(ctx, req)
+// putBytes adds the serialized request to the storage, and the given sizes to the items/bytes sizes.
+func (pq *persistentQueue[T]) putBytes(ctx context.Context, reqBuf []byte, itemsSize, bytesSize int64) error {
+	pq.metadata.ItemsSize += itemsSize
+	pq.metadata.BytesSize += bytesSize
+	pq.metadata.WriteIndex++
+	pq.metadata.StorageSize += int64(len(reqBuf))
+
+	metadataBuf, err := pq.metadata.Marshal()
 	if err != nil {
 		return err
 	}
+
 	// Carry out a transaction where we both add the item and update the write index
 	ops := []*storage.Operation{
 		storage.SetOperation(metadataKey, metadataBuf),
 		storage.SetOperation(getItemKey(pq.metadata.WriteIndex-1), reqBuf),
 	}
-	if err := pq.client.Batch(ctx, ops...); err != nil {
-		// At this moment, metadata may be updated in the storage, so we cannot just revert changes to the
-		// metadata, rely on the sizes being fixed on complete draining.
+	if err := pq.batch(ctx, ops...); err != nil {
+		// Unless the storage client is transactional, metadata may be updated in the storage at this moment, so we
+		// cannot just revert changes to the metadata, rely on the sizes being fixed on complete draining.
+		if pq.txClient != nil {
+			pq.metadata.ItemsSize -= itemsSize
+			pq.metadata.BytesSize -= bytesSize
+			pq.metadata.WriteIndex--
+			pq.metadata.StorageSize -= int64(len(reqBuf))
+		}
 		return err
 	}
 
@@ -331,15 +610,14 @@ func (pq *persistentQueue[T]) Read(ctx context.Context) (context.Context, T, Don
 
 		// Read until either a successful retrieved element or no more elements in the storage.
 		for pq.metadata.ReadIndex != pq.metadata.WriteIndex {
-			index, req, reqCtx, consumed := pq.getNextItem(ctx)
+			index, req, reqCtx, storageSize, consumed := pq.getNextItem(ctx)
 			// Ensure the used size are in sync when queue is drained.
 			if pq.requestSize() == 0 {
-				pq.metadata.BytesSize = 0
-				pq.metadata.ItemsSize = 0
+				pq.resetSizes()
 			}
 			if consumed {
 				id := indexDonePool.Get().(*indexDone)
-				id.reset(index, pq.itemsSizer.Sizeof(req), pq.bytesSizer.Sizeof(req), pq)
+				id.reset(index, pq.itemsSizer.Sizeof(req), pq.bytesSizer.Sizeof(req), storageSize, pq)
 				return reqCtx, req, id, true
 			}
 			// More space available, data was dropped.
@@ -352,10 +630,18 @@ func (pq *persistentQueue[T]) Read(ctx context.Context) (context.Context, T, Don
 	}
 }
 
-// getNextItem pulls the next available item from the persistent storage along with its index. Once processing is
-// finished, the index should be called with onDone to clean up the storage. If no new item is available,
-// returns false.
-func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, context.Context, bool) {
+// resetSizes resets all the recorded sizes, used to ensure they are in sync when the queue is drained.
+// Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) resetSizes() {
+	pq.metadata.BytesSize = 0
+	pq.metadata.ItemsSize = 0
+	pq.metadata.StorageSize = 0
+}
+
+// getNextItem pulls the next available item from the persistent storage along with its index and serialized size.
+// Once processing is finished, the index should be called with onDone to clean up the storage. If no new item is
+// available, returns false.
+func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, context.Context, int64, bool) {
 	index := pq.metadata.ReadIndex
 	// Increase here, so even if errors happen below, it always iterates
 	pq.metadata.ReadIndex++
@@ -365,34 +651,45 @@ func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, conte
 	restoredCtx := context.Background()
 	metadataBytes, err := pq.metadata.Marshal()
 	if err != nil {
-		return 0, req, restoredCtx, false
+		return 0, req, restoredCtx, 0, false
 	}
 
 	getOp := storage.GetOperation(getItemKey(index))
-	err = pq.client.Batch(ctx, storage.SetOperation(metadataKey, metadataBytes), getOp)
+	err = pq.batch(ctx, storage.SetOperation(metadataKey, metadataBytes), getOp)
 	if err == nil {
 		restoredCtx, req, err = pq.encoding.Unmarshal(getOp.Value)
 	}
 
+	storageSize := int64(len(getOp.Value))
 	if err != nil {
 		pq.logger.Debug("Failed to dispatch item", zap.Error(err))
+		pq.notifyWaiter(index, err)
+		pq.decreaseStorageSize(storageSize)
 		// We need to make sure that currently dispatched items list is cleaned
 		if err = pq.itemDispatchingFinish(ctx, index); err != nil {
 			pq.logger.Error("Error deleting item from queue", zap.Error(err))
 		}
 
-		return 0, req, restoredCtx, false
+		return 0, req, restoredCtx, 0, false
 	}
 
 	// Increase the reference count, so the client is not closed while the request is being processed.
 	// The client cannot be closed because we hold the lock since last we checked `stopped`.
 	pq.refClient++
 
-	return index, req, restoredCtx, true
+	return index, req, restoredCtx, storageSize, true
+}
+
+// decreaseStorageSize records that an item of the given serialized size is removed. Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) decreaseStorageSize(storageSize int64) {
+	pq.metadata.StorageSize -= storageSize
+	if pq.metadata.StorageSize < 0 {
+		pq.metadata.StorageSize = 0
+	}
 }
 
 // onDone should be called to remove the item of the given index from the queue once processing is finished.
-func (pq *persistentQueue[T]) onDone(index uint64, itemsSize, bytesSize int64, consumeErr error) {
+func (pq *persistentQueue[T]) onDone(index uint64, itemsSize, bytesSize, storageSize int64, consumeErr error) {
 	// Delete the item from the persistent storage after it was processed.
 	pq.mu.Lock()
 	// Always unref client even if the consumer is shutdown because we always ref it for every valid request.
@@ -406,9 +703,12 @@ func (pq *persistentQueue[T]) onDone(index uint64, itemsSize, bytesSize int64, c
 	if experr.IsShutdownErr(consumeErr) {
 		// The queue is shutting down, don't mark the item as dispatched, so it's picked up again after restart.
 		// TODO: Handle partially delivered requests by updating their values in the storage.
+		pq.notifyWaiter(index, nil)
 		return
 	}
 
+	pq.notifyWaiter(index, consumeErr)
+
 	pq.metadata.BytesSize -= bytesSize
 	if pq.metadata.BytesSize < 0 {
 		pq.metadata.BytesSize = 0
@@ -417,6 +717,7 @@ func (pq *persistentQueue[T]) onDone(index uint64, itemsSize, bytesSize int64, c
 	if pq.metadata.ItemsSize < 0 {
 		pq.metadata.ItemsSize = 0
 	}
+	pq.decreaseStorageSize(storageSize)
 
 	if err := pq.itemDispatchingFinish(context.Background(), index); err != nil {
 		pq.logger.Error("Error deleting item from queue", zap.Error(err))
@@ -467,6 +768,9 @@ func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, disp
 	if cleanupErr != nil {
 		pq.logger.Debug("Failed cleaning items left by consumers", zap.Error(cleanupErr))
 	}
+	for _, op := range retrieveBatch {
+		pq.decreaseStorageSize(int64(len(op.Value)))
+	}
 
 	if retrieveErr != nil {
 		pq.logger.Warn("Failed retrieving items left by consumers", zap.Error(retrieveErr))
@@ -512,8 +816,7 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 
 	// Ensure the used size are in sync when queue is drained.
 	if pq.requestSize() == 0 {
-		pq.metadata.BytesSize = 0
-		pq.metadata.ItemsSize = 0
+		pq.resetSizes()
 	}
 
 	metadataBytes, err := pq.metadata.Marshal()
@@ -523,7 +826,7 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 
 	setOp := storage.SetOperation(metadataKey, metadataBytes)
 	deleteOp := storage.DeleteOperation(getItemKey(index))
-	err = pq.client.Batch(ctx, setOp, deleteOp)
+	err = pq.batch(ctx, setOp, deleteOp)
 	if err == nil {
 		// Everything ok, exit
 		return nil
@@ -547,7 +850,15 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 	return nil
 }
 
-func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, signal pipeline.Signal) (storage.Client, error) {
+// batch runs the operations atomically if the storage client supports it.
+func (pq *persistentQueue[T]) batch(ctx context.Context, ops ...*storage.Operation) error {
+	if pq.txClient != nil {
+		return pq.txClient.AtomicBatch(ctx, ops...)
+	}
+	return pq.client.Batch(ctx, ops...)
+}
+
+func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, name string) (storage.Client, error) {
 	ext, found := host.GetExtensions()[storageID]
 	if !found {
 		return nil, errNoStorageClient
@@ -558,7 +869,7 @@ func toStorageClient(ctx context.Context, storageID component.ID, host component
 		return nil, errWrongExtensionType
 	}
 
-	return storageExt.GetClient(ctx, component.KindExporter, ownerID, signal.String())
+	return storageExt.GetClient(ctx, component.KindExporter, ownerID, name)
 }
 
 func getItemKey(index uint64) string {
@@ -605,24 +916,26 @@ func bytesToItemIndexArray(buf []byte) ([]uint64, error) {
 }
 
 type indexDone struct {
-	index     uint64
-	itemsSize int64
-	bytesSize int64
-	queue     interface {
-		onDone(uint64, int64, int64, error)
+	index       uint64
+	itemsSize   int64
+	bytesSize   int64
+	storageSize int64
+	queue       interface {
+		onDone(uint64, int64, int64, int64, error)
 	}
 }
 
-func (id *indexDone) reset(index uint64, itemsSize, bytesSize int64, queue interface {
-	onDone(uint64, int64, int64, error)
+func (id *indexDone) reset(index uint64, itemsSize, bytesSize, storageSize int64, queue interface {
+	onDone(uint64, int64, int64, int64, error)
 },
 ) {
 	id.index = index
 	id.itemsSize = itemsSize
 	id.bytesSize = bytesSize
+	id.storageSize = storageSize
 	id.queue = queue
 }
 
 func (id *indexDone) OnDone(err error) {
-	id.queue.onDone(id.index, id.itemsSize, id.bytesSize, err)
+	id.queue.onDone(id.index, id.itemsSize, id.bytesSize, id.storageSize, err)
 }
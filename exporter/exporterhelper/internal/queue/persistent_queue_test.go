@@ -17,11 +17,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
@@ -500,7 +504,7 @@ func TestToStorageClient(t *testing.T) {
 			ownerID := component.MustNewID("foo_exporter")
 
 			// execute
-			client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces)
+			client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces.String())
 
 			// verify
 			if tt.expectedError != nil {
@@ -530,7 +534,7 @@ func TestInvalidStorageExtensionType(t *testing.T) {
 	ownerID := component.MustNewID("foo_exporter")
 
 	// execute
-	client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces)
+	client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces.String())
 
 	// we should get an error about the extension type
 	require.ErrorIs(t, err, errWrongExtensionType)
@@ -1177,6 +1181,198 @@ func TestPersistentQueue_RestoredUsedSizeIsCorrectedOnDrain(t *testing.T) {
 	require.NoError(t, pq.Shutdown(context.Background()))
 }
 
+func TestPersistentQueue_MaxStorageSize(t *testing.T) {
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	// Every item below is serialized as 2 bytes, so only 3 items fit.
+	set.MaxStorageSize = 6
+	pq := newPersistentQueue[int64](set).(*persistentQueue[int64])
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: storagetest.NewMockStorageExtension(nil)})))
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pq.Offer(context.Background(), int64(10)))
+	}
+	assert.Equal(t, int64(6), pq.StorageSize())
+	require.ErrorIs(t, pq.Offer(context.Background(), int64(10)), ErrQueueIsFull)
+	assert.Equal(t, int64(3), pq.Size())
+
+	// Items larger than the limit are never accepted.
+	require.ErrorIs(t, pq.Offer(context.Background(), int64(1000000)), errSizeTooLarge)
+
+	assert.True(t, consume(pq, func(context.Context, int64) error { return nil }))
+	assert.Equal(t, int64(4), pq.StorageSize())
+	require.NoError(t, pq.Offer(context.Background(), int64(10)))
+	assert.Equal(t, int64(6), pq.StorageSize())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_MaxStorageSizeBlockOnOverflow(t *testing.T) {
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	set.MaxStorageSize = 2
+	set.BlockOnOverflow = true
+	pq := newPersistentQueue[int64](set).(*persistentQueue[int64])
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: storagetest.NewMockStorageExtension(nil)})))
+	require.NoError(t, pq.Offer(context.Background(), int64(10)))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, pq.Offer(context.Background(), int64(20)))
+	}()
+	assert.True(t, consume(pq, func(_ context.Context, val int64) error {
+		assert.Equal(t, int64(10), val)
+		return nil
+	}))
+	<-done
+	assert.Equal(t, int64(2), pq.StorageSize())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_StorageSizeRestoredAfterRestart(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	require.NoError(t, pq.Offer(context.Background(), int64(10)))
+	require.NoError(t, pq.Offer(context.Background(), int64(200)))
+	require.NoError(t, pq.Offer(context.Background(), int64(3000)))
+	assert.Equal(t, int64(9), pq.StorageSize())
+
+	// Read the first item without finishing it, so it is moved back to the queue after restart.
+	_, _, _, ok := pq.Read(context.Background())
+	require.True(t, ok)
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	pq = createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	assert.Equal(t, int64(3), pq.Size())
+	assert.Equal(t, int64(9), pq.StorageSize())
+	for i := 0; i < 3; i++ {
+		assert.True(t, consume(pq, func(context.Context, int64) error { return nil }))
+	}
+	assert.Equal(t, int64(0), pq.StorageSize())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_CompactionOnStart(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pq.Offer(context.Background(), int64(10)))
+		assert.True(t, consume(pq, func(context.Context, int64) error { return nil }))
+	}
+	require.NoError(t, pq.Offer(context.Background(), int64(20)))
+
+	// Simulate items left behind by a crash while they were deleted.
+	client, err := ext.GetClient(context.Background(), component.KindExporter, pq.id, pq.signal.String())
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), getItemKey(0), []byte("10")))
+	require.NoError(t, client.Set(context.Background(), getItemKey(2), []byte("10")))
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	pq = createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	// The compaction runs in the background.
+	assert.Eventually(t, func() bool {
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+		return pq.metadata.CompactedIndex == 3
+	}, 1*time.Second, 10*time.Millisecond)
+	for _, index := range []uint64{0, 2} {
+		val, err := client.Get(context.Background(), getItemKey(index))
+		require.NoError(t, err)
+		assert.Nil(t, val)
+	}
+	// Items in the queue are not removed.
+	assert.Equal(t, int64(1), pq.Size())
+	assert.True(t, consume(pq, func(_ context.Context, val int64) error {
+		assert.Equal(t, int64(20), val)
+		return nil
+	}))
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_CompactionLimitedRange(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	pq.metadata.ReadIndex = maxCompactionRange + 10
+	pq.metadata.WriteIndex = maxCompactionRange + 10
+	pq.compact(context.Background(), pq.metadata.ReadIndex)
+	assert.Equal(t, uint64(maxCompactionRange+10), pq.metadata.CompactedIndex)
+
+	// Nothing to compact when the read index did not move.
+	pq.compact(context.Background(), pq.metadata.ReadIndex)
+	assert.Equal(t, uint64(maxCompactionRange+10), pq.metadata.CompactedIndex)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_CompactionIterableClient(t *testing.T) {
+	client := storage.NewMemoryClient()
+	pq := createTestPersistentQueueWithClient(client)
+	require.NoError(t, pq.Offer(context.Background(), int64(10)))
+	pq.metadata.ReadIndex = maxCompactionRange + 10
+	pq.metadata.WriteIndex = maxCompactionRange + 11
+	pq.metadata.CompactedIndex = maxCompactionRange
+	// Items outside the range checked without listing the keys.
+	require.NoError(t, client.Set(context.Background(), getItemKey(0), []byte("10")))
+	require.NoError(t, client.Set(context.Background(), getItemKey(5), []byte("10")))
+	require.NoError(t, client.Set(context.Background(), getItemKey(maxCompactionRange+10), []byte("20")))
+
+	pq.compact(context.Background(), pq.metadata.ReadIndex)
+	assert.Equal(t, uint64(maxCompactionRange+10), pq.metadata.CompactedIndex)
+	keys, err := client.(storage.IterableClient).List(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{getItemKey(maxCompactionRange + 10), metadataKey}, keys)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_CompactionIterableClientPages(t *testing.T) {
+	client := storage.NewMemoryClient()
+	pq := createTestPersistentQueueWithClient(client)
+	// More orphaned items than deleted by a single batch, interleaved with the items in the queue.
+	end := uint64(2*compactionBatchSize + 10)
+	for index := uint64(0); index < end+5; index++ {
+		require.NoError(t, client.Set(context.Background(), getItemKey(index), []byte("10")))
+	}
+	pq.metadata.ReadIndex = end
+	pq.metadata.WriteIndex = end + 5
+
+	pq.compact(context.Background(), end)
+	assert.Equal(t, end, pq.metadata.CompactedIndex)
+	count, err := client.(storage.IterableClient).Count(context.Background(), "")
+	require.NoError(t, err)
+	// The items in the queue and the metadata are kept.
+	assert.Equal(t, 6, count)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_CompactionStoppedOnShutdown(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	pq.metadata.ReadIndex = maxCompactionRange
+	pq.metadata.WriteIndex = maxCompactionRange
+	pq.startCompaction()
+	// The compaction is stopped before the client is closed, the next start compacts the remaining items.
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_StorageSizeMetric(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	set.Telemetry = tt.NewTelemetrySettings()
+	pq := newPersistentQueue[int64](set)
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: storagetest.NewMockStorageExtension(nil)})))
+	require.NoError(t, pq.Offer(context.Background(), int64(100)))
+
+	metadatatest.AssertEqualExporterQueueStorageSize(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(exporterKey, set.ID.String()),
+					attribute.String(dataTypeKey, pipeline.SignalTraces.String())),
+				Value: int64(3),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
 func requireCurrentlyDispatchedItemsEqual(t *testing.T, pq *persistentQueue[int64], compare []uint64) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
@@ -1193,3 +1389,169 @@ func itemIndexArrayToBytes(arr []uint64) []byte {
 	}
 	return buf
 }
+
+func TestPersistentQueue_EmptyAndTooLargeRequests(t *testing.T) {
+	for _, sizerType := range []request.SizerType{request.SizerTypeItems, request.SizerTypeBytes} {
+		t.Run(sizerType.String(), func(t *testing.T) {
+			pq := createTestPersistentQueue(t, storagetest.NewMockStorageExtension(nil), sizerType, 100)
+
+			// Empty requests are ignored.
+			require.NoError(t, pq.Offer(context.Background(), int64(0)))
+			assert.Equal(t, int64(0), pq.Size())
+			assert.Equal(t, int64(0), pq.StorageSize())
+
+			// Requests larger than the capacity are never accepted.
+			require.ErrorIs(t, pq.Offer(context.Background(), int64(101)), errSizeTooLarge)
+			require.ErrorIs(t, pq.Offer(context.Background(), int64(-1)), errInvalidSize)
+			require.NoError(t, pq.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestPersistentQueue_BytesSizer(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueue(t, ext, request.SizerTypeBytes, 100)
+	require.NoError(t, pq.Offer(context.Background(), int64(5)))
+	require.NoError(t, pq.Offer(context.Background(), int64(4)))
+	assert.Equal(t, int64(90), pq.Size())
+	require.ErrorIs(t, pq.Offer(context.Background(), int64(2)), ErrQueueIsFull)
+
+	// The used size is restored after restart.
+	require.NoError(t, pq.Shutdown(context.Background()))
+	pq = createTestPersistentQueue(t, ext, request.SizerTypeBytes, 100)
+	assert.Equal(t, int64(90), pq.Size())
+	assert.True(t, consume(pq, func(context.Context, int64) error { return nil }))
+	assert.Equal(t, int64(40), pq.Size())
+	require.NoError(t, pq.Offer(context.Background(), int64(6)))
+	assert.Equal(t, int64(100), pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func createTestPersistentQueueWaitForResult(t *testing.T, ext storage.Extension) *persistentQueue[int64] {
+	set := newSettingsWithStorage(request.SizerTypeItems, 1000)
+	set.WaitForResult = true
+	pq := newPersistentQueue[int64](set).(*persistentQueue[int64])
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: ext})))
+	return pq
+}
+
+func TestPersistentQueue_WaitForResult(t *testing.T) {
+	pq := createTestPersistentQueueWaitForResult(t, storagetest.NewMockStorageExtension(nil))
+	consumeErr := errors.New("consume error")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.ErrorIs(t, pq.Offer(context.Background(), int64(10)), consumeErr)
+		assert.NoError(t, pq.Offer(context.Background(), int64(20)))
+	}()
+
+	// The item is durably written before the result is awaited.
+	assert.Eventually(t, func() bool { return pq.Size() == 10 }, time.Second, 10*time.Millisecond)
+	assert.True(t, consume(pq, func(context.Context, int64) error { return consumeErr }))
+	assert.True(t, consume(pq, func(_ context.Context, val int64) error {
+		assert.Equal(t, int64(20), val)
+		return nil
+	}))
+	<-done
+	assert.Empty(t, pq.waiters)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_WaitForResultContextCanceled(t *testing.T) {
+	pq := createTestPersistentQueueWaitForResult(t, storagetest.NewMockStorageExtension(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.ErrorIs(t, pq.Offer(ctx, int64(10)), context.Canceled)
+	}()
+	assert.Eventually(t, func() bool { return pq.Size() == 10 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	// The item is still processed.
+	assert.True(t, consume(pq, func(context.Context, int64) error { return nil }))
+	assert.Equal(t, int64(0), pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_WaitForResultReleasedOnShutdown(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWaitForResult(t, ext)
+
+	var wg sync.WaitGroup
+	for _, val := range []int64{10, 20} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pq.Offer(context.Background(), val))
+		}()
+	}
+	assert.Eventually(t, func() bool { return pq.Size() == 30 }, time.Second, 10*time.Millisecond)
+
+	// One item is interrupted by shutdown while being processed, the other one is not read.
+	_, _, done, ok := pq.Read(context.Background())
+	require.True(t, ok)
+	done.OnDone(experr.NewShutdownErr(errors.New("shutdown")))
+	require.NoError(t, pq.Shutdown(context.Background()))
+	wg.Wait()
+
+	// Both items are kept in the storage.
+	pq = createTestPersistentQueueWithItemsSizer(t, ext, 1000)
+	assert.Equal(t, int64(30), pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+// fakeTransactionalClient is a transactional client that counts the atomic batches, and fails them while failing is set.
+type fakeTransactionalClient struct {
+	storage.TransactionalClient
+	failing       atomic.Bool
+	atomicBatches atomic.Int64
+}
+
+func (c *fakeTransactionalClient) AtomicBatch(ctx context.Context, ops ...*storage.Operation) error {
+	c.atomicBatches.Add(1)
+	if c.failing.Load() {
+		return errors.New("atomic batch failed")
+	}
+	return c.TransactionalClient.AtomicBatch(ctx, ops...)
+}
+
+func TestPersistentQueue_TransactionalClient(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	storageClient, err := ext.GetClient(context.Background(), component.KindExporter, component.ID{}, "")
+	require.NoError(t, err)
+	client := &fakeTransactionalClient{TransactionalClient: storageClient.(storage.TransactionalClient)}
+	pq := newPersistentQueue[int64](newSettingsWithStorage(request.SizerTypeItems, 1000)).(*persistentQueue[int64])
+	pq.initClient(context.Background(), client)
+
+	require.NoError(t, pq.Offer(context.Background(), int64(10)))
+	assert.Equal(t, int64(1), client.atomicBatches.Load())
+
+	// Nothing is written if the atomic batch fails, so the metadata is reverted.
+	client.failing.Store(true)
+	require.Error(t, pq.Offer(context.Background(), int64(20)))
+	assert.Equal(t, int64(10), pq.Size())
+	assert.Equal(t, uint64(1), pq.metadata.WriteIndex)
+	assert.Equal(t, int64(2), pq.StorageSize())
+
+	client.failing.Store(false)
+	require.NoError(t, pq.Offer(context.Background(), int64(30)))
+	assert.True(t, consume(pq, func(_ context.Context, val int64) error {
+		assert.Equal(t, int64(10), val)
+		return nil
+	}))
+	// The items are read and deleted atomically with the metadata.
+	assert.Equal(t, int64(5), client.atomicBatches.Load())
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	pq = createTestPersistentQueueWithItemsSizer(t, ext, 1000)
+	assert.Equal(t, int64(30), pq.Size())
+	assert.True(t, consume(pq, func(_ context.Context, val int64) error {
+		assert.Equal(t, int64(30), val)
+		return nil
+	}))
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
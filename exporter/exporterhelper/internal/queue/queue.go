@@ -47,6 +47,9 @@ type Done interface {
 
 type ConsumeFunc[T any] func(context.Context, T, Done)
 
+// PriorityFunc returns the priority class of the given element, where 0 is the highest priority.
+type PriorityFunc[T any] func(context.Context, T) int
+
 // Queue defines a producer-consumer exchange which can be backed by e.g. the memory-based ring buffer queue
 // (boundedMemoryQueue) or via a disk-based queue (persistentQueue)
 // Experimental: This API is at the early stage of development and may change without backward compatibility
@@ -61,23 +64,32 @@ type Queue[T any] interface {
 	Size() int64
 	// Capacity returns the capacity of the queue.
 	Capacity() int64
+	// Spill removes the elements that are not read yet from the queue, and passes them to the given function to be
+	// stored elsewhere. It stops at the first error returned by the function, leaving that element in the queue.
+	// The elements of a persistent queue are already stored, so it is a no-op for them.
+	Spill(ctx context.Context, fn func(context.Context, T) error) error
 }
 
 // Settings define internal parameters for a new Queue creation.
 type Settings[T any] struct {
-	ItemsSizer       request.Sizer[T]
-	BytesSizer       request.Sizer[T]
-	SizerType        request.SizerType
-	Capacity         int64
-	NumConsumers     int
-	WaitForResult    bool
-	BlockOnOverflow  bool
-	Signal           pipeline.Signal
-	StorageID        *component.ID
+	ItemsSizer      request.Sizer[T]
+	BytesSizer      request.Sizer[T]
+	SizerType       request.SizerType
+	Capacity        int64
+	NumConsumers    int
+	WaitForResult   bool
+	BlockOnOverflow bool
+	Signal          pipeline.Signal
+	StorageID       *component.ID
+	// MaxStorageSize if positive, limits the size in bytes of the serialized requests stored by the persistent queue.
+	MaxStorageSize   int64
 	ReferenceCounter ReferenceCounter[T]
 	Encoding         Encoding[T]
 	ID               component.ID
 	Telemetry        component.TelemetrySettings
+	// PriorityFunc if set, enables priority lanes in the memory queue. The number of lanes is given by NumPriorities.
+	PriorityFunc  PriorityFunc[T]
+	NumPriorities int
 }
 
 func (set *Settings[T]) activeSizer() request.Sizer[T] {
@@ -101,6 +113,10 @@ func NewQueue[T request.Request](set Settings[T], next ConsumeFunc[T]) (Queue[T]
 	if err != nil {
 		return nil, err
 	}
+	// The memory queue drops the lower priority elements itself, they are recorded as failed to be enqueued.
+	if mq, ok := q.(*memoryQueue[T]); ok {
+		mq.onShed = oq.onShed
+	}
 
 	return oq, nil
 }
@@ -5,6 +5,8 @@ package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhel
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -19,7 +21,8 @@ type Config struct {
 	Enabled bool `mapstructure:"enabled"`
 
 	// WaitForResult determines if incoming requests are blocked until the request is processed or not.
-	// Currently, this option is not available when persistent queue is configured using the storage configuration.
+	// When persistent queue is configured using the storage configuration, requests are written to the storage
+	// before waiting for the result, and are released without error if the queue shuts down before processing them.
 	WaitForResult bool `mapstructure:"wait_for_result"`
 
 	// Sizer determines the type of size measurement used by this component.
@@ -39,12 +42,30 @@ type Config struct {
 	// See https://github.com/open-telemetry/opentelemetry-collector/issues/13822
 	StorageID *component.ID `mapstructure:"storage"`
 
+	// MaxStorageSize if positive, limits the size in bytes of the serialized requests stored by the persistent queue,
+	// in addition to the QueueSize. Requests are rejected, or blocked if BlockOnOverflow is set, when the limit is reached.
+	// Only available when persistent queue is configured using the storage configuration.
+	MaxStorageSize int64 `mapstructure:"max_storage_size"`
+
 	// NumConsumers is the maximum number of concurrent consumers from the queue.
 	// This applies across all different optional configurations from above (e.g. wait_for_result, block_on_overflow, storage, etc.).
 	NumConsumers int `mapstructure:"num_consumers"`
 
+	// AdaptiveConcurrency if set, enables adjusting the number of concurrent export calls between
+	// `min_concurrency` and NumConsumers, based on the observed latency and errors of the export calls.
+	AdaptiveConcurrency configoptional.Optional[AdaptiveConcurrencyConfig] `mapstructure:"adaptive_concurrency"`
+
 	// BatchConfig it configures how the requests are consumed from the queue and batch together during consumption.
 	Batch configoptional.Optional[BatchConfig] `mapstructure:"batch"`
+
+	// DeadLetter if set, enables writing requests that permanently failed to be exported, or exhausted the retries,
+	// to a storage extension instead of dropping them.
+	DeadLetter configoptional.Optional[DeadLetterConfig] `mapstructure:"dead_letter"`
+
+	// Priority if set, enables priority lanes in the queue. Requests are read from the highest priority lane first,
+	// and when the queue is full the lowest priority requests are dropped first.
+	// Currently, this option is not available when persistent queue is configured using the storage configuration.
+	Priority configoptional.Optional[PriorityConfig] `mapstructure:"priority"`
 }
 
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
@@ -56,6 +77,16 @@ func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
 	if !conf.IsSet("batch::sizer") && cfg.Batch.HasValue() {
 		cfg.Batch.Get().Sizer = cfg.Sizer
 	}
+
+	// If the adaptive concurrency is enabled, use the default values for the options not set.
+	if cfg.AdaptiveConcurrency.HasValue() {
+		if !conf.IsSet("adaptive_concurrency::min_concurrency") {
+			cfg.AdaptiveConcurrency.Get().MinConcurrency = 1
+		}
+		if !conf.IsSet("adaptive_concurrency::decrease_ratio") {
+			cfg.AdaptiveConcurrency.Get().DecreaseRatio = 0.5
+		}
+	}
 	return nil
 }
 
@@ -73,9 +104,20 @@ func (cfg *Config) Validate() error {
 		return errors.New("`queue_size` must be positive")
 	}
 
-	// Only support request sizer for persistent queue at this moment.
-	if cfg.StorageID != nil && cfg.WaitForResult {
-		return errors.New("`wait_for_result` is not supported with a persistent queue configured with `storage`")
+	if cfg.MaxStorageSize < 0 {
+		return errors.New("`max_storage_size` must not be negative")
+	}
+
+	if cfg.StorageID == nil && cfg.MaxStorageSize > 0 {
+		return errors.New("`max_storage_size` is only supported with a persistent queue configured with `storage`")
+	}
+
+	if cfg.AdaptiveConcurrency.HasValue() && cfg.AdaptiveConcurrency.Get().MinConcurrency > cfg.NumConsumers {
+		return errors.New("`min_concurrency` must be less than or equal to `num_consumers`")
+	}
+
+	if cfg.StorageID != nil && cfg.Priority.HasValue() {
+		return errors.New("`priority` is not supported with a persistent queue configured with `storage`")
 	}
 
 	if cfg.Batch.HasValue() && cfg.Batch.Get().Sizer == cfg.Sizer {
@@ -133,3 +175,98 @@ func (cfg *BatchConfig) Validate() error {
 
 	return nil
 }
+
+// AdaptiveConcurrencyConfig defines a configuration for the adaptive concurrency control of the export calls.
+// The limit is adjusted using an additive increase/multiplicative decrease (AIMD) algorithm.
+type AdaptiveConcurrencyConfig struct {
+	// MinConcurrency is the lower bound and the initial value of the concurrency limit.
+	MinConcurrency int `mapstructure:"min_concurrency"`
+
+	// LatencyThreshold is the duration after which a successful export call is considered to be a sign of
+	// an overloaded backend and decreases the limit. A zero value means that latency is not taken into account.
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// DecreaseRatio is the ratio by which the limit is multiplied on failed or slow export calls.
+	DecreaseRatio float64 `mapstructure:"decrease_ratio"`
+}
+
+func (cfg *AdaptiveConcurrencyConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.MinConcurrency <= 0 {
+		return errors.New("`min_concurrency` must be positive")
+	}
+
+	if cfg.LatencyThreshold < 0 {
+		return errors.New("`latency_threshold` must be non-negative")
+	}
+
+	if cfg.DecreaseRatio <= 0 || cfg.DecreaseRatio >= 1 {
+		return errors.New("`decrease_ratio` must be greater than 0 and less than 1")
+	}
+
+	return nil
+}
+
+// DeadLetterConfig defines a configuration for storing requests that failed to be exported.
+type DeadLetterConfig struct {
+	// StorageID is the component ID of the storage extension used to store the failed requests.
+	StorageID component.ID `mapstructure:"storage"`
+
+	// ReplayOnStart determines if the requests stored in the dead letter queue are sent again to the sending queue
+	// when the exporter starts. Requests that are successfully sent to the queue are removed from the dead letter queue.
+	ReplayOnStart bool `mapstructure:"replay_on_start"`
+}
+
+func (cfg *DeadLetterConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.StorageID == (component.ID{}) {
+		return errors.New("`storage` must be set")
+	}
+
+	return nil
+}
+
+// PriorityConfig defines a configuration for the priority lanes of the queue.
+type PriorityConfig struct {
+	// Classes lists the names of the priority classes, ordered from the highest to the lowest priority.
+	Classes []string `mapstructure:"classes"`
+
+	// Default is the class assigned to requests for which the class is unknown or cannot be determined.
+	// If not configured, the lowest priority class is used.
+	Default string `mapstructure:"default"`
+
+	// MetadataKey is the client.Metadata key used to determine the priority class of a request.
+	// If not configured, the priority class is determined by the exporter, if supported.
+	MetadataKey string `mapstructure:"metadata_key"`
+}
+
+func (cfg *PriorityConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if len(cfg.Classes) == 0 {
+		return errors.New("`classes` must not be empty")
+	}
+
+	for i, class := range cfg.Classes {
+		if class == "" {
+			return errors.New("`classes` must not contain empty names")
+		}
+		if slices.Contains(cfg.Classes[:i], class) {
+			return fmt.Errorf("duplicate entry in `classes`: %q", class)
+		}
+	}
+
+	if cfg.Default != "" && !slices.Contains(cfg.Classes, cfg.Default) {
+		return fmt.Errorf("`default` %q must be one of the `classes`", cfg.Default)
+	}
+
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
@@ -34,7 +36,21 @@ func TestConfig_Validate(t *testing.T) {
 	cfg = newTestConfig()
 	cfg.WaitForResult = true
 	cfg.StorageID = &storageID
-	require.EqualError(t, xconfmap.Validate(cfg), "`wait_for_result` is not supported with a persistent queue configured with `storage`")
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.MaxStorageSize = 1024
+	require.EqualError(t, xconfmap.Validate(cfg), "`max_storage_size` is only supported with a persistent queue configured with `storage`")
+
+	cfg = newTestConfig()
+	cfg.StorageID = &storageID
+	cfg.MaxStorageSize = -1
+	require.EqualError(t, xconfmap.Validate(cfg), "`max_storage_size` must not be negative")
+
+	cfg = newTestConfig()
+	cfg.StorageID = &storageID
+	cfg.MaxStorageSize = 1024
+	require.NoError(t, xconfmap.Validate(cfg))
 
 	cfg = newTestConfig()
 	cfg.QueueSize = cfg.Batch.Get().MinSize - 1
@@ -44,6 +60,27 @@ func TestConfig_Validate(t *testing.T) {
 	cfg.Batch.Get().Sizer = request.SizerType{}
 	require.EqualError(t, xconfmap.Validate(cfg), "batch: `batch` supports only `items` or `bytes` sizer")
 
+	cfg = newTestConfig()
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{MinConcurrency: cfg.NumConsumers + 1, DecreaseRatio: 0.5})
+	require.EqualError(t, xconfmap.Validate(cfg), "`min_concurrency` must be less than or equal to `num_consumers`")
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{})
+	require.EqualError(t, xconfmap.Validate(cfg), "dead_letter: `storage` must be set")
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.StorageID = &storageID
+	cfg.Priority = configoptional.Some(PriorityConfig{Classes: []string{"high", "low"}})
+	require.EqualError(t, xconfmap.Validate(cfg), "`priority` is not supported with a persistent queue configured with `storage`")
+
+	cfg = newTestConfig()
+	cfg.Priority = configoptional.Some(PriorityConfig{})
+	require.EqualError(t, xconfmap.Validate(cfg), "priority: `classes` must not be empty")
+
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeBytes
 	require.NoError(t, xconfmap.Validate(cfg))
@@ -53,6 +90,51 @@ func TestConfig_Validate(t *testing.T) {
 	assert.NoError(t, xconfmap.Validate(cfg))
 }
 
+func TestConfig_UnmarshalAdaptiveConcurrency(t *testing.T) {
+	cfg := newTestConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"adaptive_concurrency": map[string]any{},
+	}).Unmarshal(&cfg))
+	assert.Equal(t, AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5}, *cfg.AdaptiveConcurrency.Get())
+
+	cfg = newTestConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"adaptive_concurrency": map[string]any{"min_concurrency": 4, "decrease_ratio": 0.9, "latency_threshold": "1s"},
+	}).Unmarshal(&cfg))
+	assert.Equal(t, AdaptiveConcurrencyConfig{MinConcurrency: 4, DecreaseRatio: 0.9, LatencyThreshold: time.Second}, *cfg.AdaptiveConcurrency.Get())
+}
+
+func TestAdaptiveConcurrencyConfig_Validate(t *testing.T) {
+	cfg := &AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5, LatencyThreshold: time.Second}
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = &AdaptiveConcurrencyConfig{MinConcurrency: 0, DecreaseRatio: 0.5}
+	require.EqualError(t, xconfmap.Validate(cfg), "`min_concurrency` must be positive")
+
+	cfg = &AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 0.5, LatencyThreshold: -1}
+	require.EqualError(t, xconfmap.Validate(cfg), "`latency_threshold` must be non-negative")
+
+	cfg = &AdaptiveConcurrencyConfig{MinConcurrency: 1, DecreaseRatio: 1}
+	require.EqualError(t, xconfmap.Validate(cfg), "`decrease_ratio` must be greater than 0 and less than 1")
+}
+
+func TestPriorityConfig_Validate(t *testing.T) {
+	cfg := &PriorityConfig{Classes: []string{"high", "normal", "low"}, Default: "normal", MetadataKey: "x-priority"}
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = &PriorityConfig{}
+	require.EqualError(t, xconfmap.Validate(cfg), "`classes` must not be empty")
+
+	cfg = &PriorityConfig{Classes: []string{"high", ""}}
+	require.EqualError(t, xconfmap.Validate(cfg), "`classes` must not contain empty names")
+
+	cfg = &PriorityConfig{Classes: []string{"high", "low", "high"}}
+	require.EqualError(t, xconfmap.Validate(cfg), "duplicate entry in `classes`: \"high\"")
+
+	cfg = &PriorityConfig{Classes: []string{"high", "low"}, Default: "normal"}
+	require.EqualError(t, xconfmap.Validate(cfg), "`default` \"normal\" must be one of the `classes`")
+}
+
 func TestBatchConfig_Validate(t *testing.T) {
 	cfg := newTestBatchConfig()
 	require.NoError(t, xconfmap.Validate(cfg))
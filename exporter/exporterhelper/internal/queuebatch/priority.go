@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+)
+
+// Prioritizer is an interface that returns the name of the priority class of the given element.
+type Prioritizer[T any] interface {
+	GetClass(context.Context, T) string
+}
+
+type GetClassFunc[T any] func(context.Context, T) string
+
+func (f GetClassFunc[T]) GetClass(ctx context.Context, t T) string {
+	return f(ctx, t)
+}
+
+// newPriorityFunc returns a queue.PriorityFunc that maps every request to the index of its priority class.
+// The class is read from the client.Metadata if a metadata key is configured, otherwise from the given prioritizer.
+func newPriorityFunc(cfg PriorityConfig, prioritizer Prioritizer[request.Request]) queue.PriorityFunc[request.Request] {
+	classes := make(map[string]int, len(cfg.Classes))
+	for i, class := range cfg.Classes {
+		classes[class] = i
+	}
+	defaultClass := len(cfg.Classes) - 1
+	if i, ok := classes[cfg.Default]; ok {
+		defaultClass = i
+	}
+
+	getClass := func(context.Context, request.Request) string { return "" }
+	switch {
+	case cfg.MetadataKey != "":
+		getClass = func(ctx context.Context, _ request.Request) string {
+			if vals := client.FromContext(ctx).Metadata.Get(cfg.MetadataKey); len(vals) > 0 {
+				return vals[0]
+			}
+			return ""
+		}
+	case prioritizer != nil:
+		getClass = prioritizer.GetClass
+	}
+
+	return func(ctx context.Context, req request.Request) int {
+		if i, ok := classes[getClass(ctx, req)]; ok {
+			return i
+		}
+		return defaultClass
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+)
+
+func TestPriorityFuncMetadata(t *testing.T) {
+	pf := newPriorityFunc(PriorityConfig{
+		Classes:     []string{"high", "normal", "low"},
+		Default:     "normal",
+		MetadataKey: "x-priority",
+	}, nil)
+
+	newCtx := func(val string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"x-priority": {val}}),
+		})
+	}
+	req := &requesttest.FakeRequest{Items: 1}
+	assert.Equal(t, 0, pf(newCtx("high"), req))
+	assert.Equal(t, 2, pf(newCtx("low"), req))
+	assert.Equal(t, 1, pf(newCtx("unknown"), req))
+	assert.Equal(t, 1, pf(context.Background(), req))
+}
+
+func TestPriorityFuncPrioritizer(t *testing.T) {
+	pf := newPriorityFunc(PriorityConfig{
+		Classes: []string{"high", "low"},
+	}, GetClassFunc[request.Request](func(_ context.Context, req request.Request) string {
+		if req.ItemsCount() > 10 {
+			return "low"
+		}
+		return "high"
+	}))
+
+	assert.Equal(t, 0, pf(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 1, pf(context.Background(), &requesttest.FakeRequest{Items: 100}))
+
+	// Without metadata key and prioritizer all requests use the default class.
+	pf = newPriorityFunc(PriorityConfig{Classes: []string{"high", "low"}}, nil)
+	assert.Equal(t, 1, pf(context.Background(), &requesttest.FakeRequest{Items: 1}))
+}
@@ -6,14 +6,31 @@ package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhel
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
+// drainPollInterval is the interval at which Drain checks whether the queue is empty.
+const drainPollInterval = 20 * time.Millisecond
+
+// minHoldDelay is the minimum time a queue consumer waits after putting back a request rejected by an open circuit
+// breaker, so the consumers do not spin while a probe is in flight.
+const minHoldDelay = 100 * time.Millisecond
+
+// errInspectLimit stops the inspection of the dead letter queue once enough requests are collected.
+var errInspectLimit = errors.New("inspect limit reached")
+
 // Settings is a subset of the queuebatch.Settings that are needed when used within an Exporter.
 type Settings[T any] struct {
 	ReferenceCounter queue.ReferenceCounter[T]
@@ -21,6 +38,9 @@ type Settings[T any] struct {
 	ItemsSizer       request.Sizer[T]
 	BytesSizer       request.Sizer[T]
 	Partitioner      Partitioner[T]
+	// Prioritizer returns the name of the priority class of the given request, used when priority lanes are
+	// configured without a metadata key.
+	Prioritizer Prioritizer[T]
 }
 
 // AllSettings defines settings for creating a QueueBatch.
@@ -33,8 +53,14 @@ type AllSettings[T any] struct {
 }
 
 type QueueBatch struct {
-	queue   queue.Queue[request.Request]
-	batcher Batcher[request.Request]
+	queue         queue.Queue[request.Request]
+	batcher       Batcher[request.Request]
+	deadLetter    *queue.DeadLetterQueue[request.Request]
+	replayOnStart bool
+	persistent    bool
+	waitForResult bool
+	stopCh        chan struct{}
+	logger        *zap.Logger
 }
 
 func NewQueueBatch(
@@ -42,6 +68,28 @@ func NewQueueBatch(
 	cfg Config,
 	next sender.SendFunc[request.Request],
 ) (*QueueBatch, error) {
+	qb := &QueueBatch{
+		logger:        set.Telemetry.Logger,
+		persistent:    cfg.StorageID != nil,
+		waitForResult: cfg.WaitForResult,
+		stopCh:        make(chan struct{}),
+	}
+	next = qb.holdSendFunc(next)
+	if cfg.DeadLetter.HasValue() {
+		if set.Encoding == nil {
+			return nil, errors.New("`Settings.Encoding` must not be nil when dead letter queue is enabled")
+		}
+		dlCfg := cfg.DeadLetter.Get()
+		qb.deadLetter = queue.NewDeadLetterQueue(queue.Settings[request.Request]{
+			Encoding:  set.Encoding,
+			Signal:    set.Signal,
+			ID:        set.ID,
+			Telemetry: set.Telemetry,
+		}, dlCfg.StorageID)
+		qb.replayOnStart = dlCfg.ReplayOnStart
+		next = qb.deadLetterSendFunc(next)
+	}
+
 	b, err := NewBatcher(cfg.Batch, batcherSettings[request.Request]{
 		itemsSizer:  set.ItemsSizer,
 		bytesSizer:  set.BytesSizer,
@@ -60,7 +108,7 @@ func NewQueueBatch(
 		cfg.NumConsumers = 1
 	}
 
-	q, err := queue.NewQueue[request.Request](queue.Settings[request.Request]{
+	qSet := queue.Settings[request.Request]{
 		SizerType:        cfg.Sizer,
 		ItemsSizer:       set.ItemsSizer,
 		BytesSizer:       set.BytesSizer,
@@ -70,25 +118,95 @@ func NewQueueBatch(
 		BlockOnOverflow:  cfg.BlockOnOverflow,
 		Signal:           set.Signal,
 		StorageID:        cfg.StorageID,
+		MaxStorageSize:   cfg.MaxStorageSize,
 		ReferenceCounter: set.ReferenceCounter,
 		Encoding:         set.Encoding,
 		ID:               set.ID,
 		Telemetry:        set.Telemetry,
-	}, b.Consume)
+	}
+	if cfg.Priority.HasValue() {
+		qSet.PriorityFunc = newPriorityFunc(*cfg.Priority.Get(), set.Prioritizer)
+		qSet.NumPriorities = len(cfg.Priority.Get().Classes)
+	}
+	q, err := queue.NewQueue[request.Request](qSet, b.Consume)
 	if err != nil {
 		return nil, err
 	}
 
-	return &QueueBatch{queue: q, batcher: b}, nil
+	qb.queue = q
+	qb.batcher = b
+	return qb, nil
+}
+
+// holdSendFunc wraps the given SendFunc to put back in the queue the requests rejected by an open circuit breaker,
+// then waits until the circuit breaker allows sending again before the queue consumer reads the next request.
+// Callers waiting for the result get the error instead, and during shutdown the requests of a persistent queue are
+// kept in the storage to be exported after the next start.
+func (qs *QueueBatch) holdSendFunc(next sender.SendFunc[request.Request]) sender.SendFunc[request.Request] {
+	return func(ctx context.Context, req request.Request) error {
+		err := next(ctx, req)
+		delay, ok := experr.CircuitOpenDelay(err)
+		if !ok || qs.waitForResult {
+			return err
+		}
+		select {
+		case <-qs.stopCh:
+			if qs.persistent {
+				return experr.NewShutdownErr(err)
+			}
+			return err
+		default:
+		}
+		if offerErr := qs.queue.Offer(context.WithoutCancel(ctx), req); offerErr != nil {
+			qs.logger.Warn("Failed to put back the request rejected by the circuit breaker in the queue",
+				zap.Error(offerErr), zap.Int("items", req.ItemsCount()))
+			return err
+		}
+		select {
+		case <-qs.stopCh:
+		case <-time.After(max(delay, minHoldDelay)):
+		}
+		return nil
+	}
+}
+
+// deadLetterSendFunc wraps the given SendFunc to store the requests that permanently failed to be exported, or
+// exhausted the retries, in the dead letter queue. Other errors, like the ones of requests interrupted by shutdown, are
+// not stored, since the requests did not necessarily fail.
+func (qs *QueueBatch) deadLetterSendFunc(next sender.SendFunc[request.Request]) sender.SendFunc[request.Request] {
+	return func(ctx context.Context, req request.Request) error {
+		err := next(ctx, req)
+		if err == nil || (!consumererror.IsPermanent(err) && !experr.IsRetriesExhaustedErr(err)) {
+			return err
+		}
+		if dlErr := qs.deadLetter.Put(ctx, req); dlErr != nil {
+			qs.logger.Error("Failed to store failed request in the dead letter queue", zap.Error(dlErr))
+			return err
+		}
+		qs.logger.Warn("Stored failed request in the dead letter queue", zap.Error(err),
+			zap.Int("items", req.ItemsCount()))
+		return err
+	}
 }
 
 // Start is invoked during service startup.
 func (qs *QueueBatch) Start(ctx context.Context, host component.Host) error {
+	if qs.deadLetter != nil {
+		if err := qs.deadLetter.Start(ctx, host); err != nil {
+			return err
+		}
+	}
 	if err := qs.batcher.Start(ctx, host); err != nil {
-		return err
+		return errors.Join(err, qs.shutdownDeadLetter(ctx))
 	}
 	if err := qs.queue.Start(ctx, host); err != nil {
-		return errors.Join(err, qs.batcher.Shutdown(ctx))
+		return errors.Join(err, qs.batcher.Shutdown(ctx), qs.shutdownDeadLetter(ctx))
+	}
+	if qs.replayOnStart {
+		if err := qs.deadLetter.Replay(ctx, qs.queue.Offer); err != nil {
+			qs.logger.Warn("Failed to replay all requests from the dead letter queue", zap.Error(err),
+				zap.Int64("remaining", qs.deadLetter.Size()))
+		}
 	}
 	return nil
 }
@@ -97,7 +215,99 @@ func (qs *QueueBatch) Start(ctx context.Context, host component.Host) error {
 func (qs *QueueBatch) Shutdown(ctx context.Context) error {
 	// Stop the queue and batcher, this will drain the queue and will call the retry (which is stopped) that will only
 	// try once every request.
-	return errors.Join(qs.queue.Shutdown(ctx), qs.batcher.Shutdown(ctx))
+	// Last stop the dead letter queue, after all requests are drained.
+	close(qs.stopCh)
+	return errors.Join(qs.queue.Shutdown(ctx), qs.batcher.Shutdown(ctx), qs.shutdownDeadLetter(ctx))
+}
+
+// Drain waits until every request in the queue is exported, or the context is done.
+//
+// In spill mode, the requests of a persistent queue are kept in the storage to be exported after the next start, so
+// Drain returns immediately. The requests waiting in a memory queue are moved to the dead letter queue, to be replayed
+// on the next start, and Drain only waits for the requests being exported. Without a dead letter queue replayed on
+// start, see ValidateSpill, the memory queue is flushed.
+func (qs *QueueBatch) Drain(ctx context.Context, mode xexporter.DrainMode) error {
+	if mode == xexporter.DrainModeSpill {
+		switch {
+		case qs.persistent:
+			return nil
+		case !qs.replayOnStart:
+			qs.logger.Warn("Cannot spill the sending queue without a dead letter queue replayed on start, flushing it")
+		default:
+			if err := qs.queue.Spill(ctx, qs.deadLetter.Put); err != nil {
+				qs.logger.Warn("Failed to spill the sending queue, flushing it", zap.Error(err))
+			}
+		}
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for qs.queue.Size() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("sending queue not drained, %d remaining: %w", qs.queue.Size(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// ValidateSpill returns an error if the requests left in the queue cannot be kept to be exported after the next start,
+// which requires a persistent queue or a dead letter queue replayed on start.
+func (qs *QueueBatch) ValidateSpill() error {
+	if qs.persistent || qs.replayOnStart {
+		return nil
+	}
+	return errors.New("spilling the sending queue requires a persistent queue, or a dead letter queue with `replay_on_start`")
+}
+
+// InspectDeadLetters returns the first limit requests stored in the dead letter queue, or all of them if limit is not
+// positive.
+func (qs *QueueBatch) InspectDeadLetters(ctx context.Context, limit int) ([]xexporter.DeadLetter, error) {
+	if qs.deadLetter == nil {
+		return nil, xexporter.ErrNoDeadLetterQueue
+	}
+	var letters []xexporter.DeadLetter
+	err := qs.deadLetter.Inspect(ctx, func(_ context.Context, index uint64, req request.Request) error {
+		if limit > 0 && len(letters) == limit {
+			return errInspectLimit
+		}
+		letters = append(letters, xexporter.DeadLetter{Index: index, Items: req.ItemsCount(), Bytes: req.BytesSize()})
+		return nil
+	})
+	if errors.Is(err, errInspectLimit) {
+		err = nil
+	}
+	return letters, err
+}
+
+// ReplayDeadLetters puts the requests of the dead letter queue with the given indexes, or all of them if no index is
+// given, back in the queue. It stops at the first request the queue rejects.
+func (qs *QueueBatch) ReplayDeadLetters(ctx context.Context, indexes ...uint64) (int, error) {
+	if qs.deadLetter == nil {
+		return 0, xexporter.ErrNoDeadLetterQueue
+	}
+	replayed := 0
+	err := qs.deadLetter.Replay(ctx, func(reqCtx context.Context, req request.Request) error {
+		if err := qs.queue.Offer(reqCtx, req); err != nil {
+			return err
+		}
+		replayed++
+		return nil
+	}, indexes...)
+	return replayed, err
+}
+
+// DeadLetterQueue returns the dead letter queue if configured, otherwise nil.
+func (qs *QueueBatch) DeadLetterQueue() *queue.DeadLetterQueue[request.Request] {
+	return qs.deadLetter
+}
+
+func (qs *QueueBatch) shutdownDeadLetter(ctx context.Context) error {
+	if qs.deadLetter == nil {
+		return nil
+	}
+	return qs.deadLetter.Shutdown(ctx)
 }
 
 // Send implements the requestSender interface. It puts the request in the queue.
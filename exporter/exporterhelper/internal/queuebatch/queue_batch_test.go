@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
@@ -26,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sendertest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -233,6 +235,379 @@ func TestQueueBatchPersistentEnabled_NoDataLossOnShutdown(t *testing.T) {
 	require.NoError(t, qb.Shutdown(context.Background()))
 }
 
+func TestQueueBatchPersistentWaitForResult(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.WaitForResult = true
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+
+	mockReq := &requesttest.FakeRequest{Items: 5}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+
+	// The result of the export is returned to the caller.
+	exportErr := errors.New("transient error")
+	sink.SetExportErr(exportErr)
+	require.ErrorIs(t, qb.Send(context.Background(), mockReq), exportErr)
+	assert.Zero(t, sink.ItemsCount())
+
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	assert.Equal(t, 5, sink.ItemsCount())
+	assert.Zero(t, qb.queue.Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchCircuitOpen(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	sink := requesttest.NewSink()
+	sink.SetExportErr(experr.NewCircuitOpenErr(errors.New("circuit breaker is open"), 10*time.Millisecond))
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The request rejected by the open circuit breaker is put back in the queue and exported later.
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
+	assert.Eventually(t, func() bool {
+		return sink.ItemsCount() == 5 && sink.RequestsCount() == 1
+	}, 1*time.Second, 10*time.Millisecond)
+	assert.Zero(t, qb.queue.Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchCircuitOpenWaitForResult(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.WaitForResult = true
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The caller waiting for the result fails fast.
+	openErr := experr.NewCircuitOpenErr(errors.New("circuit breaker is open"), time.Minute)
+	sink.SetExportErr(openErr)
+	require.ErrorIs(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 5}), openErr)
+	assert.Zero(t, sink.RequestsCount())
+	assert.Zero(t, qb.queue.Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchCircuitOpenPersistentShutdown(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumConsumers = 1
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+
+	mockReq := &requesttest.FakeRequest{Items: 2}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	var attempts atomic.Int64
+	qb, err := NewQueueBatch(qSet, cfg, func(context.Context, request.Request) error {
+		attempts.Add(1)
+		return experr.NewCircuitOpenErr(errors.New("circuit breaker is open"), time.Minute)
+	})
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	assert.Eventually(t, func() bool {
+		return attempts.Load() == 1
+	}, 1*time.Second, 10*time.Millisecond)
+
+	// Shutdown interrupts the wait, and the request put back in the queue is kept in the storage.
+	require.NoError(t, qb.Shutdown(context.Background()))
+	sink := requesttest.NewSink()
+	qb, err = NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	assert.Eventually(t, func() bool {
+		return sink.ItemsCount() == 2 && sink.RequestsCount() == 1
+	}, 1*time.Second, 10*time.Millisecond)
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchDeadLetter(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID, ReplayOnStart: true})
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+
+	mockReq := &requesttest.FakeRequest{Items: 5}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+
+	sink.SetExportErr(consumererror.NewPermanent(errors.New("permanent error")))
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	assert.Eventually(t, func() bool {
+		return qb.DeadLetterQueue().Size() == 1
+	}, 1*time.Second, 10*time.Millisecond)
+	assert.Zero(t, sink.ItemsCount())
+	require.NoError(t, qb.Shutdown(context.Background()))
+
+	// Restart and confirm the dead lettered request is replayed.
+	qb, err = NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	assert.Eventually(t, func() bool {
+		return sink.ItemsCount() == 5 && sink.RequestsCount() == 1
+	}, 1*time.Second, 10*time.Millisecond)
+	assert.Zero(t, qb.DeadLetterQueue().Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchInspectReplayDeadLetters(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID})
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+
+	mockReq := &requesttest.FakeRequest{Items: 5}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+
+	for i := range 3 {
+		sink.SetExportErr(consumererror.NewPermanent(errors.New("permanent error")))
+		require.NoError(t, qb.Send(context.Background(), mockReq))
+		assert.Eventually(t, func() bool {
+			return qb.DeadLetterQueue().Size() == int64(i+1)
+		}, 1*time.Second, 10*time.Millisecond)
+	}
+
+	letters, err := qb.InspectDeadLetters(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []xexporter.DeadLetter{{Index: 0, Items: 5}, {Index: 1, Items: 5}}, letters)
+
+	replayed, err := qb.ReplayDeadLetters(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Eventually(t, func() bool {
+		return sink.ItemsCount() == 5
+	}, 1*time.Second, 10*time.Millisecond)
+
+	letters, err = qb.InspectDeadLetters(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []xexporter.DeadLetter{{Index: 2, Items: 5}, {Index: 3, Items: 5}}, letters)
+	replayed, err = qb.ReplayDeadLetters(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, 15, sink.ItemsCount())
+	assert.Zero(t, qb.DeadLetterQueue().Size())
+}
+
+func TestQueueBatchDeadLettersNotConfigured(t *testing.T) {
+	qb, err := NewQueueBatch(newFakeRequestSettings(), newTestConfig(), sendertest.NewNopSenderFunc[request.Request]())
+	require.NoError(t, err)
+	_, err = qb.InspectDeadLetters(context.Background(), 0)
+	require.ErrorIs(t, err, xexporter.ErrNoDeadLetterQueue)
+	_, err = qb.ReplayDeadLetters(context.Background())
+	require.ErrorIs(t, err, xexporter.ErrNoDeadLetterQueue)
+}
+
+func TestQueueBatchDeadLetterErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		err        error
+		deadLetter bool
+	}{
+		{name: "permanent", err: consumererror.NewPermanent(errors.New("bad data")), deadLetter: true},
+		{name: "retries_exhausted", err: experr.NewRetriesExhaustedErr(errors.New("unavailable")), deadLetter: true},
+		{name: "transient", err: errors.New("unavailable")},
+		{name: "shutdown", err: experr.NewShutdownErr(errors.New("could not export data"))},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Batch = configoptional.Optional[BatchConfig]{}
+			storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+			cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID})
+			host := hosttest.NewHost(map[component.ID]component.Component{
+				storageID: storagetest.NewMockStorageExtension(nil),
+			})
+
+			qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, func(context.Context, request.Request) error {
+				return tt.err
+			})
+			require.NoError(t, err)
+			require.NoError(t, qb.Start(context.Background(), host))
+			require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+			require.NoError(t, qb.Shutdown(context.Background()))
+			if tt.deadLetter {
+				assert.EqualValues(t, 1, qb.DeadLetterQueue().Size())
+			} else {
+				assert.Zero(t, qb.DeadLetterQueue().Size())
+			}
+		})
+	}
+}
+
+func TestQueueBatchDeadLetterStorageError(t *testing.T) {
+	storageError := errors.New("could not get storage client")
+	cfg := newTestConfig()
+	storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID})
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sendertest.NewNopSenderFunc[request.Request]())
+	require.NoError(t, err)
+
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(storageError),
+	})
+	require.ErrorIs(t, qb.Start(context.Background(), host), storageError)
+}
+
+func TestQueueBatchDeadLetterNoEncoding(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: component.MustNewID("file_storage")})
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = nil
+	_, err := NewQueueBatch(qSet, cfg, sendertest.NewNopSenderFunc[request.Request]())
+	require.EqualError(t, err, "`Settings.Encoding` must not be nil when dead letter queue is enabled")
+}
+
+func TestQueueBatchDrain(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	cfg.NumConsumers = 1
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	for range 3 {
+		require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2, Delay: 10 * time.Millisecond}))
+	}
+	require.NoError(t, qb.Drain(context.Background(), xexporter.DrainModeFlush))
+	assert.Equal(t, 3, sink.RequestsCount())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchDrainTimeout(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2, Delay: 200 * time.Millisecond}))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = qb.Drain(ctx, xexporter.DrainModeFlush)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.EqualError(t, err, "sending queue not drained, 2 remaining: context deadline exceeded")
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, 2, sink.ItemsCount())
+}
+
+func TestQueueBatchDrainSpill(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	cfg.NumConsumers = 1
+	storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID, ReplayOnStart: true})
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+
+	mockReq := &requesttest.FakeRequest{Items: 5}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	require.NoError(t, qb.ValidateSpill())
+
+	// The first request is being exported while the others wait in the queue.
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 1, Delay: 100 * time.Millisecond}))
+	// To make the request reached the consumer before the others are sent.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+
+	require.NoError(t, qb.Drain(context.Background(), xexporter.DrainModeSpill))
+	assert.Equal(t, 1, sink.ItemsCount())
+	assert.EqualValues(t, 2, qb.DeadLetterQueue().Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+
+	// The spilled requests are replayed after the restart.
+	qb, err = NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	assert.Eventually(t, func() bool { return sink.ItemsCount() == 11 }, 1*time.Second, 10*time.Millisecond)
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchDrainSpillWithoutReplay(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID})
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.EqualError(t, qb.ValidateSpill(),
+		"spilling the sending queue requires a persistent queue, or a dead letter queue with `replay_on_start`")
+	require.NoError(t, qb.Start(context.Background(), host))
+
+	// The dead letter queue is not replayed on start, the memory queue is flushed instead.
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2, Delay: 50 * time.Millisecond}))
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	require.NoError(t, qb.Drain(context.Background(), xexporter.DrainModeSpill))
+	assert.Equal(t, 5, sink.ItemsCount())
+	assert.Zero(t, qb.DeadLetterQueue().Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchDrainSpillPersistent(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, requesttest.NewSink().Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.ValidateSpill())
+	require.NoError(t, qb.Start(context.Background(), host))
+
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2, Delay: 50 * time.Millisecond}))
+	// The requests of the persistent queue are already stored, Drain does not wait for them.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, qb.Drain(ctx, xexporter.DrainModeSpill))
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
 func TestQueueBatchNoStartShutdown(t *testing.T) {
 	qs, err := NewQueueBatch(newFakeRequestSettings(), newTestConfig(), sendertest.NewNopSenderFunc[request.Request]())
 	require.NoError(t, err)
@@ -23,28 +23,6 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 )
 
-// TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
-type throttleRetry struct {
-	err   error
-	delay time.Duration
-}
-
-func (t throttleRetry) Error() string {
-	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
-}
-
-func (t throttleRetry) Unwrap() error {
-	return t.err
-}
-
-// NewThrottleRetry creates a new throttle retry error.
-func NewThrottleRetry(err error, delay time.Duration) error {
-	return throttleRetry{
-		err:   err,
-		delay: delay,
-	}
-}
-
 type retrySender struct {
 	component.StartFunc
 	cfg    configretry.BackOffConfig
@@ -83,16 +61,29 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 	if rs.cfg.MaxElapsedTime > 0 {
 		maxElapsedTime = time.Now().Add(rs.cfg.MaxElapsedTime)
 	}
+	attemptCtx := ctx
+	var lastErr error
 	for {
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(attribute.Int64("retry_num", retryNum)))
 
-		err := rs.next.Send(ctx, req)
+		err := rs.next.Send(attemptCtx, req)
 		if err == nil {
 			return nil
 		}
 
+		// The retry budget is exhausted, report the error of the last attempt.
+		if errors.Is(err, errRetryBudgetExhausted) {
+			return experr.NewRetriesExhaustedErr(errors.Join(err, lastErr))
+		}
+		lastErr = err
+
+		// The circuit breaker is open, fail fast and let the queue hold the request.
+		if _, ok := experr.CircuitOpenDelay(err); ok {
+			return err
+		}
+
 		// Immediately drop data on permanent errors.
 		if consumererror.IsPermanent(err) {
 			return fmt.Errorf("not retryable error: %w", err)
@@ -104,18 +95,21 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 
 		backoffDelay := expBackoff.NextBackOff()
 		if backoffDelay == backoff.Stop {
-			return fmt.Errorf("no more retries left: %w", err)
+			return experr.NewRetriesExhaustedErr(err)
 		}
 
-		throttleErr := throttleRetry{}
-		if errors.As(err, &throttleErr) {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+		// Honor the delay requested by the destination, up to the configured maximum.
+		if throttleDelay, ok := consumererror.RetryAfter(err); ok {
+			if rs.cfg.MaxThrottleDelay > 0 {
+				throttleDelay = min(throttleDelay, rs.cfg.MaxThrottleDelay)
+			}
+			backoffDelay = max(backoffDelay, throttleDelay)
 		}
 
 		nextRetryTime := time.Now().Add(backoffDelay)
 		if !maxElapsedTime.IsZero() && maxElapsedTime.Before(nextRetryTime) {
 			// The delay is longer than the maxElapsedTime.
-			return fmt.Errorf("no more retries left: %w", err)
+			return experr.NewRetriesExhaustedErr(err)
 		}
 
 		if deadline, has := ctx.Deadline(); has && deadline.Before(nextRetryTime) {
@@ -136,6 +130,7 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 			zap.String("interval", backoffDelayStr),
 		)
 		retryNum++
+		attemptCtx = contextWithRetryAttempt(ctx)
 
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
 		select {
@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
@@ -72,7 +73,9 @@ func TestRetrySenderMaxElapsedTime(t *testing.T) {
 	expErr := errors.New("transient error")
 	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(func(context.Context, request.Request) error { return expErr }))
 	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
-	require.ErrorIs(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 2}), expErr)
+	err := rs.Send(context.Background(), &requesttest.FakeRequest{Items: 2})
+	require.ErrorIs(t, err, expErr)
+	assert.True(t, experr.IsRetriesExhaustedErr(err))
 	require.NoError(t, rs.Shutdown(context.Background()))
 }
 
@@ -82,7 +85,7 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	sink := requesttest.NewSink()
 	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(sink.Export))
 	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
-	retry := fmt.Errorf("wrappe error: %w", NewThrottleRetry(errors.New("throttle error"), 100*time.Millisecond))
+	retry := fmt.Errorf("wrappe error: %w", consumererror.NewRetryAfter(errors.New("throttle error"), 100*time.Millisecond))
 	start := time.Now()
 	sink.SetExportErr(retry)
 	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
@@ -93,6 +96,40 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	require.NoError(t, rs.Shutdown(context.Background()))
 }
 
+func TestRetrySenderCircuitOpen(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	sink := requesttest.NewSink()
+	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(sink.Export))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	sink.SetExportErr(experr.NewCircuitOpenErr(errors.New("circuit breaker is open"), 10*time.Minute))
+	start := time.Now()
+	err := rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5})
+	_, ok := experr.CircuitOpenDelay(err)
+	require.True(t, ok)
+	// The request is not retried, nor delayed.
+	assert.Greater(t, 5*time.Second, time.Since(start))
+	assert.Equal(t, 0, sink.RequestsCount())
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestRetrySenderMaxThrottleDelay(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	rCfg.MaxThrottleDelay = 50 * time.Millisecond
+	sink := requesttest.NewSink()
+	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(sink.Export))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	start := time.Now()
+	sink.SetExportErr(consumererror.NewRetryAfter(errors.New("throttle error"), 10*time.Minute))
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
+	// The requested delay is 10m, but it is reduced to the configured maximum.
+	assert.Less(t, 50*time.Millisecond, time.Since(start))
+	assert.Greater(t, 5*time.Second, time.Since(start))
+	assert.Equal(t, 5, sink.ItemsCount())
+	assert.Equal(t, 1, sink.RequestsCount())
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
 func TestRetrySenderWithContextTimeout(t *testing.T) {
 	const testTimeout = 10 * time.Second
 	rCfg := configretry.NewDefaultBackOffConfig()
@@ -4,6 +4,7 @@
 package storagetest // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"sync"
@@ -17,6 +18,8 @@ import (
 type mockStorageExtension struct {
 	component.StartFunc
 	component.ShutdownFunc
+	// mu serializes the operations of all the clients, so they are applied atomically.
+	mu             sync.Mutex
 	st             sync.Map
 	getClientError error
 	executionDelay time.Duration
@@ -26,7 +29,7 @@ func (m *mockStorageExtension) GetClient(context.Context, component.Kind, compon
 	if m.getClientError != nil {
 		return nil, m.getClientError
 	}
-	return &MockStorageClient{st: &m.st, closed: &atomic.Bool{}, executionDelay: m.executionDelay}, nil
+	return &MockStorageClient{mu: &m.mu, st: &m.st, closed: &atomic.Bool{}, executionDelay: m.executionDelay}, nil
 }
 
 func NewMockStorageExtension(getClientError error) storage.Extension {
@@ -40,7 +43,10 @@ func NewMockStorageExtensionWithDelay(getClientError error, executionDelay time.
 	}
 }
 
+var _ storage.TransactionalClient = (*MockStorageClient)(nil)
+
 type MockStorageClient struct {
+	mu             *sync.Mutex
 	st             *sync.Map
 	closed         *atomic.Bool
 	executionDelay time.Duration // simulate real storage client delay
@@ -66,12 +72,50 @@ func (m *MockStorageClient) Close(context.Context) error {
 }
 
 func (m *MockStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	m.before()
+	defer m.mu.Unlock()
+	return m.apply(ops)
+}
+
+func (m *MockStorageClient) AtomicBatch(_ context.Context, ops ...*storage.Operation) error {
+	m.before()
+	defer m.mu.Unlock()
+	for _, op := range ops {
+		if op.Type != storage.Get && op.Type != storage.Set && op.Type != storage.Delete {
+			return errors.New("wrong operation type")
+		}
+	}
+	return m.apply(ops)
+}
+
+func (m *MockStorageClient) CompareAndSet(_ context.Context, key string, oldValue, newValue []byte) (bool, error) {
+	m.before()
+	defer m.mu.Unlock()
+	val, found := m.st.Load(key)
+	if found != (oldValue != nil) || (found && !bytes.Equal(val.([]byte), oldValue)) {
+		return false, nil
+	}
+	if newValue == nil {
+		m.st.Delete(key)
+	} else {
+		m.st.Store(key, newValue)
+	}
+	return true, nil
+}
+
+// before checks the client is not closed, simulates the execution delay and locks the storage.
+func (m *MockStorageClient) before() {
 	if m.IsClosed() {
 		panic("client already closed")
 	}
 	if m.executionDelay != 0 {
 		time.Sleep(m.executionDelay)
 	}
+	m.mu.Lock()
+}
+
+// apply applies the operations in order. Callers MUST hold the mutex.
+func (m *MockStorageClient) apply(ops []*storage.Operation) error {
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
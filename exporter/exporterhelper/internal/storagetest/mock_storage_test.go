@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	xstoragetest "go.opentelemetry.io/collector/extension/xextension/storage/storagetest"
+)
+
+func TestMockStorageClientConformance(t *testing.T) {
+	xstoragetest.CheckTransactionalClient(t, func(t *testing.T) storage.TransactionalClient {
+		client, err := NewMockStorageExtension(nil).GetClient(context.Background(), component.KindExporter, component.ID{}, "")
+		require.NoError(t, err)
+		return client.(storage.TransactionalClient)
+	})
+}
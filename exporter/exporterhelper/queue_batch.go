@@ -50,6 +50,14 @@ func WithQueueBatch(cfg QueueBatchConfig, set QueueBatchSettings) Option {
 	return internal.WithQueueBatch(cfg, set)
 }
 
+// WithQueuePrioritizer sets the function returning the name of the priority class of a request, used by the sending
+// queue when priority classes are configured without a `metadata_key`. Unknown class names are mapped to the default
+// class.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithQueuePrioritizer(getClass func(ctx context.Context, req Request) string) Option {
+	return internal.WithQueuePrioritizer(queuebatch.GetClassFunc[Request](getClass))
+}
+
 // NewDefaultQueueConfig returns the default config for QueueBatchConfig.
 // By default, the queue stores 1000 requests of telemetry and is non-blocking when full.
 var NewDefaultQueueConfig = internal.NewDefaultQueueConfig
@@ -6,10 +6,12 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"time"
 
-	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 // NewThrottleRetry creates a new throttle retry error.
+//
+// Deprecated: [v0.136.0] Use consumererror.NewRetryAfter.
 func NewThrottleRetry(err error, delay time.Duration) error {
-	return internal.NewThrottleRetry(err, delay)
+	return consumererror.NewRetryAfter(err, delay)
 }
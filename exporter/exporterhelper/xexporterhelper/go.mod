@@ -47,6 +47,7 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/client v1.41.0 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.135.0 // indirect
 	go.opentelemetry.io/collector/config/configoptional v0.135.0 // indirect
 	go.opentelemetry.io/collector/config/configretry v1.41.0 // indirect
 	go.opentelemetry.io/collector/confmap v1.41.0 // indirect
@@ -118,6 +119,8 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../../../extens
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../../../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/featuregate => ../../../featuregate
 
 replace go.opentelemetry.io/collector/internal/telemetry => ../../../internal/telemetry
@@ -133,3 +136,5 @@ replace go.opentelemetry.io/collector/confmap => ../../../confmap
 replace go.opentelemetry.io/collector/confmap/xconfmap => ../../../confmap/xconfmap
 
 replace go.opentelemetry.io/collector/exporter/exporterhelper => ../
+
+replace go.opentelemetry.io/collector/component/componentstatus => ../../../component/componentstatus
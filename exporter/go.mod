@@ -36,11 +36,13 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/client v1.41.0 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.135.0 // indirect
 	go.opentelemetry.io/collector/config/configoptional v0.135.0 // indirect
 	go.opentelemetry.io/collector/confmap v1.41.0 // indirect
 	go.opentelemetry.io/collector/confmap/xconfmap v0.135.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumererror v0.135.0 // indirect
 	go.opentelemetry.io/collector/consumer/xconsumer v0.135.0 // indirect
+	go.opentelemetry.io/collector/exporter/xexporter v0.135.0 // indirect
 	go.opentelemetry.io/collector/extension v1.41.0 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.135.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.41.0 // indirect
@@ -104,6 +106,8 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../extension/ex
 
 replace go.opentelemetry.io/collector/extension/xextension => ../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/featuregate => ../featuregate
 
 replace go.opentelemetry.io/collector/internal/telemetry => ../internal/telemetry
@@ -119,3 +123,5 @@ replace go.opentelemetry.io/collector/config/configoptional => ../config/configo
 replace go.opentelemetry.io/collector/confmap/xconfmap => ../confmap/xconfmap
 
 replace go.opentelemetry.io/collector/exporter/exporterhelper => ./exporterhelper
+
+replace go.opentelemetry.io/collector/component/componentstatus => ../component/componentstatus
@@ -19,10 +19,11 @@ import (
 
 // Config defines configuration for OTLP exporter.
 type Config struct {
-	TimeoutConfig exporterhelper.TimeoutConfig    `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	QueueConfig   exporterhelper.QueueBatchConfig `mapstructure:"sending_queue"`
-	RetryConfig   configretry.BackOffConfig       `mapstructure:"retry_on_failure"`
-	ClientConfig  configgrpc.ClientConfig         `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	TimeoutConfig        exporterhelper.TimeoutConfig        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueConfig          exporterhelper.QueueBatchConfig     `mapstructure:"sending_queue"`
+	RetryConfig          configretry.BackOffConfig           `mapstructure:"retry_on_failure"`
+	CircuitBreakerConfig exporterhelper.CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	ClientConfig         configgrpc.ClientConfig             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
 	// prevent unkeyed literal initialization
 	_ struct{}
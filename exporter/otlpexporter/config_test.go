@@ -51,6 +51,14 @@ func TestUnmarshalConfig(t *testing.T) {
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
 			},
+			CircuitBreakerConfig: exporterhelper.CircuitBreakerConfig{
+				Enabled:          true,
+				FailureThreshold: 0.6,
+				MinimumRequests:  20,
+				Interval:         30 * time.Second,
+				OpenDuration:     10 * time.Second,
+				RetryBudget:      0.1,
+			},
 			QueueConfig: exporterhelper.QueueBatchConfig{
 				Enabled:      true,
 				Sizer:        exporterhelper.RequestSizerTypeItems,
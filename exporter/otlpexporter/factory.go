@@ -42,10 +42,11 @@ func createDefaultConfig() component.Config {
 	clientCfg.BalancerName = ""
 
 	return &Config{
-		TimeoutConfig: exporterhelper.NewDefaultTimeoutConfig(),
-		RetryConfig:   configretry.NewDefaultBackOffConfig(),
-		QueueConfig:   exporterhelper.NewDefaultQueueConfig(),
-		ClientConfig:  clientCfg,
+		TimeoutConfig:        exporterhelper.NewDefaultTimeoutConfig(),
+		RetryConfig:          configretry.NewDefaultBackOffConfig(),
+		CircuitBreakerConfig: exporterhelper.NewDefaultCircuitBreakerConfig(),
+		QueueConfig:          exporterhelper.NewDefaultQueueConfig(),
+		ClientConfig:         clientCfg,
 	}
 }
 
@@ -61,6 +62,7 @@ func createTraces(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -79,6 +81,7 @@ func createMetrics(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -97,6 +100,7 @@ func createLogs(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -115,6 +119,7 @@ func createProfilesExporter(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
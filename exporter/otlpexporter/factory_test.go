@@ -33,6 +33,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	ocfg, ok := factory.CreateDefaultConfig().(*Config)
 	assert.True(t, ok)
 	assert.Equal(t, configretry.NewDefaultBackOffConfig(), ocfg.RetryConfig)
+	assert.Equal(t, exporterhelper.NewDefaultCircuitBreakerConfig(), ocfg.CircuitBreakerConfig)
 	assert.Equal(t, exporterhelper.NewDefaultQueueConfig(), ocfg.QueueConfig)
 	assert.Equal(t, exporterhelper.NewDefaultTimeoutConfig(), ocfg.TimeoutConfig)
 	assert.Equal(t, configcompression.TypeGzip, ocfg.ClientConfig.Compression)
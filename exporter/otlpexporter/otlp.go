@@ -19,7 +19,6 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/statusutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -178,7 +177,7 @@ func processError(err error) error {
 	throttleDuration := retryInfo.GetRetryDelay().AsDuration()
 	if throttleDuration != 0 {
 		// We are throttled. Wait before retrying as requested by the server.
-		return exporterhelper.NewThrottleRetry(err, throttleDuration)
+		return consumererror.NewRetryAfter(err, throttleDuration)
 	}
 
 	// Need to retry.
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/xexporter"
@@ -1040,3 +1041,16 @@ func TestSendProfilesWhenEndpointHasHttpScheme(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessErrorRetryAfter(t *testing.T) {
+	st := status.New(codes.Unavailable, "server is overloaded")
+	delay, ok := consumererror.RetryAfter(processError(st.Err()))
+	assert.False(t, ok)
+	assert.Zero(t, delay)
+
+	st, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(10 * time.Minute)})
+	require.NoError(t, err)
+	delay, ok = consumererror.RetryAfter(processError(st.Err()))
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Minute, delay)
+}
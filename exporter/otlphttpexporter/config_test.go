@@ -52,6 +52,7 @@ func TestUnmarshalConfig(t *testing.T) {
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
 			},
+			CircuitBreakerConfig: exporterhelper.NewDefaultCircuitBreakerConfig(),
 			QueueConfig: exporterhelper.QueueBatchConfig{
 				Enabled:      true,
 				Sizer:        exporterhelper.RequestSizerTypeRequests,
@@ -43,10 +43,11 @@ func createDefaultConfig() component.Config {
 	clientConfig.WriteBufferSize = 512 * 1024
 
 	return &Config{
-		RetryConfig:  configretry.NewDefaultBackOffConfig(),
-		QueueConfig:  exporterhelper.NewDefaultQueueConfig(),
-		Encoding:     EncodingProto,
-		ClientConfig: clientConfig,
+		RetryConfig:          configretry.NewDefaultBackOffConfig(),
+		CircuitBreakerConfig: exporterhelper.NewDefaultCircuitBreakerConfig(),
+		QueueConfig:          exporterhelper.NewDefaultQueueConfig(),
+		Encoding:             EncodingProto,
+		ClientConfig:         clientConfig,
 	}
 }
 
@@ -96,6 +97,7 @@ func createTraces(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
 }
 
@@ -122,6 +124,7 @@ func createMetrics(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
 }
 
@@ -147,6 +150,7 @@ func createLogs(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
 }
 
@@ -173,5 +177,6 @@ func createProfiles(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
 }
@@ -27,7 +27,6 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer/consumererror"
-	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -173,6 +172,17 @@ func TestErrorResponses(t *testing.T) {
 				require.ErrorContains(t, err, "), error: "+status.New(codes.ResourceExhausted, errMsgPrefix(srv)+"429, Message=Quota exceeded, Details=[]").String())
 			},
 		},
+		{
+			name:           "503-Retry-After-Seconds",
+			responseStatus: http.StatusServiceUnavailable,
+			responseBody:   status.New(codes.Unavailable, "Server overloaded"),
+			headers:        map[string]string{"Retry-After": "30"},
+			checkErr: func(t *testing.T, err error, _ *httptest.Server) {
+				delay, ok := consumererror.RetryAfter(err)
+				require.True(t, ok)
+				assert.Equal(t, 30*time.Second, delay)
+			},
+		},
 		{
 			name:           "429-Retry-After-Malformed",
 			responseStatus: http.StatusTooManyRequests,
@@ -213,7 +223,7 @@ func TestErrorResponses(t *testing.T) {
 			responseBody:   status.New(codes.InvalidArgument, "Server overloaded"),
 			headers:        map[string]string{"Retry-After": "30"},
 			checkErr: func(t *testing.T, err error, srv *httptest.Server) {
-				require.EqualError(t, err, exporterhelper.NewThrottleRetry(
+				require.EqualError(t, err, consumererror.NewRetryAfter(
 					status.New(codes.Unavailable, errMsgPrefix(srv)+"503, Message=Server overloaded, Details=[]").Err(),
 					time.Duration(30)*time.Second).Error())
 			},
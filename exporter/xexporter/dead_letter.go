@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xexporter // import "go.opentelemetry.io/collector/exporter/xexporter"
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoDeadLetterQueue is returned by the [DeadLetterQueue] methods of an exporter configured without a dead letter
+// queue.
+var ErrNoDeadLetterQueue = errors.New("no dead letter queue configured")
+
+// DeadLetter describes a request stored in the dead letter queue of an exporter.
+type DeadLetter struct {
+	// Index identifies the request in the dead letter queue.
+	Index uint64
+	// Items is the number of items (spans, data points, log records or profiles) of the request.
+	Items int
+	// Bytes is the size of the request in bytes.
+	Bytes int
+}
+
+// DeadLetterQueue is an optional interface that exporters storing the requests they failed to export can implement,
+// so the stored requests can be inspected and sent again.
+type DeadLetterQueue interface {
+	// InspectDeadLetters returns the first limit requests stored in the dead letter queue, or all of them if limit is
+	// not positive, in the order they were stored.
+	InspectDeadLetters(ctx context.Context, limit int) ([]DeadLetter, error)
+	// ReplayDeadLetters puts the requests with the given indexes back in the sending queue, or all the requests if no
+	// index is given, and removes them from the dead letter queue. It returns the number of replayed requests.
+	// The requests stored before the last replayed one which are kept get new indexes.
+	ReplayDeadLetters(ctx context.Context, indexes ...uint64) (int, error)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xexporter // import "go.opentelemetry.io/collector/exporter/xexporter"
+
+import (
+	"context"
+	"fmt"
+)
+
+// DrainMode defines what an exporter does with the data left in its memory queue when it is drained.
+type DrainMode string
+
+const (
+	// DrainModeFlush exports the data left in the memory queue.
+	DrainModeFlush DrainMode = "flush"
+	// DrainModeSpill writes the data left in the memory queue to persistent storage, so it can be exported
+	// after the next start. Only the requests being exported are waited for.
+	DrainModeSpill DrainMode = "spill"
+)
+
+// UnmarshalText implements [encoding.TextUnmarshaler] interface.
+func (m *DrainMode) UnmarshalText(text []byte) error {
+	switch mode := DrainMode(text); mode {
+	case DrainModeFlush, DrainModeSpill:
+		*m = mode
+		return nil
+	default:
+		return fmt.Errorf("invalid drain mode %q, must be %q or %q", text, DrainModeFlush, DrainModeSpill)
+	}
+}
+
+// SpillValidator is an optional interface that exporters implementing [Drainer] can implement to report whether the
+// data left in their memory queue can be kept, to be exported after the next start, when drained with
+// [DrainModeSpill]. The service does not start with this mode if one of its exporters returns an error.
+type SpillValidator interface {
+	// ValidateSpill returns an error if the exporter cannot keep the data left in its memory queue.
+	ValidateSpill() error
+}
+
+// Drainer is an optional interface that exporters can implement to handle the data they hold before they are
+// shut down. The service calls Drain on the exporters, after their upstream components are shut down and before
+// shutting them down.
+type Drainer interface {
+	// Drain blocks until the data held by the exporter is exported, or handled according to the given mode,
+	// or until the context is done, in which case it returns the error of the context.
+	Drain(ctx context.Context, mode DrainMode) error
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainModeUnmarshalText(t *testing.T) {
+	var mode DrainMode
+	require.NoError(t, mode.UnmarshalText([]byte("flush")))
+	assert.Equal(t, DrainModeFlush, mode)
+	require.NoError(t, mode.UnmarshalText([]byte("spill")))
+	assert.Equal(t, DrainModeSpill, mode)
+	require.EqualError(t, mode.UnmarshalText([]byte("drop")), `invalid drain mode "drop", must be "flush" or "spill"`)
+	assert.Equal(t, DrainModeSpill, mode)
+}
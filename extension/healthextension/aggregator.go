@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// extensionsGroup is the name of the group holding the status of the extensions, which are not part of any pipeline.
+const extensionsGroup = "extensions"
+
+// severity orders the statuses from the least to the most severe, the status of a group is the status of its
+// most severe component.
+var severity = map[componentstatus.Status]int{
+	componentstatus.StatusNone:             0,
+	componentstatus.StatusOK:               1,
+	componentstatus.StatusStopped:          2,
+	componentstatus.StatusStarting:         3,
+	componentstatus.StatusStopping:         4,
+	componentstatus.StatusRecoverableError: 5,
+	componentstatus.StatusPermanentError:   6,
+	componentstatus.StatusFatalError:       7,
+}
+
+// moreSevere returns whether ev is more severe than other. Between two recoverable errors, the one reported
+// first is the most severe, since it is the closest to exceeding the recovery duration.
+func moreSevere(ev, other *componentstatus.Event) bool {
+	if other == nil {
+		return true
+	}
+	if ev.Status() != other.Status() {
+		return severity[ev.Status()] > severity[other.Status()]
+	}
+	return ev.Timestamp().Before(other.Timestamp())
+}
+
+// failed returns whether the event makes the probe fail at the given time.
+func (cfg *ProbeConfig) failed(ev *componentstatus.Event, now time.Time) bool {
+	switch ev.Status() {
+	case componentstatus.StatusFatalError:
+		return true
+	case componentstatus.StatusPermanentError:
+		return cfg.IncludePermanentErrors
+	case componentstatus.StatusRecoverableError:
+		return now.Sub(ev.Timestamp()) >= cfg.RecoveryDuration
+	default:
+		return false
+	}
+}
+
+// aggregator keeps the latest status of every component instance, and aggregates them per pipeline.
+type aggregator struct {
+	mu     sync.RWMutex
+	events map[*componentstatus.InstanceID]*componentstatus.Event
+	ready  bool
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{events: make(map[*componentstatus.InstanceID]*componentstatus.Event)}
+}
+
+func (a *aggregator) record(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Stopped components are forgotten, so that the components retired by a reload do not accumulate.
+	if event.Status() == componentstatus.StatusStopped {
+		delete(a.events, source)
+		return
+	}
+	a.events[source] = event
+}
+
+func (a *aggregator) setReady(ready bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.ready = ready
+}
+
+// componentStatus is the status of a component, or the aggregated status of a group of components.
+type componentStatus struct {
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Since  time.Time `json:"since,omitzero"`
+
+	event *componentstatus.Event
+}
+
+func newComponentStatus(ev *componentstatus.Event) componentStatus {
+	st := componentStatus{
+		Status: ev.Status().String(),
+		Since:  ev.Timestamp(),
+		event:  ev,
+	}
+	if ev.Err() != nil {
+		st.Error = ev.Err().Error()
+	}
+	return st
+}
+
+// groupStatus is the aggregated status of a pipeline, or of the extensions.
+type groupStatus struct {
+	componentStatus
+	Components map[string]componentStatus `json:"components"`
+}
+
+// snapshot is the status of the collector at a given time.
+type snapshot struct {
+	ready  bool
+	groups map[string]*groupStatus
+}
+
+func (a *aggregator) snapshot() *snapshot {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	s := &snapshot{ready: a.ready, groups: make(map[string]*groupStatus)}
+	for source, ev := range a.events {
+		name := componentName(source)
+		add := func(group string) {
+			gs, ok := s.groups[group]
+			if !ok {
+				gs = &groupStatus{Components: make(map[string]componentStatus)}
+				s.groups[group] = gs
+			}
+			gs.Components[name] = newComponentStatus(ev)
+			if moreSevere(ev, gs.event) {
+				gs.componentStatus = newComponentStatus(ev)
+			}
+		}
+		if source.Kind() == component.KindExtension {
+			add(extensionsGroup)
+			continue
+		}
+		source.AllPipelineIDs(func(id pipeline.ID) bool {
+			add(id.String())
+			return true
+		})
+	}
+	return s
+}
+
+// check returns whether the probe passes for the given group, or for all of them if group is empty, along with
+// the most severe status of the checked components. It returns false for found when the group is unknown.
+func (s *snapshot) check(cfg *ProbeConfig, needReady bool, group string, now time.Time) (healthy bool, st componentStatus, found bool) {
+	groups := s.groups
+	if group != "" {
+		gs, ok := s.groups[group]
+		if !ok {
+			return false, componentStatus{}, false
+		}
+		groups = map[string]*groupStatus{group: gs}
+	}
+	healthy = !needReady || s.ready
+	for _, gs := range groups {
+		for _, cs := range gs.Components {
+			if cfg.failed(cs.event, now) {
+				healthy = false
+			}
+		}
+		if moreSevere(gs.event, st.event) {
+			st = gs.componentStatus
+		}
+	}
+	if st.event == nil {
+		st.Status = componentstatus.StatusNone.String()
+	}
+	return healthy, st, true
+}
+
+// componentName returns the name identifying a component in a group, prefixed by its kind since components
+// of different kinds can have the same ID.
+func componentName(id *componentstatus.InstanceID) string {
+	return strings.ToLower(id.Kind().String()) + ":" + id.ComponentID().String()
+}
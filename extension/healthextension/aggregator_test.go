@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var (
+	tracesID  = pipeline.NewID(pipeline.SignalTraces)
+	metricsID = pipeline.NewID(pipeline.SignalMetrics)
+
+	receiverID = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver, tracesID, metricsID)
+	tracesExp  = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter, tracesID)
+	metricsExp = componentstatus.NewInstanceID(component.MustNewID("debug"), component.KindExporter, metricsID)
+	extID      = componentstatus.NewInstanceID(component.MustNewID("zpages"), component.KindExtension)
+)
+
+func TestAggregatorSnapshot(t *testing.T) {
+	agg := newAggregator()
+	agg.record(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	agg.record(tracesExp, componentstatus.NewRecoverableErrorEvent(errors.New("unavailable")))
+	agg.record(metricsExp, componentstatus.NewEvent(componentstatus.StatusOK))
+	agg.record(extID, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	s := agg.snapshot()
+	require.Len(t, s.groups, 3)
+	assert.Equal(t, "StatusRecoverableError", s.groups["traces"].Status)
+	assert.Equal(t, "unavailable", s.groups["traces"].Error)
+	assert.Len(t, s.groups["traces"].Components, 2)
+	assert.Equal(t, "StatusOK", s.groups["metrics"].Status)
+	assert.Contains(t, s.groups["metrics"].Components, "receiver:otlp")
+	assert.Contains(t, s.groups["metrics"].Components, "exporter:debug")
+	assert.Equal(t, "StatusOK", s.groups[extensionsGroup].Components["extension:zpages"].Status)
+
+	// Stopped components are forgotten.
+	agg.record(metricsExp, componentstatus.NewEvent(componentstatus.StatusStopped))
+	assert.NotContains(t, agg.snapshot().groups["metrics"].Components, "exporter:debug")
+}
+
+func TestAggregatorCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       *componentstatus.Event
+		cfg         ProbeConfig
+		ready       bool
+		needReady   bool
+		wantHealthy bool
+	}{
+		{
+			name:        "ok",
+			event:       componentstatus.NewEvent(componentstatus.StatusOK),
+			wantHealthy: true,
+		},
+		{
+			name:        "not ready",
+			event:       componentstatus.NewEvent(componentstatus.StatusOK),
+			needReady:   true,
+			wantHealthy: false,
+		},
+		{
+			name:        "ready",
+			event:       componentstatus.NewEvent(componentstatus.StatusOK),
+			ready:       true,
+			needReady:   true,
+			wantHealthy: true,
+		},
+		{
+			name:        "recent recoverable error",
+			event:       componentstatus.NewRecoverableErrorEvent(errors.New("err")),
+			cfg:         ProbeConfig{RecoveryDuration: time.Hour},
+			wantHealthy: true,
+		},
+		{
+			name:        "stale recoverable error",
+			event:       componentstatus.NewRecoverableErrorEvent(errors.New("err")),
+			cfg:         ProbeConfig{RecoveryDuration: 0},
+			wantHealthy: false,
+		},
+		{
+			name:        "excluded permanent error",
+			event:       componentstatus.NewPermanentErrorEvent(errors.New("err")),
+			wantHealthy: true,
+		},
+		{
+			name:        "included permanent error",
+			event:       componentstatus.NewPermanentErrorEvent(errors.New("err")),
+			cfg:         ProbeConfig{IncludePermanentErrors: true},
+			wantHealthy: false,
+		},
+		{
+			name:        "fatal error",
+			event:       componentstatus.NewFatalErrorEvent(errors.New("err")),
+			cfg:         ProbeConfig{RecoveryDuration: time.Hour},
+			wantHealthy: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := newAggregator()
+			agg.setReady(tt.ready)
+			agg.record(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+			agg.record(tracesExp, tt.event)
+			s := agg.snapshot()
+			now := time.Now()
+
+			healthy, st, found := s.check(&tt.cfg, tt.needReady, "", now)
+			require.True(t, found)
+			assert.Equal(t, tt.wantHealthy, healthy)
+			assert.Equal(t, tt.event.Status().String(), st.Status)
+
+			healthy, _, found = s.check(&tt.cfg, tt.needReady, "traces", now)
+			require.True(t, found)
+			assert.Equal(t, tt.wantHealthy, healthy)
+
+			// The metrics pipeline only contains the receiver, which is fine.
+			healthy, _, found = s.check(&tt.cfg, tt.needReady, "metrics", now)
+			require.True(t, found)
+			assert.Equal(t, !tt.needReady || tt.ready, healthy)
+
+			_, _, found = s.check(&tt.cfg, tt.needReady, "logs", now)
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestAggregatorCheckAllComponents(t *testing.T) {
+	agg := newAggregator()
+	agg.record(receiverID, componentstatus.NewRecoverableErrorEvent(errors.New("recoverable")))
+	agg.record(tracesExp, componentstatus.NewPermanentErrorEvent(errors.New("permanent")))
+
+	// The permanent error is the most severe, but the stale recoverable error makes the probe fail.
+	healthy, st, found := agg.snapshot().check(&ProbeConfig{RecoveryDuration: time.Minute}, false, "traces", time.Now().Add(time.Hour))
+	require.True(t, found)
+	assert.False(t, healthy)
+	assert.Equal(t, "permanent", st.Error)
+}
+
+func TestAggregatorEmpty(t *testing.T) {
+	healthy, st, found := newAggregator().snapshot().check(&ProbeConfig{}, false, "", time.Now())
+	require.True(t, found)
+	assert.True(t, healthy)
+	assert.Equal(t, "StatusNone", st.Status)
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+)
+
+// Config has the configuration of the health extension.
+type Config struct {
+	// HTTP is the configuration of the HTTP server serving the liveness, readiness and status endpoints.
+	HTTP configoptional.Optional[confighttp.ServerConfig] `mapstructure:"http"`
+
+	// GRPC is the configuration of the gRPC server implementing the gRPC health checking protocol.
+	GRPC configoptional.Optional[configgrpc.ServerConfig] `mapstructure:"grpc"`
+
+	// Liveness defines which component errors make the collector not alive.
+	Liveness ProbeConfig `mapstructure:"liveness"`
+
+	// Readiness defines which component errors make the collector not ready.
+	Readiness ProbeConfig `mapstructure:"readiness"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ProbeConfig defines which component errors make a probe fail. A fatal error always makes it fail.
+type ProbeConfig struct {
+	// RecoveryDuration is how long a component can report a recoverable error before the probe fails.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+
+	// IncludePermanentErrors makes the probe fail when a component reports a permanent error.
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if !cfg.HTTP.HasValue() && !cfg.GRPC.HasValue() {
+		return errors.New("must specify at least one protocol when using the health extension")
+	}
+	if cfg.Liveness.RecoveryDuration < 0 || cfg.Readiness.RecoveryDuration < 0 {
+		return errors.New("recovery_duration must not be negative")
+	}
+	return nil
+}
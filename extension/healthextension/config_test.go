@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.Endpoint = "localhost:13000"
+	grpcCfg := configgrpc.NewDefaultServerConfig()
+	grpcCfg.NetAddr.Endpoint = "localhost:13001"
+	assert.Equal(t,
+		&Config{
+			HTTP:      configoptional.Some(httpCfg),
+			GRPC:      configoptional.Some(grpcCfg),
+			Liveness:  ProbeConfig{RecoveryDuration: time.Minute},
+			Readiness: ProbeConfig{RecoveryDuration: 10 * time.Second},
+		}, cfg)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.HTTP = configoptional.None[confighttp.ServerConfig]()
+	require.EqualError(t, cfg.Validate(), "must specify at least one protocol when using the health extension")
+
+	cfg = NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Readiness.RecoveryDuration = -time.Second
+	require.EqualError(t, cfg.Validate(), "recovery_duration must not be negative")
+}
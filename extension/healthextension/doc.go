@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package healthextension implements an extension that serves the liveness and readiness of the collector,
+// aggregated per pipeline from the status reported by the components, over HTTP and the gRPC health checking
+// protocol.
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+const (
+	livenessPath  = "/livez"
+	readinessPath = "/readyz"
+	statusPath    = "/status"
+
+	// pipelineParam restricts a probe to the components of the given pipeline.
+	pipelineParam = "pipeline"
+
+	// Names of the services checked through the gRPC health checking protocol, any other service name is
+	// interpreted as a pipeline ID whose readiness is checked.
+	livenessService  = "liveness"
+	readinessService = "readiness"
+)
+
+var (
+	_ extensioncapabilities.PipelineWatcher = (*healthExtension)(nil)
+	_ componentstatus.Watcher               = (*healthExtension)(nil)
+)
+
+type healthExtension struct {
+	config     *Config
+	telemetry  component.TelemetrySettings
+	aggregator *aggregator
+
+	httpServer *http.Server
+	grpcServer *grpc.Server
+	serversWg  sync.WaitGroup
+}
+
+func newHealthExtension(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	return &healthExtension{
+		config:     config,
+		telemetry:  telemetry,
+		aggregator: newAggregator(),
+	}
+}
+
+func (he *healthExtension) Start(ctx context.Context, host component.Host) error {
+	serve := func(serveFn func() error, closedErr error) {
+		he.serversWg.Add(1)
+		go func() {
+			defer he.serversWg.Done()
+			if err := serveFn(); err != nil && !errors.Is(err, closedErr) {
+				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
+			}
+		}()
+	}
+
+	if he.config.HTTP.HasValue() {
+		httpCfg := he.config.HTTP.Get()
+		// Start the listener here so we can have earlier failure if port is
+		// already in use.
+		ln, err := httpCfg.ToListener(ctx)
+		if err != nil {
+			return err
+		}
+		he.httpServer, err = httpCfg.ToServer(ctx, host, he.telemetry, he.httpHandler())
+		if err != nil {
+			ln.Close()
+			return err
+		}
+		he.telemetry.Logger.Info("Starting HTTP health server", zap.String("endpoint", httpCfg.Endpoint))
+		serve(func() error { return he.httpServer.Serve(ln) }, http.ErrServerClosed)
+	}
+
+	if he.config.GRPC.HasValue() {
+		grpcCfg := he.config.GRPC.Get()
+		ln, err := grpcCfg.NetAddr.Listen(ctx)
+		if err != nil {
+			return errors.Join(err, he.closeServers())
+		}
+		he.grpcServer, err = grpcCfg.ToServer(ctx, host, he.telemetry)
+		if err != nil {
+			ln.Close()
+			return errors.Join(err, he.closeServers())
+		}
+		healthpb.RegisterHealthServer(he.grpcServer, &healthServer{aggregator: he.aggregator, config: he.config})
+		he.telemetry.Logger.Info("Starting gRPC health server", zap.String("endpoint", grpcCfg.NetAddr.Endpoint))
+		serve(func() error { return he.grpcServer.Serve(ln) }, grpc.ErrServerStopped)
+	}
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	err := he.closeServers()
+	he.serversWg.Wait()
+	return err
+}
+
+func (he *healthExtension) closeServers() error {
+	var err error
+	if he.httpServer != nil {
+		err = he.httpServer.Close()
+	}
+	if he.grpcServer != nil {
+		he.grpcServer.Stop()
+	}
+	return err
+}
+
+// ComponentStatusChanged implements the componentstatus.Watcher interface.
+func (he *healthExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	he.aggregator.record(source, event)
+}
+
+// Ready implements the extensioncapabilities.PipelineWatcher interface.
+func (he *healthExtension) Ready() error {
+	he.aggregator.setReady(true)
+	return nil
+}
+
+// NotReady implements the extensioncapabilities.PipelineWatcher interface.
+func (he *healthExtension) NotReady() error {
+	he.aggregator.setReady(false)
+	return nil
+}
+
+// probeResponse is the body of the liveness and readiness responses.
+type probeResponse struct {
+	Healthy bool `json:"healthy"`
+	componentStatus
+}
+
+// statusResponse is the body of the status response.
+type statusResponse struct {
+	Live  bool `json:"live"`
+	Ready bool `json:"ready"`
+	componentStatus
+	Pipelines map[string]*groupStatus `json:"pipelines"`
+}
+
+func (he *healthExtension) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(livenessPath, func(w http.ResponseWriter, r *http.Request) {
+		he.handleProbe(w, r, &he.config.Liveness, false)
+	})
+	mux.HandleFunc(readinessPath, func(w http.ResponseWriter, r *http.Request) {
+		he.handleProbe(w, r, &he.config.Readiness, true)
+	})
+	mux.HandleFunc(statusPath, func(w http.ResponseWriter, _ *http.Request) {
+		s := he.aggregator.snapshot()
+		now := time.Now()
+		live, st, _ := s.check(&he.config.Liveness, false, "", now)
+		ready, _, _ := s.check(&he.config.Readiness, true, "", now)
+		writeJSON(w, http.StatusOK, statusResponse{
+			Live:            live,
+			Ready:           ready,
+			componentStatus: st,
+			Pipelines:       s.groups,
+		})
+	})
+	return mux
+}
+
+func (he *healthExtension) handleProbe(w http.ResponseWriter, r *http.Request, cfg *ProbeConfig, needReady bool) {
+	group := r.URL.Query().Get(pipelineParam)
+	healthy, st, found := he.aggregator.snapshot().check(cfg, needReady, group, time.Now())
+	if !found {
+		http.Error(w, "unknown pipeline "+group, http.StatusNotFound)
+		return
+	}
+	code := http.StatusOK
+	if !healthy {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, probeResponse{Healthy: healthy, componentStatus: st})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// healthServer implements the gRPC health checking protocol on top of the aggregated component status.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	aggregator *aggregator
+	config     *Config
+}
+
+func (hs *healthServer) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	cfg, needReady, group := &hs.config.Readiness, true, ""
+	switch req.GetService() {
+	case "", readinessService:
+	case livenessService:
+		cfg, needReady = &hs.config.Liveness, false
+	default:
+		group = req.GetService()
+	}
+	healthy, _, found := hs.aggregator.snapshot().check(cfg, needReady, group, time.Now())
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	if !healthy {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func newTestExtension(t *testing.T) (*healthExtension, string, string) {
+	httpEndpoint := testutil.GetAvailableLocalAddress(t)
+	grpcEndpoint := testutil.GetAvailableLocalAddress(t)
+	cfg := &Config{
+		HTTP: configoptional.Some(confighttp.ServerConfig{Endpoint: httpEndpoint}),
+		GRPC: configoptional.Some(configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{Endpoint: grpcEndpoint, Transport: confignet.TransportTypeTCP},
+		}),
+		Liveness:  ProbeConfig{RecoveryDuration: 0},
+		Readiness: ProbeConfig{RecoveryDuration: time.Hour, IncludePermanentErrors: true},
+	}
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+	return he, "http://" + httpEndpoint, grpcEndpoint
+}
+
+func getJSON(t *testing.T, url string, v any) int {
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if v != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func TestHealthExtensionHTTP(t *testing.T) {
+	he, url, _ := newTestExtension(t)
+	he.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	he.ComponentStatusChanged(tracesExp, componentstatus.NewEvent(componentstatus.StatusOK))
+	he.ComponentStatusChanged(metricsExp, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	var probe probeResponse
+	assert.Equal(t, http.StatusOK, getJSON(t, url+livenessPath, &probe))
+	assert.True(t, probe.Healthy)
+	assert.Equal(t, "StatusOK", probe.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, url+readinessPath, nil))
+
+	require.NoError(t, he.Ready())
+	assert.Equal(t, http.StatusOK, getJSON(t, url+readinessPath, nil))
+
+	he.ComponentStatusChanged(metricsExp, componentstatus.NewPermanentErrorEvent(errors.New("invalid credentials")))
+	assert.Equal(t, http.StatusOK, getJSON(t, url+livenessPath, nil))
+	probe = probeResponse{}
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, url+readinessPath, &probe))
+	assert.False(t, probe.Healthy)
+	assert.Equal(t, "invalid credentials", probe.Error)
+	assert.Equal(t, http.StatusOK, getJSON(t, url+readinessPath+"?pipeline=traces", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, url+readinessPath+"?pipeline=metrics", nil))
+	assert.Equal(t, http.StatusNotFound, getJSON(t, url+readinessPath+"?pipeline=logs", nil))
+
+	var st statusResponse
+	assert.Equal(t, http.StatusOK, getJSON(t, url+statusPath, &st))
+	assert.True(t, st.Live)
+	assert.False(t, st.Ready)
+	assert.Equal(t, "StatusPermanentError", st.Status)
+	require.Contains(t, st.Pipelines, "metrics")
+	assert.Equal(t, "invalid credentials", st.Pipelines["metrics"].Components["exporter:debug"].Error)
+	assert.Equal(t, "StatusOK", st.Pipelines["traces"].Status)
+
+	require.NoError(t, he.NotReady())
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, url+readinessPath+"?pipeline=traces", nil))
+}
+
+func TestHealthExtensionGRPC(t *testing.T) {
+	he, _, endpoint := newTestExtension(t)
+	he.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	he.ComponentStatusChanged(tracesExp, componentstatus.NewRecoverableErrorEvent(errors.New("unavailable")))
+	require.NoError(t, he.Ready())
+
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	client := healthpb.NewHealthClient(conn)
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, checkErr := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, checkErr)
+		return resp.GetStatus()
+	}
+	// Readiness tolerates recoverable errors, while liveness fails as soon as one is reported.
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(readinessService))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(livenessService))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("traces"))
+
+	he.ComponentStatusChanged(tracesExp, componentstatus.NewFatalErrorEvent(errors.New("fatal")))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("traces"))
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "logs"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHealthExtensionPortInUse(t *testing.T) {
+	_, url, _ := newTestExtension(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.HTTP.Get().Endpoint = url[len("http://"):]
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.Error(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, he.Shutdown(context.Background()))
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+const (
+	defaultHTTPEndpoint = "localhost:13133"
+	defaultGRPCEndpoint = "localhost:13132"
+
+	defaultLivenessRecoveryDuration = 5 * time.Minute
+)
+
+// NewFactory creates a factory for the health extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.Endpoint = defaultHTTPEndpoint
+	grpcCfg := configgrpc.NewDefaultServerConfig()
+	grpcCfg.NetAddr.Endpoint = defaultGRPCEndpoint
+
+	return &Config{
+		HTTP: configoptional.Some(httpCfg),
+		GRPC: configoptional.Default(grpcCfg),
+		Liveness: ProbeConfig{
+			RecoveryDuration: defaultLivenessRecoveryDuration,
+		},
+		Readiness: ProbeConfig{
+			IncludePermanentErrors: true,
+		},
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newHealthExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
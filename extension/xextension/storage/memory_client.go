@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "go.opentelemetry.io/collector/extension/xextension/storage"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var errWrongOperationType = errors.New("wrong operation type")
+
+// memoryClient is a reference implementation of the TransactionalClient and IterableClient
+// interfaces, keeping the data in memory.
+type memoryClient struct {
+	mu sync.Mutex
+	st map[string][]byte
+}
+
+// NewMemoryClient returns a client that keeps the data in memory, so it is lost when the
+// process exits. It implements the TransactionalClient and IterableClient interfaces,
+// and is intended to be used in tests and as a reference implementation.
+func NewMemoryClient() Client {
+	return &memoryClient{st: map[string][]byte{}}
+}
+
+// Get will retrieve data from memory, and returns nil, nil if not found
+func (c *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.st[key], nil
+}
+
+// Set will store a copy of the data in memory
+func (c *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.st[key] = bytes.Clone(value)
+	return nil
+}
+
+// Delete will delete data from memory
+func (c *memoryClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.st, key)
+	return nil
+}
+
+// Close does nothing and returns nil, the data is kept until the client is garbage collected
+func (c *memoryClient) Close(context.Context) error {
+	return nil
+}
+
+// Batch handles the operations atomically, since they are all applied while holding the lock
+func (c *memoryClient) Batch(ctx context.Context, ops ...*Operation) error {
+	return c.AtomicBatch(ctx, ops...)
+}
+
+// AtomicBatch handles the operations atomically, or returns an error without applying any of them
+// if an operation has an unknown type
+func (c *memoryClient) AtomicBatch(_ context.Context, ops ...*Operation) error {
+	for _, op := range ops {
+		if op.Type != Get && op.Type != Set && op.Type != Delete {
+			return errWrongOperationType
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case Get:
+			op.Value = c.st[op.Key]
+		case Set:
+			c.st[op.Key] = bytes.Clone(op.Value)
+		case Delete:
+			delete(c.st, op.Key)
+		}
+	}
+	return nil
+}
+
+// CompareAndSet will store newValue only if the current data is equal to oldValue
+func (c *memoryClient) CompareAndSet(_ context.Context, key string, oldValue, newValue []byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	val := c.st[key]
+	if (val == nil) != (oldValue == nil) || !bytes.Equal(val, oldValue) {
+		return false, nil
+	}
+	if newValue == nil {
+		delete(c.st, key)
+	} else {
+		c.st[key] = bytes.Clone(newValue)
+	}
+	return true, nil
+}
+
+// List will retrieve the sorted keys starting with the prefix
+func (c *memoryClient) List(_ context.Context, prefix string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var keys []string
+	for key := range c.st {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// Range will call fn for the keys in [start, end). The keys are read before calling fn, and the data of every key
+// right before calling fn for it, so fn can use the client. The keys added during the iteration are not iterated over,
+// and the ones deleted are skipped.
+func (c *memoryClient) Range(_ context.Context, start, end string, fn func(string, []byte) bool) error {
+	c.mu.Lock()
+	var keys []string
+	for key := range c.st {
+		if key >= start && (end == "" || key < end) {
+			keys = append(keys, key)
+		}
+	}
+	c.mu.Unlock()
+
+	slices.Sort(keys)
+	for _, key := range keys {
+		c.mu.Lock()
+		value, ok := c.st[key]
+		c.mu.Unlock()
+		if ok && !fn(key, value) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Count will return the number of keys starting with the prefix
+func (c *memoryClient) Count(_ context.Context, prefix string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := 0
+	for key := range c.st {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -7,7 +7,7 @@ import "context"
 
 type nopClient struct{}
 
-var nopClientInstance Client = &nopClient{}
+var nopClientInstance IterableClient = &nopClient{}
 
 // NewNopClient returns a nop client
 func NewNopClient() Client {
@@ -38,3 +38,18 @@ func (c nopClient) Close(context.Context) error {
 func (c nopClient) Batch(context.Context, ...*Operation) error {
 	return nil // no result, but no problem
 }
+
+// List does nothing, and returns nil, nil
+func (c nopClient) List(context.Context, string) ([]string, error) {
+	return nil, nil // no result, but no problem
+}
+
+// Range does nothing and returns nil
+func (c nopClient) Range(context.Context, string, string, func(string, []byte) bool) error {
+	return nil // no result, but no problem
+}
+
+// Count does nothing, and returns 0, nil
+func (c nopClient) Count(context.Context, string) (int, error) {
+	return 0, nil // no result, but no problem
+}
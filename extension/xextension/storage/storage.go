@@ -54,6 +54,47 @@ type Client interface {
 	Close(ctx context.Context) error
 }
 
+// TransactionalClient is an optional interface that storage clients can implement
+// to provide atomicity guarantees, in addition to the Client interface.
+// Components can detect it using a type assertion on the Client returned by Extension.GetClient.
+type TransactionalClient interface {
+	Client
+
+	// CompareAndSet will store newValue only if the data currently stored for
+	// the specified key is equal to oldValue, where a nil oldValue means the key
+	// is not found. A nil newValue deletes the key. It returns true if the data
+	// was stored, or false without error if the current data is different.
+	CompareAndSet(ctx context.Context, key string, oldValue, newValue []byte) (bool, error)
+
+	// AtomicBatch handles specified operations in batch, like Batch, but either
+	// all the Set and Delete operations are applied or none of them is, even if
+	// the process crashes. Get operations observe the changes made by the
+	// previous operations of the same batch.
+	AtomicBatch(ctx context.Context, ops ...*Operation) error
+}
+
+// IterableClient is an optional interface that storage clients can implement
+// to allow enumerating the stored data, in addition to the Client interface.
+// Components can detect it using a type assertion on the Client returned by Extension.GetClient.
+type IterableClient interface {
+	Client
+
+	// List will retrieve the keys starting with the specified prefix, sorted
+	// in lexicographic order. An empty prefix retrieves all the keys, Range
+	// should be used instead to iterate over a large number of keys.
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Range will call fn for every key in the range [start, end), and the data
+	// corresponding to it, in lexicographic order of the keys. An empty end
+	// means the range has no upper bound. The iteration stops if fn returns false,
+	// so the keys can be read one page at a time, starting the next page right
+	// after the last key iterated over.
+	Range(ctx context.Context, start, end string, fn func(key string, value []byte) bool) error
+
+	// Count will return the number of keys starting with the specified prefix.
+	Count(ctx context.Context, prefix string) (int, error)
+}
+
 type OpType int
 
 const (
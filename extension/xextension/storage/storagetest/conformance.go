@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storagetest provides conformance tests for the implementations of the storage client interfaces.
+package storagetest // import "go.opentelemetry.io/collector/extension/xextension/storage/storagetest"
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// CheckClient runs the conformance tests of the storage.Client interface.
+// The newClient function is called for every test and must return a client with no data stored.
+func CheckClient(t *testing.T, newClient func(*testing.T) storage.Client) {
+	t.Run("get_set_delete", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+
+		val, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Nil(t, val, "Get must return nil for a key not found")
+
+		require.NoError(t, client.Set(ctx, "key", []byte("v1")))
+		val, err = client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), val)
+
+		require.NoError(t, client.Set(ctx, "key", []byte("v2")), "Set must overwrite an existing key")
+		val, err = client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v2"), val)
+
+		require.NoError(t, client.Delete(ctx, "key"))
+		val, err = client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Nil(t, val)
+
+		require.NoError(t, client.Delete(ctx, "key"), "Delete must not fail for a key not found")
+		require.NoError(t, client.Close(ctx))
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+		require.NoError(t, client.Set(ctx, "deleted", []byte("v")))
+
+		getOp := storage.GetOperation("missing")
+		require.NoError(t, client.Batch(ctx,
+			storage.SetOperation("key1", []byte("v1")),
+			storage.SetOperation("key2", []byte("v2")),
+			storage.DeleteOperation("deleted"),
+			getOp,
+		))
+		assert.Nil(t, getOp.Value)
+
+		getOps := []*storage.Operation{
+			storage.GetOperation("key1"),
+			storage.GetOperation("key2"),
+			storage.GetOperation("deleted"),
+		}
+		require.NoError(t, client.Batch(ctx, getOps...))
+		assert.Equal(t, []byte("v1"), getOps[0].Value)
+		assert.Equal(t, []byte("v2"), getOps[1].Value)
+		assert.Nil(t, getOps[2].Value)
+		require.NoError(t, client.Close(ctx))
+	})
+}
+
+// CheckTransactionalClient runs the conformance tests of the storage.TransactionalClient interface,
+// including the ones of the storage.Client interface.
+// The newClient function is called for every test and must return a client with no data stored.
+func CheckTransactionalClient(t *testing.T, newClient func(*testing.T) storage.TransactionalClient) {
+	CheckClient(t, func(t *testing.T) storage.Client { return newClient(t) })
+
+	t.Run("compare_and_set", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+
+		swapped, err := client.CompareAndSet(ctx, "key", []byte("v0"), []byte("v1"))
+		require.NoError(t, err)
+		assert.False(t, swapped, "CompareAndSet must not store the data if the key is not found")
+
+		swapped, err = client.CompareAndSet(ctx, "key", nil, []byte("v1"))
+		require.NoError(t, err)
+		assert.True(t, swapped)
+
+		swapped, err = client.CompareAndSet(ctx, "key", nil, []byte("v2"))
+		require.NoError(t, err)
+		assert.False(t, swapped, "CompareAndSet must not store the data if the key is found")
+
+		swapped, err = client.CompareAndSet(ctx, "key", []byte("v0"), []byte("v2"))
+		require.NoError(t, err)
+		assert.False(t, swapped, "CompareAndSet must not store the data if the current value is different")
+		val, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), val)
+
+		swapped, err = client.CompareAndSet(ctx, "key", []byte("v1"), []byte("v2"))
+		require.NoError(t, err)
+		assert.True(t, swapped)
+		val, err = client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v2"), val)
+
+		swapped, err = client.CompareAndSet(ctx, "key", []byte("v2"), nil)
+		require.NoError(t, err)
+		assert.True(t, swapped)
+		val, err = client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Nil(t, val, "CompareAndSet must delete the key if the new value is nil")
+		require.NoError(t, client.Close(ctx))
+	})
+
+	t.Run("compare_and_set_concurrent", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+		const workers, increments = 8, 25
+
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < increments; {
+					old, err := client.Get(ctx, "counter")
+					if !assert.NoError(t, err) {
+						return
+					}
+					n := 0
+					if old != nil {
+						n, err = strconv.Atoi(string(old))
+						if !assert.NoError(t, err) {
+							return
+						}
+					}
+					swapped, err := client.CompareAndSet(ctx, "counter", old, []byte(strconv.Itoa(n+1)))
+					if !assert.NoError(t, err) {
+						return
+					}
+					if swapped {
+						i++
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		val, err := client.Get(ctx, "counter")
+		require.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(workers*increments), string(val), "CompareAndSet must not lose concurrent updates")
+		require.NoError(t, client.Close(ctx))
+	})
+
+	t.Run("atomic_batch", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+		require.NoError(t, client.Set(ctx, "deleted", []byte("v")))
+
+		getOps := []*storage.Operation{
+			storage.GetOperation("key1"),
+			storage.GetOperation("deleted"),
+		}
+		require.NoError(t, client.AtomicBatch(ctx,
+			storage.SetOperation("key1", []byte("v1")),
+			storage.DeleteOperation("deleted"),
+			getOps[0],
+			getOps[1],
+		))
+		assert.Equal(t, []byte("v1"), getOps[0].Value, "AtomicBatch must observe the previous operations of the batch")
+		assert.Nil(t, getOps[1].Value, "AtomicBatch must observe the previous operations of the batch")
+		require.NoError(t, client.Close(ctx))
+	})
+
+	t.Run("atomic_batch_all_or_nothing", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+		require.NoError(t, client.Set(ctx, "key1", []byte("v0")))
+
+		err := client.AtomicBatch(ctx,
+			storage.SetOperation("key1", []byte("v1")),
+			storage.SetOperation("key2", []byte("v2")),
+			&storage.Operation{Key: "key3", Type: storage.OpType(-1)},
+		)
+		require.Error(t, err, "AtomicBatch must fail for an invalid operation")
+
+		val, err := client.Get(ctx, "key1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v0"), val, "AtomicBatch must not apply any operation of a failed batch")
+		val, err = client.Get(ctx, "key2")
+		require.NoError(t, err)
+		assert.Nil(t, val, "AtomicBatch must not apply any operation of a failed batch")
+		require.NoError(t, client.Close(ctx))
+	})
+}
+
+// CheckIterableClient runs the conformance tests of the storage.IterableClient interface,
+// including the ones of the storage.Client interface.
+// The newClient function is called for every test and must return a client with no data stored.
+func CheckIterableClient(t *testing.T, newClient func(*testing.T) storage.IterableClient) {
+	CheckClient(t, func(t *testing.T) storage.Client { return newClient(t) })
+
+	setKeys := func(t *testing.T, client storage.Client, keys ...string) {
+		for _, key := range keys {
+			require.NoError(t, client.Set(context.Background(), key, []byte("value_"+key)))
+		}
+	}
+
+	t.Run("list", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+
+		keys, err := client.List(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+
+		setKeys(t, client, "b/2", "a", "b/1", "b", "c/1")
+		require.NoError(t, client.Delete(ctx, "c/1"))
+
+		keys, err = client.List(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "b/1", "b/2"}, keys, "List must return all the keys in lexicographic order")
+
+		keys, err = client.List(ctx, "b/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b/1", "b/2"}, keys)
+
+		keys, err = client.List(ctx, "c/")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+		require.NoError(t, client.Close(ctx))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+		setKeys(t, client, "3", "1", "4", "2", "5")
+
+		collect := func(start, end string, limit int) map[string][]byte {
+			var keys []string
+			values := map[string][]byte{}
+			require.NoError(t, client.Range(ctx, start, end, func(key string, value []byte) bool {
+				keys = append(keys, key)
+				values[key] = value
+				return len(keys) < limit
+			}))
+			assert.IsIncreasing(t, keys, "Range must iterate the keys in lexicographic order")
+			return values
+		}
+
+		assert.Equal(t, map[string][]byte{"2": []byte("value_2"), "3": []byte("value_3")}, collect("2", "4", 10),
+			"Range must include the start and exclude the end")
+		assert.Len(t, collect("2", "", 10), 4, "Range must not have an upper bound if the end is empty")
+		assert.Len(t, collect("", "", 10), 5)
+		assert.Equal(t, map[string][]byte{"1": []byte("value_1"), "2": []byte("value_2")}, collect("", "", 2),
+			"Range must stop when the function returns false")
+		assert.Empty(t, collect("6", "", 10))
+		require.NoError(t, client.Close(ctx))
+	})
+
+	t.Run("count", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+
+		count, err := client.Count(ctx, "")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		setKeys(t, client, "a/1", "a/2", "b/1")
+		count, err = client.Count(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		count, err = client.Count(ctx, "a/")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		require.NoError(t, client.Close(ctx))
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func TestMemoryClientConformance(t *testing.T) {
+	CheckTransactionalClient(t, func(*testing.T) storage.TransactionalClient {
+		return storage.NewMemoryClient().(storage.TransactionalClient)
+	})
+	CheckIterableClient(t, func(*testing.T) storage.IterableClient {
+		return storage.NewMemoryClient().(storage.IterableClient)
+	})
+}
+
+func TestMemoryClientRangeDelete(t *testing.T) {
+	client := storage.NewMemoryClient().(storage.IterableClient)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, client.Set(context.Background(), key, []byte(key)))
+	}
+
+	// The keys deleted during the iteration are skipped.
+	var keys []string
+	require.NoError(t, client.Range(context.Background(), "", "", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		require.NoError(t, client.Delete(context.Background(), "b"))
+		return true
+	}))
+	assert.Equal(t, []string{"a", "c"}, keys)
+}
@@ -6,22 +6,43 @@ package filter // import "go.opentelemetry.io/collector/filter"
 import (
 	"errors"
 	"regexp"
+	"regexp/syntax"
+	"strings"
 )
 
 // Config configures the matching behavior of a Filter.
+// Exactly one of Strict, Regex, Glob, Prefix or Suffix must be set.
 type Config struct {
+	// Strict matches values equal to the given string.
 	Strict string `mapstructure:"strict"`
-	Regex  string `mapstructure:"regexp"`
+	// Regex matches strings containing a match of the given regular expression.
+	Regex string `mapstructure:"regexp"`
+	// Glob matches strings matching the given pattern, where `*` matches any sequence of characters,
+	// `?` matches any single character, and `[...]` matches any character in the set (or not in the set, if it
+	// starts with `!`). Special characters can be escaped with `\`.
+	Glob string `mapstructure:"glob"`
+	// Prefix matches strings starting with the given string.
+	Prefix string `mapstructure:"prefix"`
+	// Suffix matches strings ending with the given string.
+	Suffix string `mapstructure:"suffix"`
+	// CaseInsensitive makes the matching ignore the case of the letters.
+	CaseInsensitive bool `mapstructure:"case_insensitive"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
 func (c Config) Validate() error {
-	if c.Strict == "" && c.Regex == "" {
-		return errors.New("must specify either strict or regex")
+	set := 0
+	for _, s := range []string{c.Strict, c.Regex, c.Glob, c.Prefix, c.Suffix} {
+		if s != "" {
+			set++
+		}
+	}
+	if set == 0 {
+		return errors.New("must specify one of strict, regexp, glob, prefix or suffix")
 	}
-	if c.Strict != "" && c.Regex != "" {
-		return errors.New("strict and regex cannot be used together")
+	if set > 1 {
+		return errors.New("only one of strict, regexp, glob, prefix or suffix can be used")
 	}
 
 	if c.Regex != "" {
@@ -31,12 +52,105 @@ func (c Config) Validate() error {
 		}
 	}
 
+	if c.Glob != "" {
+		_, err := globToRegex(c.Glob)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// regex returns the regular expression equivalent to the config, or an empty string for a case-sensitive
+// strict config, which is matched without a regular expression.
+func (c Config) regex() (string, error) {
+	var expr string
+	switch {
+	case c.Strict != "":
+		if !c.CaseInsensitive {
+			return "", nil
+		}
+		expr = `^` + regexp.QuoteMeta(c.Strict) + `$`
+	case c.Regex != "":
+		expr = c.Regex
+	case c.Glob != "":
+		var err error
+		if expr, err = globToRegex(c.Glob); err != nil {
+			return "", err
+		}
+	case c.Prefix != "":
+		expr = `^` + regexp.QuoteMeta(c.Prefix)
+	case c.Suffix != "":
+		expr = regexp.QuoteMeta(c.Suffix) + `$`
+	default:
+		return "", nil
+	}
+	if c.CaseInsensitive {
+		return `(?i:` + expr + `)`, nil
+	}
+	return expr, nil
+}
+
+// globToRegex translates a glob pattern to an equivalent regular expression matching the whole string.
+func globToRegex(glob string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(`(?s:^`)
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			sb.WriteString(`.*`)
+		case '?':
+			sb.WriteString(`.`)
+		case '\\':
+			i++
+			if i == len(glob) {
+				return "", errors.New("invalid glob pattern: trailing escape character")
+			}
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return "", errors.New("invalid glob pattern: unterminated character set")
+			}
+			set := glob[i+1 : i+1+end]
+			i += end + 1
+			sb.WriteByte('[')
+			if strings.HasPrefix(set, "!") {
+				sb.WriteByte('^')
+				set = set[1:]
+			}
+			if set == "" {
+				return "", errors.New("invalid glob pattern: empty character set")
+			}
+			// Only the range operator keeps its special meaning in a set.
+			for _, r := range set {
+				if r == '-' {
+					sb.WriteRune(r)
+					continue
+				}
+				sb.WriteString(regexp.QuoteMeta(string(r)))
+			}
+			sb.WriteByte(']')
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	sb.WriteString(`$)`)
+	expr := sb.String()
+	if _, err := regexp.Compile(expr); err != nil {
+		return "", err
+	}
+	return expr, nil
+}
+
 type combinedFilter struct {
 	stricts map[any]struct{}
-	regexes []*regexp.Regexp
+	// anchored is the alternation of the regular expressions anchored at the start of the text, and unanchored is
+	// the alternation of the others. Each alternation is compiled into a single automaton, so the cost of matching
+	// a value does not grow linearly with the number of configs.
+	anchored   *regexp.Regexp
+	unanchored *regexp.Regexp
 }
 
 // CreateFilter creates a Filter out of a set of Config configuration objects.
@@ -44,31 +158,70 @@ func CreateFilter(configs []Config) Filter {
 	cf := &combinedFilter{
 		stricts: make(map[any]struct{}),
 	}
+	var anchored, unanchored []*syntax.Regexp
 	for _, config := range configs {
-		if config.Strict != "" {
+		if config.Strict != "" && !config.CaseInsensitive {
 			cf.stricts[config.Strict] = struct{}{}
+			continue
 		}
 
-		if config.Regex != "" {
-			// Validate() call above ensures that the regex is valid.
-			re := regexp.MustCompile(config.Regex)
-			cf.regexes = append(cf.regexes, re)
+		// Validate() call above ensures that the config is valid.
+		expr, err := config.regex()
+		if err != nil {
+			panic(err)
 		}
+		if expr == "" {
+			continue
+		}
+		re, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			panic(err)
+		}
+		if stripped, ok := stripBeginText(re); ok {
+			anchored = append(anchored, stripped)
+		} else {
+			unanchored = append(unanchored, re)
+		}
+	}
+	if len(anchored) > 0 {
+		// Anchor the whole alternation instead of each expression, so the literal prefixes shared by the
+		// expressions can be factored out, and the matching is not attempted at every position of the value.
+		cf.anchored = regexp.MustCompile(`^(?:` + alternate(anchored).String() + `)`)
+	}
+	if len(unanchored) > 0 {
+		cf.unanchored = regexp.MustCompile(alternate(unanchored).String())
 	}
 	return cf
 }
 
+// stripBeginText returns the regular expression without the leading start of text anchor, if it has one.
+func stripBeginText(re *syntax.Regexp) (*syntax.Regexp, bool) {
+	switch {
+	case re.Op == syntax.OpBeginText:
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}, true
+	case re.Op == syntax.OpConcat && len(re.Sub) > 0 && re.Sub[0].Op == syntax.OpBeginText:
+		stripped := *re
+		stripped.Sub = re.Sub[1:]
+		return &stripped, true
+	}
+	return re, false
+}
+
+func alternate(subs []*syntax.Regexp) *syntax.Regexp {
+	if len(subs) == 1 {
+		return subs[0]
+	}
+	return &syntax.Regexp{Op: syntax.OpAlternate, Sub: subs}
+}
+
 func (cf *combinedFilter) Matches(toMatch any) bool {
 	_, ok := cf.stricts[toMatch]
 	if ok {
 		return ok
 	}
 	if str, ok := toMatch.(string); ok {
-		for _, re := range cf.regexes {
-			if re.MatchString(str) {
-				return true
-			}
-		}
+		return (cf.anchored != nil && cf.anchored.MatchString(str)) ||
+			(cf.unanchored != nil && cf.unanchored.MatchString(str))
 	}
 	return false
 }
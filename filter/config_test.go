@@ -4,6 +4,7 @@
 package filter
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -37,6 +38,22 @@ func TestConfig(t *testing.T) {
 				Strict: "strict",
 			},
 		},
+		"glob/default": {
+			{
+				Glob: "one.*",
+			},
+		},
+		"prefix/case_insensitive": {
+			{
+				Prefix:          "One",
+				CaseInsensitive: true,
+			},
+		},
+		"suffix/default": {
+			{
+				Suffix: ".two",
+			},
+		},
 	}
 
 	for testName, actualCfg := range actualConfigs {
@@ -77,6 +94,135 @@ func TestMatches(t *testing.T) {
 	assert.True(t, fs.Matches("c"))
 }
 
+func TestMatchesModes(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		matches   []string
+		noMatches []string
+	}{
+		{
+			name:      "strict",
+			cfg:       Config{Strict: "host.name"},
+			matches:   []string{"host.name"},
+			noMatches: []string{"HOST.NAME", "host.name2", "hostxname"},
+		},
+		{
+			name:      "strict/case_insensitive",
+			cfg:       Config{Strict: "host.name", CaseInsensitive: true},
+			matches:   []string{"host.name", "HOST.Name"},
+			noMatches: []string{"host.name2", "hostxname"},
+		},
+		{
+			name:      "regexp",
+			cfg:       Config{Regex: "^k8s\\.(pod|node)"},
+			matches:   []string{"k8s.pod.name", "k8s.node"},
+			noMatches: []string{"K8S.pod", "k8s.container"},
+		},
+		{
+			name:      "regexp/case_insensitive",
+			cfg:       Config{Regex: "^k8s\\.(pod|node)", CaseInsensitive: true},
+			matches:   []string{"K8S.Pod.name"},
+			noMatches: []string{"k8s.container"},
+		},
+		{
+			name:      "glob",
+			cfg:       Config{Glob: "k8s.*.na?e"},
+			matches:   []string{"k8s.pod.name", "k8s.pod.sub.nabe", "k8s..name"},
+			noMatches: []string{"k8s.pod.name2", "xk8s.pod.name", "k8s.pod.nme", "k8sxpod.name"},
+		},
+		{
+			name:      "glob/sets_and_escapes",
+			cfg:       Config{Glob: "v[0-9][!a-c]\\*"},
+			matches:   []string{"v1d*", "v9z*"},
+			noMatches: []string{"v1a*", "vxd*", "v1d", "v1dd"},
+		},
+		{
+			name:      "glob/case_insensitive",
+			cfg:       Config{Glob: "Host.*", CaseInsensitive: true},
+			matches:   []string{"host.name", "HOST.ID"},
+			noMatches: []string{"myhost.name"},
+		},
+		{
+			name:      "prefix",
+			cfg:       Config{Prefix: "k8s."},
+			matches:   []string{"k8s.pod", "k8s."},
+			noMatches: []string{"K8S.pod", "xk8s.pod", "k8sxpod"},
+		},
+		{
+			name:      "prefix/case_insensitive",
+			cfg:       Config{Prefix: "k8s.", CaseInsensitive: true},
+			matches:   []string{"K8S.pod"},
+			noMatches: []string{"xk8s.pod"},
+		},
+		{
+			name:      "suffix",
+			cfg:       Config{Suffix: ".name"},
+			matches:   []string{"host.name", ".name"},
+			noMatches: []string{"host.NAME", "host.name2", "hostxname"},
+		},
+		{
+			name:      "suffix/case_insensitive",
+			cfg:       Config{Suffix: ".name", CaseInsensitive: true},
+			matches:   []string{"host.NAME"},
+			noMatches: []string{"host.name2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.cfg.Validate())
+			fs := CreateFilter([]Config{tt.cfg})
+			for _, m := range tt.matches {
+				assert.True(t, fs.Matches(m), "expected %q to match", m)
+			}
+			for _, m := range tt.noMatches {
+				assert.False(t, fs.Matches(m), "expected %q not to match", m)
+			}
+		})
+	}
+}
+
+func TestMatchesCombined(t *testing.T) {
+	cfg := []Config{
+		{Strict: "a"},
+		{Regex: "(?i)^b"},
+		{Regex: "c$"},
+		{Glob: "d*"},
+		{Prefix: "E", CaseInsensitive: true},
+		{Suffix: "f"},
+		{Regex: "^g|h"},
+	}
+	for _, c := range cfg {
+		require.NoError(t, c.Validate())
+	}
+	fs := CreateFilter(cfg)
+
+	for _, m := range []string{"a", "B1", "1c", "d1", "e1", "1f", "g1", "1h"} {
+		assert.True(t, fs.Matches(m), "expected %q to match", m)
+	}
+	// The flags of a regular expression do not apply to the others.
+	for _, m := range []string{"A", "c1", "1d", "1e", "F", "1g"} {
+		assert.False(t, fs.Matches(m), "expected %q not to match", m)
+	}
+	// Only strict matches values that are not strings.
+	assert.False(t, fs.Matches(1))
+	assert.True(t, CreateFilter([]Config{{Strict: "a"}}).Matches("a"))
+	assert.False(t, CreateFilter(nil).Matches("a"))
+}
+
+func BenchmarkMatches(b *testing.B) {
+	cfg := make([]Config, 0, 200)
+	for i := range 200 {
+		cfg = append(cfg, Config{Regex: fmt.Sprintf("^attribute_%d_[a-z]+$", i)})
+	}
+	fs := CreateFilter(cfg)
+	b.ResetTimer()
+	for b.Loop() {
+		fs.Matches("attribute_not_matching_any_pattern")
+	}
+}
+
 func TestConfigInvalid(t *testing.T) {
 	actualConfigs := readTestdataConfigYamls(t, "config_invalid.yaml")
 	expectedConfigs := map[string][]Config{
@@ -97,6 +243,17 @@ func TestConfigInvalid(t *testing.T) {
 				Strict: "1",
 			},
 		},
+		"invalid/glob": {
+			{
+				Glob: "one[a-",
+			},
+		},
+		"invalid/config_prefix_and_suffix_set": {
+			{
+				Prefix: "1",
+				Suffix: "1",
+			},
+		},
 	}
 
 	for testName, actualCfg := range actualConfigs {
@@ -23,10 +23,19 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
 	"go.opentelemetry.io/collector/service"
 )
 
+var incrementalReloadFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"otelcol.incrementalReload",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("When enabled, a configuration change restarts only the pipeline components "+
+		"whose configuration changed, instead of the whole service"),
+)
+
 // State defines Collector's state.
 type State int
 
@@ -171,28 +180,65 @@ func buildModuleInfo(m map[component.Type]string) map[component.Type]service.Mod
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	col.setCollectorState(StateStarting)
 
+	set, cfg, err := col.serviceSettings(ctx)
+	if err != nil {
+		return err
+	}
+	col.serviceConfig = &cfg.Service
+
+	col.service, err = service.New(ctx, set, cfg.Service)
+	if err != nil {
+		return err
+	}
+	if col.updateConfigProviderLogger != nil {
+		col.updateConfigProviderLogger(col.service.Logger().Core())
+	}
+	if col.bc != nil {
+		x := col.bc.TakeLogs()
+		for _, log := range x {
+			ce := col.service.Logger().Core().Check(log.Entry, nil)
+			if ce != nil {
+				ce.Write(log.Context...)
+			}
+		}
+	}
+
+	if !col.set.SkipSettingGRPCLogger {
+		grpclog.SetLogger(col.service.Logger())
+	}
+
+	if err = col.service.Start(ctx); err != nil {
+		return multierr.Combine(err, col.service.Shutdown(ctx))
+	}
+	col.setCollectorState(StateRunning)
+
+	return nil
+}
+
+// serviceSettings gets and validates the configuration, and returns it with the settings of its service.
+func (col *Collector) serviceSettings(ctx context.Context) (service.Settings, *Config, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return service.Settings{}, nil, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 	cfg, err := col.configProvider.Get(ctx, factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return service.Settings{}, nil, fmt.Errorf("failed to get config: %w", err)
 	}
 
 	if err = xconfmap.Validate(cfg); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return service.Settings{}, nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	col.serviceConfig = &cfg.Service
-
 	conf := confmap.New()
 
 	if err = conf.Marshal(cfg); err != nil {
-		return fmt.Errorf("could not marshal configuration: %w", err)
+		return service.Settings{}, nil, fmt.Errorf("could not marshal configuration: %w", err)
 	}
+	// The configuration is shared with the extensions, which must not see the sensitive values.
+	conf = col.configProvider.redact(conf)
 
-	col.service, err = service.New(ctx, service.Settings{
+	return service.Settings{
 		BuildInfo:     col.set.BuildInfo,
 		CollectorConf: conf,
 
@@ -216,36 +262,18 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		},
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
-	}, cfg.Service)
-	if err != nil {
-		return err
-	}
-	if col.updateConfigProviderLogger != nil {
-		col.updateConfigProviderLogger(col.service.Logger().Core())
-	}
-	if col.bc != nil {
-		x := col.bc.TakeLogs()
-		for _, log := range x {
-			ce := col.service.Logger().Core().Check(log.Entry, nil)
-			if ce != nil {
-				ce.Write(log.Context...)
-			}
-		}
-	}
-
-	if !col.set.SkipSettingGRPCLogger {
-		grpclog.SetLogger(col.service.Logger())
-	}
-
-	if err = col.service.Start(ctx); err != nil {
-		return multierr.Combine(err, col.service.Shutdown(ctx))
-	}
-	col.setCollectorState(StateRunning)
-
-	return nil
+	}, cfg, nil
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
+	if incrementalReloadFeatureGate.IsEnabled() {
+		err := col.reloadPipelines(ctx)
+		if err == nil {
+			return nil
+		}
+		col.service.Logger().Warn("Failed to reload the pipelines", zap.Error(err))
+	}
+
 	col.service.Logger().Warn("Config updated, restart service")
 	col.setCollectorState(StateClosing)
 
@@ -260,6 +288,22 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	return nil
 }
 
+// reloadPipelines updates the running service to the new configuration, restarting only the pipeline components
+// whose configuration changed.
+func (col *Collector) reloadPipelines(ctx context.Context) error {
+	col.service.Logger().Info("Config updated, reload pipelines")
+
+	set, cfg, err := col.serviceSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if err = col.service.Reload(ctx, set, cfg.Service); err != nil {
+		return err
+	}
+	col.serviceConfig = &cfg.Service
+	return nil
+}
+
 func (col *Collector) DryRun(ctx context.Context) error {
 	factories, err := col.set.Factories()
 	if err != nil {
@@ -286,6 +330,7 @@ func (col *Collector) DryRun(ctx context.Context) error {
 		ConnectorsFactories: factories.Connectors,
 	}, service.Config{
 		Pipelines: cfg.Service.Pipelines,
+		Shutdown:  cfg.Service.Shutdown,
 	})
 }
 
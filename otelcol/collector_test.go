@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"syscall"
 	"testing"
@@ -19,12 +20,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	yaml "go.yaml.in/yaml/v3"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -167,6 +170,83 @@ func TestCollectorStateAfterConfigChange(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+func TestCollectorIncrementalReload(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(incrementalReloadFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(incrementalReloadFeatureGate.ID(), false))
+	})
+
+	var mu sync.Mutex
+	var watcher confmap.WatcherFunc
+	var messages []string
+	metricsProcessors := []any{"nop"}
+	metricsLevel := "none"
+	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		watcher = w
+		conf := newConfFromFile(t, uri[5:])
+		conf["service"].(map[string]any)["telemetry"] = map[string]any{"metrics": map[string]any{"level": metricsLevel}}
+		conf["service"].(map[string]any)["pipelines"].(map[string]any)["metrics"].(map[string]any)["processors"] = metricsProcessors
+		return confmap.NewRetrieved(conf)
+	})
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: nopFactories,
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{filepath.Join("testdata", "otelcol-nop.yaml")},
+				ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			},
+		},
+		LoggingOptions: []zap.Option{zap.Hooks(func(entry zapcore.Entry) error {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, entry.Message)
+			return nil
+		})},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 10*time.Second, 10*time.Millisecond)
+	logged := func(message string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Contains(messages, message)
+	}
+
+	// A pipeline change only reloads the pipelines.
+	mu.Lock()
+	metricsProcessors = []any{}
+	mu.Unlock()
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return logged("Pipelines reloaded")
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.False(t, logged("Config updated, restart service"))
+	assert.Equal(t, StateRunning, col.GetState())
+
+	// A telemetry change restarts the service.
+	mu.Lock()
+	metricsLevel = "basic"
+	mu.Unlock()
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return logged("Config updated, restart service")
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.True(t, logged("Failed to reload the pipelines"))
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
 func TestCollectorReportError(t *testing.T) {
 	col, err := NewCollector(CollectorSettings{
 		BuildInfo:              component.NewDefaultBuildInfo(),
@@ -698,3 +778,18 @@ func TestProviderAndConverterModules(t *testing.T) {
 	col.Shutdown()
 	wg.Wait()
 }
+
+func TestCollectorServiceSettingsRedacted(t *testing.T) {
+	set := explainSettings("file:"+filepath.Join("testdata", "explain", "base.yaml"), "yaml:receivers::secret::endpoint: ${secret:host}:4317")
+	col, err := NewCollector(set)
+	require.NoError(t, err)
+
+	srvSet, cfg, err := col.serviceSettings(context.Background())
+	require.NoError(t, err)
+	// The components are configured with the sensitive values, but the configuration shared with the extensions
+	// has them redacted.
+	assert.Equal(t, "s3cr3t-host:4317", cfg.Receivers[component.MustNewID("secret")].(*secretReceiverConfig).Endpoint)
+	assert.Equal(t, "[REDACTED]", srvSet.CollectorConf.Get("receivers::secret::endpoint"))
+	assert.Equal(t, "[REDACTED]", srvSet.CollectorConf.Get("receivers::secret::token"))
+	assert.Equal(t, []any{"secret"}, srvSet.CollectorConf.Get("service::pipelines::traces::receivers"))
+}
@@ -8,6 +8,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -43,6 +44,8 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
 	rootCmd.AddCommand(newConfigPrintSubCommand(set, flagSet))
+	rootCmd.AddCommand(newConfigExplainSubCommand(set, flagSet))
+	rootCmd.AddCommand(newConfigSchemaSubCommand(set))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -58,6 +61,10 @@ func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error
 	if len(resolverSet.URIs) == 0 {
 		return errors.New("at least one config flag must be provided")
 	}
+	// The rules set using flags take precedence over the ones already set.
+	if mergeRules := getMergeRulesFlag(flags); len(mergeRules) > 0 {
+		resolverSet.ListMergeRules = slices.Concat(mergeRules, resolverSet.ListMergeRules)
+	}
 
 	if set.ConfigProviderSettings.ResolverSettings.DefaultScheme == "" {
 		set.ConfigProviderSettings.ResolverSettings.DefaultScheme = "env"
@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+const diffConfigFlag = "diff-config"
+
+var explainCommandFeatureFlag = featuregate.GlobalRegistry().MustRegister(
+	"otelcol.explainConfig",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("if set to true, turns on the explain-config command"),
+)
+
+// newConfigExplainSubCommand constructs a new config explain sub command using the given CollectorSettings.
+func newConfigExplainSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var diffConfigs []string
+	cmd := &cobra.Command{
+		Use:   "explain-config",
+		Short: "Prints the Collector's effective configuration annotated with the source of each value",
+		Long: `Prints the Collector's effective configuration in YAML format, including the default values of the components, and annotates each value with the config location that set it, the ${} references expanded to set it, or the factory that provided its default.
+The configopaque.String values are redacted.
+If --` + diffConfigFlag + ` is set, prints the differences between the effective configurations of the --config locations and of the --` + diffConfigFlag + ` locations instead.
+Note: The output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !explainCommandFeatureFlag.IsEnabled() {
+				return errors.New("explain-config is currently experimental, use the otelcol.explainConfig feature gate to enable this command")
+			}
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+			explained, err := explainConfig(cmd.Context(), set.ConfigProviderSettings.ResolverSettings, factories)
+			if err != nil {
+				return err
+			}
+			if len(diffConfigs) == 0 {
+				return explained.print(cmd.OutOrStdout())
+			}
+
+			diffSet := set.ConfigProviderSettings.ResolverSettings
+			diffSet.URIs = diffConfigs
+			other, err := explainConfig(cmd.Context(), diffSet, factories)
+			if err != nil {
+				return err
+			}
+			return printConfigDiff(cmd.OutOrStdout(), explained, other)
+		},
+	}
+	cmd.Flags().StringArrayVar(&diffConfigs, diffConfigFlag, nil,
+		"Locations to the config file(s) to compare with the --config ones, note that only a single location can be set per flag entry.")
+	cmd.Flags().AddGoFlagSet(flagSet)
+	return cmd
+}
+
+// explainedConfig is an effective configuration, with the source of each of its values.
+type explainedConfig struct {
+	// uris are the config locations, in merge order.
+	uris []string
+	// references are the URIs of the ${} references expanded while resolving the configuration.
+	references []string
+	// conf is the effective configuration, including the defaults, and with the sensitive values redacted.
+	conf *confmap.Conf
+	// sources maps the keys of the values of conf to their source.
+	sources map[string]string
+}
+
+// configLayer is the configuration retrieved from a single config location.
+type configLayer struct {
+	uri string
+	// raw is the configuration before the ${} references are expanded.
+	raw *confmap.Conf
+	// resolved is the configuration after the ${} references are expanded.
+	resolved *confmap.Conf
+}
+
+func explainConfig(ctx context.Context, set confmap.ResolverSettings, factories Factories) (*explainedConfig, error) {
+	resolved, resolver, err := resolveConfig(ctx, set)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := effectiveConfig(resolved, factories)
+	if err != nil {
+		return nil, err
+	}
+	effective = resolver.Redact(effective)
+
+	// Resolve every location on its own, to know which values it sets.
+	explained := &explainedConfig{uris: set.URIs, conf: effective, sources: map[string]string{}}
+	layers := make([]configLayer, 0, len(set.URIs))
+	for _, uri := range set.URIs {
+		layerSet := set
+		layerSet.URIs = []string{uri}
+		layerSet.ConverterFactories = nil
+		conf, retrieved, err := resolveRecording(ctx, layerSet)
+		if err != nil {
+			return nil, err
+		}
+		layer := configLayer{uri: uri, resolved: conf, raw: confmap.New()}
+		// The location is retrieved first, then the ${} references it contains.
+		if raw, err := retrieved[0].ret.AsConf(); err == nil {
+			layer.raw = raw
+		}
+		for _, ref := range retrieved[1:] {
+			if !slices.Contains(explained.references, ref.uri) {
+				explained.references = append(explained.references, ref.uri)
+			}
+		}
+		layers = append(layers, layer)
+	}
+	slices.Sort(explained.references)
+
+	for _, key := range effective.AllKeys() {
+		explained.sources[key] = explainKey(key, layers, resolved)
+	}
+	return explained, nil
+}
+
+// explainKey returns the source of the value of the key.
+func explainKey(key string, layers []configLayer, resolved *confmap.Conf) string {
+	var setBy []string
+	var last configLayer
+	for _, layer := range layers {
+		if layer.resolved.IsSet(key) {
+			setBy = append(setBy, layer.uri)
+			last = layer
+		}
+	}
+	if len(setBy) == 0 {
+		if resolved.IsSet(key) {
+			return "set by a converter"
+		}
+		return explainDefault(key)
+	}
+
+	source := last.uri
+	if len(setBy) > 1 {
+		source += " (also set by " + strings.Join(setBy[:len(setBy)-1], ", ") + ")"
+	}
+	// The reference may be the value of the key, or of one of its parents.
+	parts := strings.Split(key, confmap.KeyDelimiter)
+	for i := len(parts); i > 0; i-- {
+		if str, ok := last.raw.Get(strings.Join(parts[:i], confmap.KeyDelimiter)).(string); ok && strings.Contains(str, "${") {
+			source += ", expanded from " + str
+			break
+		}
+	}
+	return source
+}
+
+// explainDefault returns the source of the default value of the key.
+func explainDefault(key string) string {
+	parts := strings.SplitN(key, confmap.KeyDelimiter, 3)
+	switch {
+	case len(parts) >= 2 && slices.Contains([]string{"receivers", "processors", "exporters", "connectors", "extensions"}, parts[0]):
+		typ, _, _ := strings.Cut(parts[1], "/")
+		return fmt.Sprintf("default of the %s %s factory", typ, strings.TrimSuffix(parts[0], "s"))
+	case len(parts) >= 2 && parts[0] == "service" && parts[1] == "telemetry":
+		return "default of the telemetry factory"
+	default:
+		return "default"
+	}
+}
+
+// retrieved is a value retrieved by a provider while resolving a configuration.
+type retrieved struct {
+	uri string
+	ret *confmap.Retrieved
+}
+
+// recordingProvider records the values it retrieves, and does not watch them for changes.
+type recordingProvider struct {
+	confmap.Provider
+	record func(retrieved)
+}
+
+func (rp *recordingProvider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	ret, err := rp.Provider.Retrieve(ctx, uri, nil)
+	if err == nil {
+		rp.record(retrieved{uri: uri, ret: ret})
+	}
+	return ret, err
+}
+
+// resolveRecording resolves the configuration, and returns the values retrieved by the providers, in order.
+func resolveRecording(ctx context.Context, set confmap.ResolverSettings) (*confmap.Conf, []retrieved, error) {
+	var rets []retrieved
+	factories := make([]confmap.ProviderFactory, len(set.ProviderFactories))
+	for i, factory := range set.ProviderFactories {
+		factories[i] = confmap.NewProviderFactory(func(ps confmap.ProviderSettings) confmap.Provider {
+			return &recordingProvider{Provider: factory.Create(ps), record: func(r retrieved) { rets = append(rets, r) }}
+		})
+	}
+	set.ProviderFactories = factories
+
+	conf, _, err := resolveConfig(ctx, set)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conf, rets, nil
+}
+
+// print writes the configuration as YAML, with the source of each value as a comment.
+func (e *explainedConfig) print(w io.Writer) error {
+	var node yaml.Node
+	if err := node.Encode(e.conf.ToStringMap()); err != nil {
+		return fmt.Errorf("error while marshaling to YAML: %w", err)
+	}
+	annotateNode(&node, "", e.sources)
+	b, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Errorf("error while marshaling to YAML: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Config locations, in merge order:\n")
+	for _, uri := range e.uris {
+		sb.WriteString("#   " + uri + "\n")
+	}
+	if len(e.references) > 0 {
+		sb.WriteString("# Expanded references:\n")
+		for _, ref := range e.references {
+			sb.WriteString("#   " + ref + "\n")
+		}
+	}
+	sb.Write(b)
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// annotateNode adds the sources of the values of the YAML mapping node as comments.
+func annotateNode(n *yaml.Node, path string, sources map[string]string) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + confmap.KeyDelimiter + key.Value
+		}
+		if value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+			annotateNode(value, keyPath, sources)
+			continue
+		}
+		source, ok := sources[keyPath]
+		if !ok {
+			continue
+		}
+		// The comment of a block sequence is written after its key, and the others after their value.
+		if value.Kind == yaml.SequenceNode && len(value.Content) > 0 {
+			key.LineComment = source
+		} else {
+			value.LineComment = source
+		}
+	}
+}
+
+// printConfigDiff writes the values which are different in the configurations, with their sources.
+func printConfigDiff(w io.Writer, from, to *explainedConfig) error {
+	fromKeys, toKeys := from.conf.AllKeys(), to.conf.AllKeys()
+	keys := slices.Concat(fromKeys, toKeys)
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	var sb strings.Builder
+	sb.WriteString("--- " + strings.Join(from.uris, " ") + "\n")
+	sb.WriteString("+++ " + strings.Join(to.uris, " ") + "\n")
+	for _, key := range keys {
+		inFrom, inTo := slices.Contains(fromKeys, key), slices.Contains(toKeys, key)
+		if inFrom && inTo && reflect.DeepEqual(from.conf.Get(key), to.conf.Get(key)) {
+			continue
+		}
+		if inFrom {
+			writeDiffLine(&sb, "-", key, from)
+		}
+		if inTo {
+			writeDiffLine(&sb, "+", key, to)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeDiffLine(sb *strings.Builder, prefix, key string, e *explainedConfig) {
+	val, err := json.Marshal(e.conf.Get(key))
+	if err != nil {
+		val = []byte(fmt.Sprint(e.conf.Get(key)))
+	}
+	fmt.Fprintf(sb, "%s %s: %s # %s\n", prefix, key, val, e.sources[key])
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type secretReceiverConfig struct {
+	Endpoint string              `mapstructure:"endpoint"`
+	Token    configopaque.String `mapstructure:"token"`
+	Timeout  time.Duration       `mapstructure:"timeout"`
+}
+
+func explainFactories() (Factories, error) {
+	factories, err := nopFactories()
+	if err != nil {
+		return Factories{}, err
+	}
+	factories.Receivers[component.MustNewType("secret")] = receiver.NewFactory(component.MustNewType("secret"), func() component.Config {
+		return &secretReceiverConfig{Endpoint: "localhost:4317", Timeout: 5 * time.Second}
+	})
+	return factories, nil
+}
+
+func explainSettings(uris ...string) CollectorSettings {
+	return CollectorSettings{
+		Factories: explainFactories,
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs: uris,
+				ProviderFactories: []confmap.ProviderFactory{
+					fileprovider.NewFactory(),
+					yamlprovider.NewFactory(),
+					newFakeProvider("env", func(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+						return confmap.NewRetrieved(uri[len("env:"):] + ":4318")
+					}),
+					newFakeProvider("secret", func(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+						return confmap.NewRetrieved("s3cr3t-"+uri[len("secret:"):], confmap.WithRetrievedSensitive())
+					}),
+				},
+				DefaultScheme: "env",
+			},
+		},
+	}
+}
+
+func enableExplainCommand(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(explainCommandFeatureFlag.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(explainCommandFeatureFlag.ID(), false))
+	})
+}
+
+func runExplainCommand(t *testing.T, set CollectorSettings, args ...string) string {
+	enableExplainCommand(t)
+	cmd := NewCommand(set)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs(append([]string{"explain-config"}, args...))
+	require.NoError(t, cmd.Execute())
+	return out.String()
+}
+
+func TestExplainCommand(t *testing.T) {
+	base := "file:" + filepath.Join("testdata", "explain", "base.yaml")
+	out := runExplainCommand(t, explainSettings(base, "yaml:receivers::secret::endpoint: ${env:remote}"))
+
+	assert.Contains(t, out, "# Config locations, in merge order:\n#   "+base+"\n#   yaml:receivers::secret::endpoint: ${env:remote}\n")
+	assert.Contains(t, out, "# Expanded references:\n#   env:remote\n")
+	assert.Contains(t, out, "endpoint: remote:4318 # yaml:receivers::secret::endpoint: ${env:remote}, expanded from ${env:remote}\n")
+	assert.Contains(t, out, "timeout: 5s # default of the secret receiver factory\n")
+	assert.Contains(t, out, "token: '[REDACTED]' # "+base+"\n")
+	assert.NotContains(t, out, "my-token")
+	assert.Contains(t, out, "nop: {} # "+base+"\n")
+	assert.Contains(t, out, "receivers: # "+base+"\n")
+	assert.Contains(t, out, "level: info # default of the telemetry factory\n")
+}
+
+func TestExplainCommandOverrides(t *testing.T) {
+	base := "file:" + filepath.Join("testdata", "explain", "base.yaml")
+	out := runExplainCommand(t, explainSettings(base, "yaml:receivers::secret::token: other"))
+	assert.Contains(t, out, "token: '[REDACTED]' # yaml:receivers::secret::token: other (also set by "+base+")\n")
+	assert.NotContains(t, out, "Expanded references")
+}
+
+func TestExplainCommandSensitiveValues(t *testing.T) {
+	base := "file:" + filepath.Join("testdata", "explain", "base.yaml")
+	out := runExplainCommand(t, explainSettings(base, "yaml:receivers::secret::endpoint: ${secret:host}:4317"))
+	assert.Contains(t, out, "endpoint: '[REDACTED]' # yaml:receivers::secret::endpoint: ${secret:host}:4317, expanded from ${secret:host}:4317\n")
+	assert.NotContains(t, out, "s3cr3t")
+}
+
+func TestExplainCommandDiff(t *testing.T) {
+	base := "file:" + filepath.Join("testdata", "explain", "base.yaml")
+	out := runExplainCommand(t, explainSettings(base), "--diff-config="+base,
+		"--diff-config=yaml:receivers::secret::endpoint: ${env:remote}",
+		"--diff-config=yaml:service::pipelines::traces::exporters: [nop/2]",
+		"--diff-config=yaml:exporters::nop/2:")
+
+	assert.Equal(t, "--- "+base+"\n"+
+		"+++ "+base+" yaml:receivers::secret::endpoint: ${env:remote} yaml:service::pipelines::traces::exporters: [nop/2] yaml:exporters::nop/2:\n"+
+		"+ exporters::nop/2: {} # yaml:exporters::nop/2:\n"+
+		"- receivers::secret::endpoint: \"localhost:4317\" # default of the secret receiver factory\n"+
+		"+ receivers::secret::endpoint: \"remote:4318\" # yaml:receivers::secret::endpoint: ${env:remote}, expanded from ${env:remote}\n"+
+		"- service::pipelines::traces::exporters: [\"nop\"] # "+base+"\n"+
+		"+ service::pipelines::traces::exporters: [\"nop/2\"] # yaml:service::pipelines::traces::exporters: [nop/2] (also set by "+base+")\n", out)
+}
+
+func TestExplainCommandFeatureGate(t *testing.T) {
+	cmd := NewCommand(explainSettings("file:" + filepath.Join("testdata", "explain", "base.yaml")))
+	cmd.SetArgs([]string{"explain-config"})
+	require.ErrorContains(t, cmd.Execute(), "explain-config is currently experimental, use the otelcol.explainConfig feature gate to enable this command")
+}
+
+func TestExplainCommandErrors(t *testing.T) {
+	enableExplainCommand(t)
+	cmd := NewCommand(explainSettings("yaml:receivers::unknown:"))
+	cmd.SetArgs([]string{"explain-config"})
+	require.ErrorContains(t, cmd.Execute(), "cannot unmarshal the configuration")
+
+	cmd = NewCommand(explainSettings("file:" + filepath.Join("testdata", "explain", "base.yaml")))
+	cmd.SetArgs([]string{"explain-config", "--diff-config=file:non-existent.yaml"})
+	require.ErrorContains(t, cmd.Execute(), "error while resolving config")
+}
@@ -4,6 +4,8 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -15,6 +17,19 @@ import (
 	"go.opentelemetry.io/collector/featuregate"
 )
 
+const (
+	printModeFlag   = "mode"
+	printFormatFlag = "format"
+
+	// printModeRaw prints the configuration before it is unmarshaled.
+	printModeRaw = "raw"
+	// printModeRedacted prints the configuration after it is unmarshaled, with the defaults and redacted values.
+	printModeRedacted = "redacted"
+
+	printFormatYAML = "yaml"
+	printFormatJSON = "json"
+)
+
 var printCommandFeatureFlag = featuregate.GlobalRegistry().MustRegister(
 	"otelcol.printInitialConfig",
 	featuregate.StageAlpha,
@@ -24,35 +39,108 @@ var printCommandFeatureFlag = featuregate.GlobalRegistry().MustRegister(
 
 // newConfigPrintSubCommand constructs a new config print sub command using the given CollectorSettings.
 func newConfigPrintSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var mode, format string
 	cmd := &cobra.Command{
 		Use:   "print-initial-config",
 		Short: "Prints the Collector's configuration in YAML format after all config sources are resolved and merged",
-		Long:  `Note: This command prints the final yaml configuration before it is unmarshaled into config structs, which may contain sensitive values.`,
-		Args:  cobra.ExactArgs(0),
+		Long: `Note: In the ` + printModeRaw + ` mode, this command prints the final yaml configuration before it is unmarshaled into config structs, which may contain sensitive values.
+In the ` + printModeRedacted + ` mode, the configuration is unmarshaled into the config structs of the components, so it includes their default values, and the configopaque.String values are redacted.
+In both modes, the values retrieved as sensitive by the providers, e.g. the secretfile provider, are redacted.`,
+		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !printCommandFeatureFlag.IsEnabled() {
 				return errors.New("print-initial-config is currently experimental, use the otelcol.printInitialConfig feature gate to enable this command")
 			}
+			if mode != printModeRaw && mode != printModeRedacted {
+				return fmt.Errorf("invalid value %q for the --%s flag, must be %q or %q", mode, printModeFlag, printModeRaw, printModeRedacted)
+			}
+			if format != printFormatYAML && format != printFormatJSON {
+				return fmt.Errorf("invalid value %q for the --%s flag, must be %q or %q", format, printFormatFlag, printFormatYAML, printFormatJSON)
+			}
 			err := updateSettingsUsingFlags(&set, flagSet)
 			if err != nil {
 				return err
 			}
-			resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+			conf, resolver, err := resolveConfig(cmd.Context(), set.ConfigProviderSettings.ResolverSettings)
 			if err != nil {
-				return fmt.Errorf("failed to create new resolver: %w", err)
+				return err
 			}
-			conf, err := resolver.Resolve(cmd.Context())
-			if err != nil {
-				return fmt.Errorf("error while resolving config: %w", err)
+			if mode == printModeRedacted {
+				factories, err := set.Factories()
+				if err != nil {
+					return fmt.Errorf("failed to initialize factories: %w", err)
+				}
+				if conf, err = effectiveConfig(conf, factories); err != nil {
+					return err
+				}
 			}
-			b, err := yaml.Marshal(conf.ToStringMap())
-			if err != nil {
+			conf = resolver.Redact(conf)
+
+			var b []byte
+			if format == printFormatJSON {
+				m, err := jsonCompatible(conf.ToStringMap())
+				if err != nil {
+					return fmt.Errorf("error while marshaling to JSON: %w", err)
+				}
+				if b, err = json.MarshalIndent(m, "", "  "); err != nil {
+					return fmt.Errorf("error while marshaling to JSON: %w", err)
+				}
+			} else if b, err = yaml.Marshal(conf.ToStringMap()); err != nil {
 				return fmt.Errorf("error while marshaling to YAML: %w", err)
 			}
-			fmt.Printf("%s\n", b)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\n", b)
 			return nil
 		},
 	}
+	cmd.Flags().StringVar(&mode, printModeFlag, printModeRaw,
+		fmt.Sprintf("Printed configuration, %q for the configuration before it is unmarshaled, or %q for the unmarshaled configuration with the defaults and the sensitive values redacted.", printModeRaw, printModeRedacted))
+	cmd.Flags().StringVar(&format, printFormatFlag, printFormatYAML,
+		fmt.Sprintf("Output format, %q or %q.", printFormatYAML, printFormatJSON))
 	cmd.Flags().AddGoFlagSet(flagSet)
 	return cmd
 }
+
+// resolveConfig resolves the configuration once, without watching it for changes. The returned Resolver is shut down,
+// and can only be used to redact the sensitive values retrieved while resolving the configuration.
+func resolveConfig(ctx context.Context, set confmap.ResolverSettings) (*confmap.Conf, *confmap.Resolver, error) {
+	resolver, err := confmap.NewResolver(set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new resolver: %w", err)
+	}
+	conf, err := resolver.Resolve(ctx)
+	if shutdownErr := resolver.Shutdown(ctx); err == nil && shutdownErr != nil {
+		err = shutdownErr
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while resolving config: %w", err)
+	}
+	return conf, resolver, nil
+}
+
+// effectiveConfig unmarshals the configuration into the config structs of the components, and marshals them back,
+// so the returned configuration includes the default values, and the configopaque.String values are redacted.
+func effectiveConfig(conf *confmap.Conf, factories Factories) (*confmap.Conf, error) {
+	cfg, err := unmarshal(conf, factories)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
+	}
+	effective := confmap.New()
+	if err = effective.Marshal(cfg.toConfig()); err != nil {
+		return nil, fmt.Errorf("cannot marshal the configuration: %w", err)
+	}
+	return effective, nil
+}
+
+// jsonCompatible converts the values of the map through YAML, so they are marshaled to JSON the same way as to YAML,
+// e.g. the durations as "5s" instead of a number of nanoseconds.
+func jsonCompatible(m map[string]any) (map[string]any, error) {
+	b, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var ret map[string]any
+	if err = yaml.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
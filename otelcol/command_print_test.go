@@ -5,9 +5,12 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	yaml "go.yaml.in/yaml/v3"
 
@@ -175,3 +178,104 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintCommandRedacted(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), false))
+	}()
+	set := explainSettings("file:" + filepath.Join("testdata", "explain", "base.yaml"))
+
+	tests := []struct {
+		name      string
+		args      []string
+		unmarshal func([]byte, any) error
+	}{
+		{
+			name:      "yaml",
+			args:      []string{"--mode=redacted"},
+			unmarshal: yaml.Unmarshal,
+		},
+		{
+			name:      "json",
+			args:      []string{"--mode=redacted", "--format=json"},
+			unmarshal: json.Unmarshal,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := NewCommand(set)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs(append([]string{"print-initial-config"}, test.args...))
+			require.NoError(t, cmd.Execute())
+			assert.NotContains(t, out.String(), "my-token")
+
+			var cfg map[string]any
+			require.NoError(t, test.unmarshal(out.Bytes(), &cfg))
+			conf := confmap.NewFromStringMap(cfg)
+			assert.Equal(t, "[REDACTED]", conf.Get("receivers::secret::token"))
+			assert.Equal(t, "localhost:4317", conf.Get("receivers::secret::endpoint"))
+			assert.Equal(t, "5s", conf.Get("receivers::secret::timeout"))
+			assert.Equal(t, []any{"secret"}, conf.Get("service::pipelines::traces::receivers"))
+			assert.True(t, conf.IsSet("service::telemetry::logs::level"))
+		})
+	}
+}
+
+func TestPrintCommandRawJSON(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), false))
+	}()
+	cmd := NewCommand(explainSettings("file:" + filepath.Join("testdata", "explain", "base.yaml")))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"print-initial-config", "--format=json"})
+	require.NoError(t, cmd.Execute())
+
+	var cfg map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &cfg))
+	conf := confmap.NewFromStringMap(cfg)
+	assert.Equal(t, "my-token", conf.Get("receivers::secret::token"))
+	assert.False(t, conf.IsSet("receivers::secret::timeout"))
+}
+
+func TestPrintCommandSensitiveValues(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), false))
+	}()
+	set := explainSettings("file:"+filepath.Join("testdata", "explain", "base.yaml"), "yaml:receivers::secret::endpoint: ${secret:host}:4317")
+
+	for _, mode := range []string{printModeRaw, printModeRedacted} {
+		t.Run(mode, func(t *testing.T) {
+			cmd := NewCommand(set)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{"print-initial-config", "--mode=" + mode})
+			require.NoError(t, cmd.Execute())
+			assert.NotContains(t, out.String(), "s3cr3t")
+
+			var cfg map[string]any
+			require.NoError(t, yaml.Unmarshal(out.Bytes(), &cfg))
+			assert.Equal(t, "[REDACTED]", confmap.NewFromStringMap(cfg).Get("receivers::secret::endpoint"))
+		})
+	}
+}
+
+func TestPrintCommandInvalidFlags(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), false))
+	}()
+	set := explainSettings("file:" + filepath.Join("testdata", "explain", "base.yaml"))
+
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"print-initial-config", "--mode=unknown"})
+	require.ErrorContains(t, cmd.Execute(), `invalid value "unknown" for the --mode flag`)
+
+	cmd = NewCommand(set)
+	cmd.SetArgs([]string{"print-initial-config", "--format=toml"})
+	require.ErrorContains(t, cmd.Execute(), `invalid value "toml" for the --format flag`)
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+)
+
+// newConfigSchemaSubCommand constructs a new config schema sub command using the given CollectorSettings.
+func newConfigSchemaSubCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "config-schema",
+		Short: "Prints the JSON Schema of the Collector's configuration",
+		Long: `Prints the JSON Schema of the configuration of the Collector, for the components of this distribution, which can be used for the autocompletion in editors and to check the configurations without running the Collector.
+The schema is generated from the config structs of the components, and includes their default values.
+Note: The output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+			schema, err := configSchema(set.BuildInfo, factories)
+			if err != nil {
+				return err
+			}
+			b, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error while marshaling to JSON: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\n", b)
+			return nil
+		},
+	}
+}
+
+// configSchema returns the JSON Schema of the configuration using the factories.
+func configSchema(info component.BuildInfo, factories Factories) (*configschema.Schema, error) {
+	gen := configschema.NewGenerator()
+	kinds := []struct {
+		key       string
+		kind      string
+		factories []component.Factory
+	}{
+		{key: "receivers", kind: "receiver", factories: sortedFactories(factories.Receivers)},
+		{key: "processors", kind: "processor", factories: sortedFactories(factories.Processors)},
+		{key: "exporters", kind: "exporter", factories: sortedFactories(factories.Exporters)},
+		{key: "connectors", kind: "connector", factories: sortedFactories(factories.Connectors)},
+		{key: "extensions", kind: "extension", factories: sortedFactories(factories.Extensions)},
+	}
+
+	root := &configschema.Schema{
+		Schema:               configschema.Version,
+		Title:                strings.TrimSpace(info.Command + " configuration"),
+		Description:          info.Description,
+		Type:                 "object",
+		Properties:           map[string]*configschema.Schema{},
+		AdditionalProperties: false,
+	}
+	types := map[string][]string{}
+	for _, k := range kinds {
+		section := &configschema.Schema{
+			Description:          fmt.Sprintf("The configurations of the %ss, by component ID.", k.kind),
+			Type:                 []string{"object", "null"},
+			PatternProperties:    map[string]*configschema.Schema{},
+			AdditionalProperties: false,
+		}
+		for _, factory := range k.factories {
+			typ := factory.Type().String()
+			types[k.key] = append(types[k.key], typ)
+			s, err := factorySchema(gen, factory.CreateDefaultConfig())
+			if err != nil {
+				return nil, fmt.Errorf("cannot generate the schema of the %s %s: %w", typ, k.kind, err)
+			}
+			s.Description = fmt.Sprintf("The configuration of the %s %s.", typ, k.kind)
+			section.PatternProperties[componentIDPattern([]string{typ})] = s
+		}
+		root.Properties[k.key] = section
+	}
+
+	telemetry, err := factorySchema(gen, otelconftelemetry.NewFactory().CreateDefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate the schema of the telemetry: %w", err)
+	}
+	telemetry.Description = "The configuration of the Collector's own telemetry."
+
+	componentIDs := func(description string, types ...string) *configschema.Schema {
+		return &configschema.Schema{
+			Description: description,
+			Type:        []string{"array", "null"},
+			Items:       &configschema.Schema{Type: "string", Pattern: componentIDPattern(types)},
+		}
+	}
+	pipeline := &configschema.Schema{
+		Type: []string{"object", "null"},
+		Properties: map[string]*configschema.Schema{
+			"receivers":  componentIDs("The IDs of the receivers of the pipeline.", slices.Concat(types["receivers"], types["connectors"])...),
+			"processors": componentIDs("The IDs of the processors of the pipeline, in order.", types["processors"]...),
+			"exporters":  componentIDs("The IDs of the exporters of the pipeline.", slices.Concat(types["exporters"], types["connectors"])...),
+		},
+		AdditionalProperties: false,
+	}
+	root.Properties["service"] = &configschema.Schema{
+		Description: "The components enabled in the Collector, and its own telemetry.",
+		Type:        "object",
+		Properties: map[string]*configschema.Schema{
+			"telemetry":  telemetry,
+			"extensions": componentIDs("The IDs of the enabled extensions.", types["extensions"]...),
+			"pipelines": {
+				Description: "The pipelines, by pipeline ID.",
+				Type:        []string{"object", "null"},
+				PatternProperties: map[string]*configschema.Schema{
+					componentIDPattern([]string{"traces", "metrics", "logs", "profiles"}): pipeline,
+				},
+				AdditionalProperties: false,
+			},
+		},
+		AdditionalProperties: false,
+	}
+	root.Defs = gen.Defs()
+	return root, nil
+}
+
+// factorySchema returns the schema of the default config of a factory, with its values as default.
+func factorySchema(gen *configschema.Generator, cfg component.Config) (*configschema.Schema, error) {
+	s := gen.Generate(reflect.TypeOf(cfg))
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return nil, err
+	}
+	defaults, err := jsonCompatible(conf.ToStringMap())
+	if err != nil {
+		return nil, err
+	}
+	if removeUnsetValues(defaults); len(defaults) > 0 {
+		s.Default = defaults
+	}
+	return s, nil
+}
+
+// removeUnsetValues removes the keys having a nil value or an empty map, which are the unset optional values
+// and the values which cannot be marshaled, from the map.
+func removeUnsetValues(m map[string]any) {
+	for k, v := range m {
+		switch v := v.(type) {
+		case nil:
+			delete(m, k)
+		case map[string]any:
+			if removeUnsetValues(v); len(v) == 0 {
+				delete(m, k)
+			}
+		}
+	}
+}
+
+// componentIDPattern returns the pattern matching the IDs having one of the types, in the type[/name] format.
+func componentIDPattern(types []string) string {
+	types = slices.Compact(slices.Sorted(slices.Values(types)))
+	quoted := make([]string, len(types))
+	for i, typ := range types {
+		quoted[i] = regexp.QuoteMeta(typ)
+	}
+	return "^(" + strings.Join(quoted, "|") + ")(/.+)?$"
+}
+
+func sortedFactories[T component.Factory](factories map[component.Type]T) []component.Factory {
+	sorted := sortFactoriesByType(factories)
+	ret := make([]component.Factory, len(sorted))
+	for i, f := range sorted {
+		ret[i] = f
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestConfigSchemaCommand(t *testing.T) {
+	cmd := NewCommand(CollectorSettings{
+		BuildInfo: component.BuildInfo{Command: "otelcoltest", Description: "Test distribution"},
+		Factories: explainFactories,
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"config-schema"})
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, "otelcoltest configuration", schema["title"])
+	assert.Equal(t, "Test distribution", schema["description"])
+	assert.Equal(t, false, schema["additionalProperties"])
+
+	props := schema["properties"].(map[string]any)
+	receivers := props["receivers"].(map[string]any)["patternProperties"].(map[string]any)
+	require.Contains(t, receivers, "^(nop)(/.+)?$")
+	require.Contains(t, receivers, "^(secret)(/.+)?$")
+	secret := receivers["^(secret)(/.+)?$"].(map[string]any)
+	assert.Equal(t, "The configuration of the secret receiver.", secret["description"])
+	assert.Equal(t, map[string]any{"endpoint": "localhost:4317", "timeout": "5s", "token": "[REDACTED]"}, secret["default"])
+	secretProps := secret["properties"].(map[string]any)
+	assert.Equal(t, "string", secretProps["endpoint"].(map[string]any)["type"])
+	assert.Contains(t, secretProps["timeout"], "pattern")
+	assert.Contains(t, secretProps["token"], "description")
+
+	service := props["service"].(map[string]any)["properties"].(map[string]any)
+	telemetry := service["telemetry"].(map[string]any)
+	assert.Contains(t, telemetry["properties"], "logs")
+	assert.Contains(t, telemetry["properties"], "metrics")
+	assert.Contains(t, telemetry, "default")
+
+	pipelines := service["pipelines"].(map[string]any)["patternProperties"].(map[string]any)
+	pipeline := pipelines["^(logs|metrics|profiles|traces)(/.+)?$"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "^(nop|secret)(/.+)?$", pipeline["receivers"].(map[string]any)["items"].(map[string]any)["pattern"])
+	assert.Equal(t, "^(nop)(/.+)?$", pipeline["processors"].(map[string]any)["items"].(map[string]any)["pattern"])
+	assert.Equal(t, "^(nop)(/.+)?$", service["extensions"].(map[string]any)["items"].(map[string]any)["pattern"])
+
+	assert.Contains(t, schema["$defs"], "migration.LogsConfigV030")
+}
+
+func TestConfigSchemaCommandFactoriesError(t *testing.T) {
+	cmd := NewCommand(CollectorSettings{
+		Factories: func() (Factories, error) { return Factories{}, errors.New("boom") },
+	})
+	cmd.SetArgs([]string{"config-schema"})
+	require.ErrorContains(t, cmd.Execute(), "failed to initialize factories: boom")
+}
@@ -76,6 +76,25 @@ func TestAddFlagToSettings(t *testing.T) {
 	require.Len(t, set.ConfigProviderSettings.ResolverSettings.URIs, 1)
 }
 
+func TestMergeStrategyFlagPrecedence(t *testing.T) {
+	set := CollectorSettings{
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				ProviderFactories: []confmap.ProviderFactory{newFakeProvider("file", nil)},
+				ListMergeRules:    []confmap.ListMergeRule{{Path: "service::extensions", Strategy: confmap.ListMergeAppend}},
+			},
+		},
+	}
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=otelcol-nop.yaml", "--merge-strategy=service.extensions=replace"}))
+
+	require.NoError(t, updateSettingsUsingFlags(&set, flgs))
+	assert.Equal(t, []confmap.ListMergeRule{
+		{Path: "service::extensions", Strategy: confmap.ListMergeReplace},
+		{Path: "service::extensions", Strategy: confmap.ListMergeAppend},
+	}, set.ConfigProviderSettings.ResolverSettings.ListMergeRules)
+}
+
 func TestInvalidCollectorSettings(t *testing.T) {
 	set := CollectorSettings{
 		ConfigProviderSettings: ConfigProviderSettings{
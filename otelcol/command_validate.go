@@ -4,21 +4,71 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/otelcol/internal/configunmarshaler"
+	"go.opentelemetry.io/collector/service"
+)
+
+const (
+	validateOutputFlag = "output"
+
+	validateOutputText = "text"
+	validateOutputJSON = "json"
+
+	severityError   = "error"
+	severityWarning = "warning"
 )
 
+// componentSections are the sections of the configuration holding the configurations of the components.
+var componentSections = []string{"receivers", "processors", "exporters", "connectors", "extensions"}
+
 // newValidateSubCommand constructs a new validate sub command using the given CollectorSettings.
 func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var output string
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates the config without running the collector",
-		Args:  cobra.ExactArgs(0),
+		Long: `Validates the config without running the collector.
+With --` + validateOutputFlag + `=` + validateOutputJSON + `, all the errors are reported at once, with the path of the invalid value, their severity and the component they belong to, and the components which are configured but not used are reported as warnings.
+Note: The JSON output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if output != validateOutputText && output != validateOutputJSON {
+				return fmt.Errorf("invalid value %q for the --%s flag, must be %q or %q", output, validateOutputFlag, validateOutputText, validateOutputJSON)
+			}
 			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
 				return err
 			}
+			if output == validateOutputJSON {
+				report, err := validateConfig(cmd.Context(), set)
+				if err != nil {
+					return err
+				}
+				b, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("error while marshaling to JSON: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\n", b)
+				if !report.Valid {
+					// Keep the output parseable, the usage is not printed for an invalid configuration.
+					cmd.SilenceUsage = true
+					return errors.New("the configuration is invalid")
+				}
+				return nil
+			}
 			col, err := NewCollector(set)
 			if err != nil {
 				return err
@@ -26,6 +76,227 @@ func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.
 			return col.DryRun(cmd.Context())
 		},
 	}
+	validateCmd.Flags().StringVar(&output, validateOutputFlag, validateOutputText,
+		fmt.Sprintf("Output format, %q to print the first error, or %q to print all the errors and warnings.", validateOutputText, validateOutputJSON))
 	validateCmd.Flags().AddGoFlagSet(flagSet)
 	return validateCmd
 }
+
+// validationIssue is an error or a warning found while validating the configuration.
+type validationIssue struct {
+	Severity string `json:"severity"`
+	// Path is the path of the invalid value, using confmap.KeyDelimiter to separate the keys.
+	Path string `json:"path,omitempty"`
+	// Kind and Component identify the component the invalid value belongs to, if any.
+	Kind      string `json:"kind,omitempty"`
+	Component string `json:"component,omitempty"`
+	Message   string `json:"message"`
+}
+
+// validationReport is the result of the validation of the configuration.
+type validationReport struct {
+	// Valid is false if there is at least one error, the warnings do not make the configuration invalid.
+	Valid  bool              `json:"valid"`
+	Issues []validationIssue `json:"issues"`
+}
+
+func (r *validationReport) add(severity, path string, err error) {
+	issue := validationIssue{Severity: severity, Path: path, Message: err.Error()}
+	parts := strings.Split(path, confmap.KeyDelimiter)
+	if len(parts) >= 2 && slices.Contains(componentSections, parts[0]) {
+		issue.Kind = strings.TrimSuffix(parts[0], "s")
+		issue.Component = parts[1]
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+// addAll adds the errors joined in err, each as a separate issue.
+func (r *validationReport) addAll(path string, err error) {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		r.add(severityError, path, err)
+		return
+	}
+	for _, e := range joined.Unwrap() {
+		r.addAll(path, e)
+	}
+}
+
+// addValidation adds the errors returned by xconfmap.Validate for the config at the path.
+func (r *validationReport) addValidation(path string, cfg any) {
+	err := xconfmap.Validate(cfg)
+	if err == nil {
+		return
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		var pe xconfmap.PathError
+		if errors.As(e, &pe) && pe.Path() != "" {
+			r.add(severityError, path+confmap.KeyDelimiter+pe.Path(), pe.Unwrap())
+			continue
+		}
+		r.add(severityError, path, e)
+	}
+}
+
+// validateConfig validates the configuration, and reports all the errors it finds instead of the first one.
+// The returned error is set only if the validation cannot be done.
+func validateConfig(ctx context.Context, set CollectorSettings) (*validationReport, error) {
+	factories, err := set.Factories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	report := &validationReport{Issues: []validationIssue{}}
+	defer func() {
+		slices.SortStableFunc(report.Issues, func(a, b validationIssue) int {
+			return cmp.Or(cmp.Compare(a.Severity, b.Severity), cmp.Compare(a.Path, b.Path), cmp.Compare(a.Message, b.Message))
+		})
+		report.Valid = !slices.ContainsFunc(report.Issues, func(i validationIssue) bool { return i.Severity == severityError })
+	}()
+
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new resolver: %w", err)
+	}
+	conf, err := resolver.Resolve(ctx)
+	if shutdownErr := resolver.Shutdown(ctx); err == nil && shutdownErr != nil {
+		err = shutdownErr
+	}
+	if err != nil {
+		report.add(severityError, "", fmt.Errorf("cannot resolve the configuration: %w", err))
+		return report, nil
+	}
+
+	for key := range conf.ToStringMap() {
+		if key != "service" && !slices.Contains(componentSections, key) {
+			report.add(severityError, key, errors.New("unknown section"))
+		}
+	}
+
+	cfg := &Config{
+		Receivers:  unmarshalComponents(report, conf, "receivers", factories.Receivers),
+		Processors: unmarshalComponents(report, conf, "processors", factories.Processors),
+		Exporters:  unmarshalComponents(report, conf, "exporters", factories.Exporters),
+		Connectors: unmarshalComponents(report, conf, "connectors", factories.Connectors),
+		Extensions: unmarshalComponents(report, conf, "extensions", factories.Extensions),
+		Service:    defaultServiceConfig(),
+	}
+	if err = unmarshalSection(conf, "service", &cfg.Service); err != nil {
+		report.add(severityError, "service", err)
+	} else {
+		report.addValidation("service", &cfg.Service)
+	}
+
+	for _, ce := range cfg.validate() {
+		report.add(severityError, ce.path, ce.err)
+	}
+	addUnusedComponents(report, cfg)
+
+	// The pipelines are built only if the components are valid, as their configs are used to create them.
+	if slices.ContainsFunc(report.Issues, func(i validationIssue) bool { return i.Severity == severityError }) {
+		return report, nil
+	}
+	err = service.Validate(ctx, service.Settings{
+		BuildInfo:           set.BuildInfo,
+		ReceiversConfigs:    cfg.Receivers,
+		ReceiversFactories:  factories.Receivers,
+		ProcessorsConfigs:   cfg.Processors,
+		ProcessorsFactories: factories.Processors,
+		ExportersConfigs:    cfg.Exporters,
+		ExportersFactories:  factories.Exporters,
+		ConnectorsConfigs:   cfg.Connectors,
+		ConnectorsFactories: factories.Connectors,
+	}, service.Config{
+		Pipelines: cfg.Service.Pipelines,
+	})
+	if err != nil {
+		report.addAll("service::pipelines", err)
+	}
+	return report, nil
+}
+
+func unmarshalSection(conf *confmap.Conf, key string, result any) error {
+	sub, err := conf.Sub(key)
+	if err != nil {
+		return err
+	}
+	return sub.Unmarshal(result)
+}
+
+// unmarshalComponents unmarshals and validates the configurations of the components of the section. The returned
+// map holds all the configured components, the invalid ones included, so they are not reported as missing.
+func unmarshalComponents[F component.Factory](report *validationReport, conf *confmap.Conf, section string, factories map[component.Type]F) map[component.ID]component.Config {
+	sub, err := conf.Sub(section)
+	if err != nil {
+		report.add(severityError, section, err)
+		return nil
+	}
+	cfgs := configunmarshaler.NewConfigs(factories)
+	errs, err := cfgs.UnmarshalEach(sub)
+	if err != nil {
+		report.add(severityError, section, err)
+		return nil
+	}
+
+	ret := cfgs.Configs()
+	for id, cfg := range ret {
+		report.addValidation(section+confmap.KeyDelimiter+id.String(), cfg)
+	}
+	for id, err := range errs {
+		report.add(severityError, section+confmap.KeyDelimiter+id.String(), err)
+		// The config of an unknown type cannot be created, any non-nil value marks the component as configured.
+		ret[id] = struct{}{}
+	}
+	return ret
+}
+
+// addUnusedComponents adds a warning for each component which is configured but not used by the service.
+func addUnusedComponents(report *validationReport, cfg *Config) {
+	usedAsReceiver, usedAsProcessor, usedAsExporter := map[component.ID]bool{}, map[component.ID]bool{}, map[component.ID]bool{}
+	for _, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		for _, id := range pipeline.Receivers {
+			usedAsReceiver[id] = true
+		}
+		for _, id := range pipeline.Processors {
+			usedAsProcessor[id] = true
+		}
+		for _, id := range pipeline.Exporters {
+			usedAsExporter[id] = true
+		}
+	}
+
+	warn := func(section, kind string, id component.ID, msg string) {
+		report.add(severityWarning, section+confmap.KeyDelimiter+id.String(), fmt.Errorf("%s %q is configured but %s", kind, id, msg))
+	}
+	for id := range cfg.Receivers {
+		if !usedAsReceiver[id] {
+			warn("receivers", "receiver", id, "not used in any pipeline")
+		}
+	}
+	for id := range cfg.Processors {
+		if !usedAsProcessor[id] {
+			warn("processors", "processor", id, "not used in any pipeline")
+		}
+	}
+	for id := range cfg.Exporters {
+		if !usedAsExporter[id] {
+			warn("exporters", "exporter", id, "not used in any pipeline")
+		}
+	}
+	for id := range cfg.Connectors {
+		if !usedAsReceiver[id] && !usedAsExporter[id] {
+			warn("connectors", "connector", id, "not used in any pipeline")
+		}
+	}
+	for id := range cfg.Extensions {
+		if !slices.Contains(cfg.Service.Extensions, id) {
+			warn("extensions", "extension", id, "not enabled in service::extensions")
+		}
+	}
+}
@@ -4,14 +4,21 @@
 package otelcol
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/receiver"
 )
 
 func TestValidateSubCommandNoConfig(t *testing.T) {
@@ -35,3 +42,92 @@ func TestValidateSubCommandInvalidComponents(t *testing.T) {
 	err := cmd.Execute()
 	require.ErrorContains(t, err, "unknown type: \"nosuchprocessor\"")
 }
+
+type strictReceiverConfig struct {
+	Server strictServerConfig `mapstructure:"server"`
+}
+
+type strictServerConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+func (c *strictServerConfig) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	return nil
+}
+
+func validateFactories() (Factories, error) {
+	factories, err := nopFactories()
+	if err != nil {
+		return Factories{}, err
+	}
+	factories.Receivers[component.MustNewType("strict")] = receiver.NewFactory(component.MustNewType("strict"), func() component.Config {
+		return &strictReceiverConfig{Server: strictServerConfig{Endpoint: "localhost:4317"}}
+	})
+	return factories, nil
+}
+
+func runValidateJSON(t *testing.T, file string) (validationReport, error) {
+	cmd := newValidateSubCommand(CollectorSettings{Factories: validateFactories, ConfigProviderSettings: ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:              []string{filepath.Join("testdata", file)},
+			ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+			DefaultScheme:     "file",
+		},
+	}}, flags(featuregate.GlobalRegistry()))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--output=json"})
+	err := cmd.Execute()
+
+	var report validationReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	return report, err
+}
+
+func TestValidateSubCommandJSON(t *testing.T) {
+	report, err := runValidateJSON(t, filepath.Join("validate", "invalid.yaml"))
+	require.EqualError(t, err, "the configuration is invalid")
+	assert.False(t, report.Valid)
+
+	// Compare the messages of the unmarshal errors on their prefix only.
+	require.Len(t, report.Issues, 9)
+	assert.Contains(t, report.Issues[0].Message, "error reading configuration for \"nop/unknownkey\"")
+	report.Issues[0].Message = ""
+	assert.Contains(t, report.Issues[1].Message, "unknown type: \"nosuchreceiver\"")
+	report.Issues[1].Message = ""
+	assert.Equal(t, []validationIssue{
+		{Severity: "error", Path: "receivers::nop/unknownkey", Kind: "receiver", Component: "nop/unknownkey"},
+		{Severity: "error", Path: "receivers::nosuchreceiver", Kind: "receiver", Component: "nosuchreceiver"},
+		{Severity: "error", Path: "receivers::strict::server", Kind: "receiver", Component: "strict", Message: "endpoint must be set"},
+		{Severity: "error", Path: "recievers", Message: "unknown section"},
+		{Severity: "error", Path: "service::extensions", Message: `references extension "nop/missing" which is not configured`},
+		{Severity: "error", Path: "service::pipelines::traces", Message: `references exporter "nop/missing" which is not configured`},
+		{Severity: "warning", Path: "exporters::nop/unused", Kind: "exporter", Component: "nop/unused", Message: `exporter "nop/unused" is configured but not used in any pipeline`},
+		{Severity: "warning", Path: "extensions::nop", Kind: "extension", Component: "nop", Message: `extension "nop" is configured but not enabled in service::extensions`},
+		{Severity: "warning", Path: "processors::nop", Kind: "processor", Component: "nop", Message: `processor "nop" is configured but not used in any pipeline`},
+	}, report.Issues)
+}
+
+func TestValidateSubCommandJSONValid(t *testing.T) {
+	report, err := runValidateJSON(t, "otelcol-nop.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, validationReport{Valid: true, Issues: []validationIssue{}}, report)
+}
+
+func TestValidateSubCommandJSONPipelineErrors(t *testing.T) {
+	report, err := runValidateJSON(t, "otelcol-invalid-connector-unused-exp.yaml")
+	require.Error(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "error", report.Issues[0].Severity)
+	assert.Equal(t, "service::pipelines", report.Issues[0].Path)
+	assert.Contains(t, report.Issues[0].Message, `connector "nop/connector1" used as receiver in [logs/in2] pipeline but not used in any supported exporter pipeline`)
+}
+
+func TestValidateSubCommandInvalidOutput(t *testing.T) {
+	cmd := newValidateSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--output=yaml"})
+	require.ErrorContains(t, cmd.Execute(), `invalid value "yaml" for the --output flag`)
+}
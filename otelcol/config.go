@@ -47,34 +47,60 @@ type Config struct {
 // invalid cases that we currently don't check for but which we may want to add in
 // the future (e.g. disallowing receiving and exporting on the same endpoint).
 func (cfg *Config) Validate() error {
+	if errs := cfg.validate(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// configError is an error of the configuration, with the path of the invalid value.
+type configError struct {
+	path string
+	err  error
+}
+
+func (ce *configError) Error() string {
+	if ce.path == "" {
+		return ce.err.Error()
+	}
+	return ce.path + ": " + ce.err.Error()
+}
+
+func (ce *configError) Unwrap() error {
+	return ce.err
+}
+
+// validate returns all the errors of the configuration, instead of the first one.
+func (cfg *Config) validate() []*configError {
 	// There must be at least one property set in the configuration	file.
 	if len(cfg.Receivers) == 0 && len(cfg.Exporters) == 0 && len(cfg.Processors) == 0 && len(cfg.Connectors) == 0 && len(cfg.Extensions) == 0 {
-		return errEmptyConfigurationFile
+		return []*configError{{err: errEmptyConfigurationFile}}
 	}
 
+	var errs []*configError
 	// Currently, there is no default receiver enabled.
 	// The configuration must specify at least one receiver to be valid.
 	if !pipelines.AllowNoPipelines.IsEnabled() && len(cfg.Receivers) == 0 {
-		return errMissingReceivers
+		errs = append(errs, &configError{err: errMissingReceivers})
 	}
 
 	// Currently, there is no default exporter enabled.
 	// The configuration must specify at least one exporter to be valid.
 	if !pipelines.AllowNoPipelines.IsEnabled() && len(cfg.Exporters) == 0 {
-		return errMissingExporters
+		errs = append(errs, &configError{err: errMissingExporters})
 	}
 
 	// Validate the connector configuration.
 	for connID := range cfg.Connectors {
 		if _, ok := cfg.Exporters[connID]; ok {
-			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q exporter and %q connector. "+
+			errs = append(errs, &configError{path: "connectors::" + connID.String(), err: fmt.Errorf("ambiguous ID: Found both %q exporter and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
-				connID, connID, connID, connID, connID.String()+"/connector")
+				connID, connID, connID, connID.String()+"/connector")})
 		}
 		if _, ok := cfg.Receivers[connID]; ok {
-			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q receiver and %q connector. "+
+			errs = append(errs, &configError{path: "connectors::" + connID.String(), err: fmt.Errorf("ambiguous ID: Found both %q receiver and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
-				connID, connID, connID, connID, connID.String()+"/connector")
+				connID, connID, connID, connID.String()+"/connector")})
 		}
 	}
 
@@ -82,12 +108,13 @@ func (cfg *Config) Validate() error {
 	for _, ref := range cfg.Service.Extensions {
 		// Check that the name referenced in the Service extensions exists in the top-level extensions.
 		if cfg.Extensions[ref] == nil {
-			return fmt.Errorf("service::extensions: references extension %q which is not configured", ref)
+			errs = append(errs, &configError{path: "service::extensions", err: fmt.Errorf("references extension %q which is not configured", ref)})
 		}
 	}
 
 	// Check that all pipelines reference only configured components.
 	for pipelineID, pipeline := range cfg.Service.Pipelines {
+		path := "service::pipelines::" + pipelineID.String()
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
 			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers.
@@ -98,14 +125,14 @@ func (cfg *Config) Validate() error {
 			if _, ok := cfg.Connectors[ref]; ok {
 				continue
 			}
-			return fmt.Errorf("service::pipelines::%s: references receiver %q which is not configured", pipelineID.String(), ref)
+			errs = append(errs, &configError{path: path, err: fmt.Errorf("references receiver %q which is not configured", ref)})
 		}
 
 		// Validate pipeline processor name references.
 		for _, ref := range pipeline.Processors {
 			// Check that the name referenced in the pipeline's processors exists in the top-level processors.
 			if cfg.Processors[ref] == nil {
-				return fmt.Errorf("service::pipelines::%s: references processor %q which is not configured", pipelineID.String(), ref)
+				errs = append(errs, &configError{path: path, err: fmt.Errorf("references processor %q which is not configured", ref)})
 			}
 		}
 
@@ -118,8 +145,8 @@ func (cfg *Config) Validate() error {
 			if _, ok := cfg.Connectors[ref]; ok {
 				continue
 			}
-			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID.String(), ref)
+			errs = append(errs, &configError{path: path, err: fmt.Errorf("references exporter %q which is not configured", ref)})
 		}
 	}
-	return nil
+	return errs
 }
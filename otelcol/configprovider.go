@@ -63,14 +63,13 @@ func (cm *ConfigProvider) Get(ctx context.Context, factories Factories) (*Config
 		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
 	}
 
-	return &Config{
-		Receivers:  cfg.Receivers.Configs(),
-		Processors: cfg.Processors.Configs(),
-		Exporters:  cfg.Exporters.Configs(),
-		Connectors: cfg.Connectors.Configs(),
-		Extensions: cfg.Extensions.Configs(),
-		Service:    cfg.Service,
-	}, nil
+	return cfg.toConfig(), nil
+}
+
+// redact returns a copy of the configuration with the values expanded from sensitive references, during the last
+// call to Get, redacted.
+func (cm *ConfigProvider) redact(conf *confmap.Conf) *confmap.Conf {
+	return cm.mapResolver.Redact(conf)
 }
 
 // Watch blocks until any configuration change was detected or an unrecoverable error
@@ -8,6 +8,7 @@ import (
 	"flag"
 	"strings"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -16,8 +17,9 @@ const (
 )
 
 type configFlagValue struct {
-	values []string
-	sets   []string
+	values     []string
+	sets       []string
+	mergeRules []confmap.ListMergeRule
 }
 
 func (s *configFlagValue) Set(val string) error {
@@ -49,6 +51,23 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 			return nil
 		})
 
+	flagSet.Func("merge-strategy",
+		"Set how the lists at the paths matching a pattern are merged when merging the config files, with one of the"+
+			" replace (default), append, append-unique or merge-by-key:<key> strategies. `*` matches a single key, and"+
+			" `**` any number of keys. The first matching flag is used. Example --merge-strategy=service.pipelines.*.processors=append-unique",
+		func(s string) error {
+			idx := strings.LastIndex(s, "=")
+			if idx == -1 {
+				return errors.New("missing equal sign")
+			}
+			rule, err := confmap.ParseListMergeRule(strings.ReplaceAll(s[:idx], ".", "::") + s[idx:])
+			if err != nil {
+				return err
+			}
+			cfgs.mergeRules = append(cfgs.mergeRules, rule)
+			return nil
+		})
+
 	reg.RegisterFlags(flagSet)
 	return flagSet
 }
@@ -57,3 +76,7 @@ func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
 }
+
+func getMergeRulesFlag(flagSet *flag.FlagSet) []confmap.ListMergeRule {
+	return flagSet.Lookup(configFlag).Value.(*configFlagValue).mergeRules
+}
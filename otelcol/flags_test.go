@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -69,3 +70,49 @@ func TestSetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeStrategyFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedRules []confmap.ListMergeRule
+		expectedErr   string
+	}{
+		{
+			name: "rules",
+			args: []string{
+				"--merge-strategy=service.pipelines.*.processors=append-unique",
+				"--merge-strategy=service::extensions=append",
+				"--merge-strategy=receivers.*.targets=merge-by-key:name",
+			},
+			expectedRules: []confmap.ListMergeRule{
+				{Path: "service::pipelines::*::processors", Strategy: confmap.ListMergeAppendUnique},
+				{Path: "service::extensions", Strategy: confmap.ListMergeAppend},
+				{Path: "receivers::*::targets", Strategy: confmap.ListMergeByKey, Key: "name"},
+			},
+		},
+		{
+			name:        "missing equal sign",
+			args:        []string{"--merge-strategy=service.extensions"},
+			expectedErr: `invalid value "service.extensions" for flag -merge-strategy: missing equal sign`,
+		},
+		{
+			name:        "unknown strategy",
+			args:        []string{"--merge-strategy=service.extensions=prepend"},
+			expectedErr: `invalid value "service.extensions=prepend" for flag -merge-strategy: invalid list merge rule "service::extensions=prepend": unknown strategy "prepend"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flgs := flags(featuregate.NewRegistry())
+			err := flgs.Parse(tt.args)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRules, getMergeRulesFlag(flgs))
+		})
+	}
+}
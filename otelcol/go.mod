@@ -7,6 +7,9 @@ require (
 	github.com/stretchr/testify v1.11.1
 	go.opentelemetry.io/collector/component v1.41.0
 	go.opentelemetry.io/collector/component/componentstatus v0.135.0
+	go.opentelemetry.io/collector/config/configopaque v1.41.0
+	go.opentelemetry.io/collector/config/configoptional v0.135.0
+	go.opentelemetry.io/collector/config/configretry v1.41.0
 	go.opentelemetry.io/collector/config/configtelemetry v0.135.0
 	go.opentelemetry.io/collector/confmap v1.41.0
 	go.opentelemetry.io/collector/confmap/provider/fileprovider v1.41.0
@@ -215,6 +218,8 @@ replace go.opentelemetry.io/collector/extension/extensionauth/extensionauthtest
 
 replace go.opentelemetry.io/collector/extension/xextension => ../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/confmap/provider/fileprovider => ../confmap/provider/fileprovider
 
 replace go.opentelemetry.io/collector/confmap/provider/yamlprovider => ../confmap/provider/yamlprovider
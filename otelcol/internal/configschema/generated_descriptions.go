@@ -0,0 +1,249 @@
+// Code generated by gendescriptions. DO NOT EDIT.
+
+package configschema
+
+// descriptions are the doc comments of the config structs, and of their fields, by package path and name.
+var descriptions = map[string]string{
+	"go.opentelemetry.io/collector/component.ID":                                                                           "ID represents the identity for a component. It combines two values: * type - the Type of the component. * name - the name of that component. The component ID (combination type + name) is unique for a given component.Kind.",
+	"go.opentelemetry.io/collector/config/configauth.Config":                                                               "Config defines the auth settings for the receiver.",
+	"go.opentelemetry.io/collector/config/configauth.Config.AuthenticatorID":                                               "AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig":                                                         "ClientConfig defines common settings for a gRPC client configuration.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Auth":                                                    "Auth configuration for outgoing RPCs.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Authority":                                               "WithAuthority parameter configures client to rewrite \":authority\" header (godoc.org/google.golang.org/grpc#WithAuthority)",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.BalancerName":                                            "Sets the balancer in grpclb_policy to discover the servers. Default is pick_first. https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Compression":                                             "The compression key for supported compression types within collector.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Endpoint":                                                "The target to which the exporter is going to send traces or metrics, using the gRPC protocol. The valid syntax is described at https://github.com/grpc/grpc/blob/master/doc/naming.md.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Headers":                                                 "The headers associated with gRPC requests.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Keepalive":                                               "The keepalive parameters for gRPC client. See grpc.WithKeepaliveParams. (https://godoc.org/google.golang.org/grpc#WithKeepaliveParams).",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.Middlewares":                                             "Middlewares for the gRPC client.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.ReadBufferSize":                                          "ReadBufferSize for gRPC client. See grpc.WithReadBufferSize. (https://godoc.org/google.golang.org/grpc#WithReadBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.TLS":                                                     "TLS struct exposes TLS client configuration.",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.WaitForReady":                                            "WaitForReady parameter configures client to wait for ready state before sending data. (https://github.com/grpc/grpc/blob/master/doc/wait-for-ready.md)",
+	"go.opentelemetry.io/collector/config/configgrpc.ClientConfig.WriteBufferSize":                                         "WriteBufferSize for gRPC gRPC. See grpc.WithWriteBufferSize. (https://godoc.org/google.golang.org/grpc#WithWriteBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.KeepaliveClientConfig":                                                "KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter. Refer to the original data-structure for the meaning of each parameter: https://godoc.org/google.golang.org/grpc/keepalive#ClientParameters",
+	"go.opentelemetry.io/collector/config/configgrpc.KeepaliveEnforcementPolicy":                                           "KeepaliveEnforcementPolicy allow configuration of the keepalive.EnforcementPolicy. The same default values as keepalive.EnforcementPolicy are applicable and get applied by the server. See https://godoc.org/google.golang.org/grpc/keepalive#EnforcementPolicy for details.",
+	"go.opentelemetry.io/collector/config/configgrpc.KeepaliveServerConfig":                                                "KeepaliveServerConfig is the configuration for keepalive.",
+	"go.opentelemetry.io/collector/config/configgrpc.KeepaliveServerParameters":                                            "KeepaliveServerParameters allow configuration of the keepalive.ServerParameters. The same default values as keepalive.ServerParameters are applicable and get applied by the server. See https://godoc.org/google.golang.org/grpc/keepalive#ServerParameters for details.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig":                                                         "ServerConfig defines common settings for a gRPC server configuration.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.Auth":                                                    "Auth for this receiver",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.IncludeMetadata":                                         "Include propagates the incoming connection's metadata to downstream consumers.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.Keepalive":                                               "Keepalive anchor for all the settings related to keepalive.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.MaxConcurrentStreams":                                    "MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport. It has effect only for streaming RPCs.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.MaxRecvMsgSizeMiB":                                       "MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.Middlewares":                                             "Middlewares for the gRPC server.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.ReadBufferSize":                                          "ReadBufferSize for gRPC server. See grpc.ReadBufferSize. (https://godoc.org/google.golang.org/grpc#ReadBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.TLS":                                                     "Configures the protocol to use TLS. The default value is nil, which will cause the protocol to not use TLS.",
+	"go.opentelemetry.io/collector/config/configgrpc.ServerConfig.WriteBufferSize":                                         "WriteBufferSize for gRPC server. See grpc.WriteBufferSize. (https://godoc.org/google.golang.org/grpc#WriteBufferSize).",
+	"go.opentelemetry.io/collector/config/confighttp.AuthConfig.RequestParameters":                                         "RequestParameters is a list of parameters that should be extracted from the request and added to the context. When a parameter is found in both the query string and the header, the value from the query string will be used.",
+	"go.opentelemetry.io/collector/config/confighttp.CORSConfig":                                                           "CORSConfig configures a receiver for HTTP cross-origin resource sharing (CORS). See the underlying https://github.com/rs/cors package for details.",
+	"go.opentelemetry.io/collector/config/confighttp.CORSConfig.AllowedHeaders":                                            "AllowedHeaders sets what headers will be allowed in CORS requests. The Accept, Accept-Language, Content-Type, and Content-Language headers are implicitly allowed. If no headers are listed, X-Requested-With will also be accepted by default. Include \"*\" to allow any request header.",
+	"go.opentelemetry.io/collector/config/confighttp.CORSConfig.AllowedOrigins":                                            "AllowedOrigins sets the allowed values of the Origin header for HTTP/JSON requests to an OTLP receiver. An origin may contain a wildcard (*) to replace 0 or more characters (e.g., \"http://*.domain.com\", or \"*\" to allow any origin).",
+	"go.opentelemetry.io/collector/config/confighttp.CORSConfig.MaxAge":                                                    "MaxAge sets the value of the Access-Control-Max-Age response header. Set it to the number of seconds that browsers should cache a CORS preflight response for.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig":                                                         "ClientConfig defines settings for creating an HTTP client.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Auth":                                                    "Auth configuration for outgoing HTTP calls.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Compression":                                             "The compression key for supported compression types within collector.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.CompressionParams":                                       "Advanced configuration options for the Compression",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Cookies":                                                 "Cookies configures the cookie management of the HTTP client.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.DisableKeepAlives":                                       "DisableKeepAlives, if true, disables HTTP keep-alives and will only use the connection to the server for a single HTTP request. WARNING: enabling this option can result in significant overhead establishing a new HTTP(S) connection for every request. Before enabling this option please consider whether changes to idle connection settings can achieve your goal.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Endpoint":                                                "The target URL to send data to (e.g.: http://some.url:9411/v1/traces).",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.ForceAttemptHTTP2":                                       "Enabling ForceAttemptHTTP2 forces the HTTP transport to use the HTTP/2 protocol. By default, this is set to true. NOTE: HTTP/2 does not support settings such as MaxConnsPerHost, MaxIdleConnsPerHost and MaxIdleConns.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.HTTP2PingTimeout":                                        "HTTP2PingTimeout if there's no response to the ping within the configured value, the connection will be closed. If not set or set to 0, it defaults to 15s.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.HTTP2ReadIdleTimeout":                                    "This is needed in case you run into https://github.com/golang/go/issues/59690 https://github.com/golang/go/issues/36026 HTTP2ReadIdleTimeout if the connection has been idle for the configured value send a ping frame for health check 0s means no health check will be performed.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Headers":                                                 "Additional headers attached to each HTTP request sent by the client. Existing header values are overwritten if collision happens. Header values are opaque since they may be sensitive.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.IdleConnTimeout":                                         "IdleConnTimeout is the maximum amount of time a connection will remain open before closing itself. By default, it is set to 90 seconds.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.MaxConnsPerHost":                                         "MaxConnsPerHost limits the total number of connections per host, including connections in the dialing, active, and idle states. Default is 0 (unlimited).",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.MaxIdleConns":                                            "MaxIdleConns is used to set a limit to the maximum idle HTTP connections the client can keep open. By default, it is set to 100. Zero means no limit.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.MaxIdleConnsPerHost":                                     "MaxIdleConnsPerHost is used to set a limit to the maximum idle HTTP connections the host can keep open. Default is 0 (unlimited).",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Middlewares":                                             "Middlewares are used to add custom functionality to the HTTP client. Middleware handlers are called in the order they appear in this list, with the first middleware becoming the outermost handler.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.ProxyURL":                                                "ProxyURL setting for the collector",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.ReadBufferSize":                                          "ReadBufferSize for HTTP client. See http.Transport.ReadBufferSize. Default is 0.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.TLS":                                                     "TLS struct exposes TLS client configuration.",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.Timeout":                                                 "Timeout parameter configures `http.Client.Timeout`. Default is 0 (unlimited).",
+	"go.opentelemetry.io/collector/config/confighttp.ClientConfig.WriteBufferSize":                                         "WriteBufferSize for HTTP client. See http.Transport.WriteBufferSize. Default is 0.",
+	"go.opentelemetry.io/collector/config/confighttp.CookiesConfig":                                                        "CookiesConfig defines the configuration of the HTTP client regarding cookies served by the server.",
+	"go.opentelemetry.io/collector/config/confighttp.CookiesConfig.Enabled":                                                "Enabled if true, cookies from HTTP responses will be reused in further HTTP requests with the same server.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig":                                                         "ServerConfig defines settings for creating an HTTP server.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.Auth":                                                    "Auth for this receiver",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.CORS":                                                    "CORS configures the server for HTTP cross-origin resource sharing (CORS).",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.CompressionAlgorithms":                                   "CompressionAlgorithms configures the list of compression algorithms the server can accept. Default: [\"\", \"gzip\", \"zstd\", \"zlib\", \"snappy\", \"deflate\"]",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.Endpoint":                                                "Endpoint configures the listening address for the server.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.IdleTimeout":                                             "IdleTimeout is the maximum amount of time to wait for the next request when keep-alives are enabled. If IdleTimeout is zero, the value of ReadTimeout is used. If both are zero, there is no timeout.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.IncludeMetadata":                                         "IncludeMetadata propagates the client metadata from the incoming requests to the downstream consumers",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.MaxRequestBodySize":                                      "MaxRequestBodySize sets the maximum request body size in bytes. Default: 20MiB.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.Middlewares":                                             "Middlewares are used to add custom functionality to the HTTP server. Middleware handlers are called in the order they appear in this list, with the first middleware becoming the outermost handler.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.ReadHeaderTimeout":                                       "ReadHeaderTimeout is the amount of time allowed to read request headers. The connection's read deadline is reset after reading the headers and the Handler can decide what is considered too slow for the body. If ReadHeaderTimeout is zero, the value of ReadTimeout is used. If both are zero, there is no timeout.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.ReadTimeout":                                             "ReadTimeout is the maximum duration for reading the entire request, including the body. A zero or negative value means there will be no timeout. Because ReadTimeout does not let Handlers make per-request decisions on each request body's acceptable deadline or upload rate, most users will prefer to use ReadHeaderTimeout. It is valid to use them both.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.ResponseHeaders":                                         "Additional headers attached to each HTTP response sent to the client. Header values are opaque since they may be sensitive.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.TLS":                                                     "TLS struct exposes TLS client configuration.",
+	"go.opentelemetry.io/collector/config/confighttp.ServerConfig.WriteTimeout":                                            "WriteTimeout is the maximum duration before timing out writes of the response. It is reset whenever a new request's header is read. Like ReadTimeout, it does not let Handlers make decisions on a per-request basis. A zero or negative value means there will be no timeout.",
+	"go.opentelemetry.io/collector/config/configmiddleware.Config":                                                         "Middleware defines the extension ID for a middleware component.",
+	"go.opentelemetry.io/collector/config/configmiddleware.Config.ID":                                                      "ID specifies the name of the extension to use.",
+	"go.opentelemetry.io/collector/config/confignet.AddrConfig":                                                            "AddrConfig represents a network endpoint address.",
+	"go.opentelemetry.io/collector/config/confignet.AddrConfig.DialerConfig":                                               "DialerConfig contains options for connecting to an address.",
+	"go.opentelemetry.io/collector/config/confignet.AddrConfig.Endpoint":                                                   "Endpoint configures the address for this network connection. For TCP and UDP networks, the address has the form \"host:port\". The host must be a literal IP address, or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name. If the host is a literal IPv6 address it must be enclosed in square brackets, as in \"[2001:db8::1]:80\" or \"[fe80::1%zone]:80\". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.",
+	"go.opentelemetry.io/collector/config/confignet.AddrConfig.Transport":                                                  "Transport to use. Allowed protocols are \"tcp\", \"tcp4\" (IPv4-only), \"tcp6\" (IPv6-only), \"udp\", \"udp4\" (IPv4-only), \"udp6\" (IPv6-only), \"ip\", \"ip4\" (IPv4-only), \"ip6\" (IPv6-only), \"unix\", \"unixgram\" and \"unixpacket\".",
+	"go.opentelemetry.io/collector/config/confignet.DialerConfig":                                                          "DialerConfig contains options for connecting to an address.",
+	"go.opentelemetry.io/collector/config/confignet.DialerConfig.Timeout":                                                  "Timeout is the maximum amount of time a dial will wait for a connect to complete. The default is no timeout.",
+	"go.opentelemetry.io/collector/config/confignet.TCPAddrConfig":                                                         "TCPAddrConfig represents a TCP endpoint address.",
+	"go.opentelemetry.io/collector/config/confignet.TCPAddrConfig.DialerConfig":                                            "DialerConfig contains options for connecting to an address.",
+	"go.opentelemetry.io/collector/config/confignet.TCPAddrConfig.Endpoint":                                                "Endpoint configures the address for this network connection. The address has the form \"host:port\". The host must be a literal IP address, or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name. If the host is a literal IPv6 address it must be enclosed in square brackets, as in \"[2001:db8::1]:80\" or \"[fe80::1%zone]:80\". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig":                                                       "BackOffConfig defines configuration for retrying batches in case of export failure. The current supported strategy is exponential backoff.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.Enabled":                                               "Enabled indicates whether to not retry sending batches in case of export failure.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.InitialInterval":                                       "InitialInterval the time to wait after the first failure before retrying.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.MaxElapsedTime":                                        "MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch. Once this value is reached, the data is discarded. If set to 0, the retries are never stopped.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.MaxInterval":                                           "MaxInterval is the upper bound on backoff interval. Once this value is reached the delay between consecutive retries will always be `MaxInterval`.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.MaxThrottleDelay":                                      "MaxThrottleDelay is the upper bound on the delay before retrying requested by the destination, for example using the gRPC `RetryInfo` or the HTTP `Retry-After` header. Longer requested delays are reduced to this value. If set to 0, the requested delay is never reduced.",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.Multiplier":                                            "Multiplier is the value multiplied by the backoff interval bounds",
+	"go.opentelemetry.io/collector/config/configretry.BackOffConfig.RandomizationFactor":                                   "RandomizationFactor is a random factor used to calculate next backoffs Randomized interval = RetryInterval * (1 ± RandomizationFactor)",
+	"go.opentelemetry.io/collector/config/configtls.ClientConfig":                                                          "ClientConfig contains TLS configurations that are specific to client connections in addition to the common configurations. This should be used by components configuring TLS client connections.",
+	"go.opentelemetry.io/collector/config/configtls.ClientConfig.Insecure":                                                 "In gRPC and HTTP when set to true, this is used to disable the client transport security. See https://godoc.org/google.golang.org/grpc#WithInsecure for gRPC. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional, default false)",
+	"go.opentelemetry.io/collector/config/configtls.ClientConfig.InsecureSkipVerify":                                       "InsecureSkipVerify will enable TLS but not verify the certificate.",
+	"go.opentelemetry.io/collector/config/configtls.ClientConfig.ServerName":                                               "ServerName requested by client for virtual hosting. This sets the ServerName in the TLSConfig. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config":                                                                "Config exposes the common client and server TLS configurations. Note: Since there isn't anything specific to a server connection. Components with server connections should use Config.",
+	"go.opentelemetry.io/collector/config/configtls.Config.CAFile":                                                         "Path to the CA cert. For a client this verifies the server certificate. For a server this verifies client certificates. If empty uses system root CA. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.CAPem":                                                          "In memory PEM encoded cert. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.CertFile":                                                       "Path to the TLS cert to use for TLS required connections. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.CertPem":                                                        "In memory PEM encoded TLS cert to use for TLS required connections. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.CipherSuites":                                                   "CipherSuites is a list of TLS cipher suites that the TLS transport can use. If left blank, a safe default list is used. See https://go.dev/src/crypto/tls/cipher_suites.go for a list of supported cipher suites.",
+	"go.opentelemetry.io/collector/config/configtls.Config.CurvePreferences":                                               "contains the elliptic curves that will be used in an ECDHE handshake, in preference order Defaults to empty list and \"crypto/tls\" defaults are used, internally.",
+	"go.opentelemetry.io/collector/config/configtls.Config.IncludeSystemCACertsPool":                                       "If true, load system CA certificates pool in addition to the certificates configured in this struct.",
+	"go.opentelemetry.io/collector/config/configtls.Config.KeyFile":                                                        "Path to the TLS key to use for TLS required connections. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.KeyPem":                                                         "In memory PEM encoded TLS key to use for TLS required connections. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.MaxVersion":                                                     "MaxVersion sets the maximum TLS version that is acceptable. If not set, refer to crypto/tls for defaults. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.MinVersion":                                                     "MinVersion sets the minimum TLS version that is acceptable. If not set, TLS 1.2 will be used. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.ReloadInterval":                                                 "ReloadInterval specifies the duration after which the certificate will be reloaded If not set, it will never be reloaded (optional)",
+	"go.opentelemetry.io/collector/config/configtls.Config.TPMConfig":                                                      "Trusted platform module configuration",
+	"go.opentelemetry.io/collector/config/configtls.ServerConfig":                                                          "ServerConfig contains TLS configurations that are specific to server connections in addition to the common configurations. This should be used by components configuring TLS server connections.",
+	"go.opentelemetry.io/collector/config/configtls.ServerConfig.ClientCAFile":                                             "Path to the TLS cert to use by the server to verify a client certificate. (optional) This sets the ClientCAs and ClientAuth to RequireAndVerifyClientCert in the TLSConfig. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.ServerConfig.ReloadClientCAFile":                                       "Reload the ClientCAs file when it is modified (optional, default false)",
+	"go.opentelemetry.io/collector/config/configtls.TPMConfig":                                                             "TPMConfig defines trusted platform module configuration for storing TLS keys.",
+	"go.opentelemetry.io/collector/config/configtls.TPMConfig.Path":                                                        "The path to the TPM device or Unix domain socket. For instance /dev/tpm0 or /dev/tpmrm0.",
+	"go.opentelemetry.io/collector/exporter/debugexporter.Config":                                                          "Config defines configuration for debug exporter.",
+	"go.opentelemetry.io/collector/exporter/debugexporter.Config.SamplingInitial":                                          "SamplingInitial defines how many samples are initially logged during each second.",
+	"go.opentelemetry.io/collector/exporter/debugexporter.Config.SamplingThereafter":                                       "SamplingThereafter defines the sampling rate after the initial samples are logged.",
+	"go.opentelemetry.io/collector/exporter/debugexporter.Config.UseInternalLogger":                                        "UseInternalLogger defines whether the exporter sends the output to the collector's internal logger.",
+	"go.opentelemetry.io/collector/exporter/debugexporter.Config.Verbosity":                                                "Verbosity defines the debug exporter verbosity.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig":                                  "CircuitBreakerConfig defines configuration for the circuit breaker and the retry budget of an exporter.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig.Enabled":                          "Enabled indicates whether to use the circuit breaker or not.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig.FailureThreshold":                 "FailureThreshold is the ratio of failed attempts to send data, in the current interval, above which the circuit breaker opens.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig.Interval":                         "Interval is the duration after which the counts of attempts and failures are cleared while closed.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig.MinimumRequests":                  "MinimumRequests is the minimum number of attempts to send data, in the current interval, before the failure threshold is evaluated.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig.OpenDuration":                     "OpenDuration is the duration the circuit breaker stays open, failing every attempt to send data, before allowing a single attempt to probe if the destination recovered.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.CircuitBreakerConfig.RetryBudget":                      "RetryBudget is the maximum ratio of retries to successful attempts to send data. Every successful attempt adds RetryBudget to the budget, and every retry consumes 1. A zero value disables the retry budget.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.TimeoutConfig":                                         "TimeoutConfig for timeout. The timeout applies to individual attempts to send data to the backend.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal.TimeoutConfig.Timeout":                                 "Timeout is the timeout for every attempt to send data to the backend. A zero timeout means no timeout.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.AdaptiveConcurrencyConfig":                  "AdaptiveConcurrencyConfig defines a configuration for the adaptive concurrency control of the export calls. The limit is adjusted using an additive increase/multiplicative decrease (AIMD) algorithm.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.AdaptiveConcurrencyConfig.DecreaseRatio":    "DecreaseRatio is the ratio by which the limit is multiplied on failed or slow export calls.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.AdaptiveConcurrencyConfig.LatencyThreshold": "LatencyThreshold is the duration after which a successful export call is considered to be a sign of an overloaded backend and decreases the limit. A zero value means that latency is not taken into account.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.AdaptiveConcurrencyConfig.MinConcurrency":   "MinConcurrency is the lower bound and the initial value of the concurrency limit.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.BatchConfig":                                "BatchConfig defines a configuration for batching requests based on a timeout and a minimum number of items.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.BatchConfig.FlushTimeout":                   "FlushTimeout sets the time after which a batch will be sent regardless of its size.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.BatchConfig.MaxSize":                        "MaxSize defines the configuration for the maximum size of a batch.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.BatchConfig.MinSize":                        "MinSize defines the configuration for the minimum size of a batch.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.BatchConfig.Sizer":                          "Sizer determines the type of size measurement used by the batch. If not configured, use the same configuration as the queue. It accepts \"requests\", \"items\", or \"bytes\".",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config":                                     "Config defines configuration for queueing and batching incoming requests.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.AdaptiveConcurrency":                 "AdaptiveConcurrency if set, enables adjusting the number of concurrent export calls between `min_concurrency` and NumConsumers, based on the observed latency and errors of the export calls.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.Batch":                               "BatchConfig it configures how the requests are consumed from the queue and batch together during consumption.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.BlockOnOverflow":                     "BlockOnOverflow determines the behavior when the component's TotalSize limit is reached. If true, the component will wait for space; otherwise, operations will immediately return a retryable error.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.DeadLetter":                          "DeadLetter if set, enables writing requests that permanently failed to be exported, or exhausted the retries, to a storage extension instead of dropping them.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.Enabled":                             "Enabled indicates whether to not enqueue and batch before exporting.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.MaxStorageSize":                      "MaxStorageSize if positive, limits the size in bytes of the serialized requests stored by the persistent queue, in addition to the QueueSize. Requests are rejected, or blocked if BlockOnOverflow is set, when the limit is reached. Only available when persistent queue is configured using the storage configuration.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.NumConsumers":                        "NumConsumers is the maximum number of concurrent consumers from the queue. This applies across all different optional configurations from above (e.g. wait_for_result, block_on_overflow, storage, etc.).",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.Priority":                            "Priority if set, enables priority lanes in the queue. Requests are read from the highest priority lane first, and when the queue is full the lowest priority requests are dropped first. Currently, this option is not available when persistent queue is configured using the storage configuration.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.QueueSize":                           "QueueSize represents the maximum data size allowed for concurrent storage and processing.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.Sizer":                               "Sizer determines the type of size measurement used by this component. It accepts \"requests\", \"items\", or \"bytes\".",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.StorageID":                           "StorageID if not empty, enables the persistent storage and uses the component specified as a storage extension for the persistent queue. TODO: This will be changed to Optional when available. See https://github.com/open-telemetry/opentelemetry-collector/issues/13822",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.Config.WaitForResult":                       "WaitForResult determines if incoming requests are blocked until the request is processed or not. When persistent queue is configured using the storage configuration, requests are written to the storage before waiting for the result, and are released without error if the queue shuts down before processing them.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.DeadLetterConfig":                           "DeadLetterConfig defines a configuration for storing requests that failed to be exported.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.DeadLetterConfig.ReplayOnStart":             "ReplayOnStart determines if the requests stored in the dead letter queue are sent again to the sending queue when the exporter starts. Requests that are successfully sent to the queue are removed from the dead letter queue.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.DeadLetterConfig.StorageID":                 "StorageID is the component ID of the storage extension used to store the failed requests.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.PriorityConfig":                             "PriorityConfig defines a configuration for the priority lanes of the queue.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.PriorityConfig.Classes":                     "Classes lists the names of the priority classes, ordered from the highest to the lowest priority.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.PriorityConfig.Default":                     "Default is the class assigned to requests for which the class is unknown or cannot be determined. If not configured, the lowest priority class is used.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch.PriorityConfig.MetadataKey":                 "MetadataKey is the client.Metadata key used to determine the priority class of a request. If not configured, the priority class is determined by the exporter, if supported.",
+	"go.opentelemetry.io/collector/exporter/otlpexporter.Config":                                                           "Config defines configuration for OTLP exporter.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config":                                                       "Config defines configuration for OTLP/HTTP exporter.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.Encoding":                                              "The encoding to export telemetry (default: \"proto\")",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.LogsEndpoint":                                          "The URL to send logs to. If omitted the Endpoint + \"/v1/logs\" will be used.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.MetricsEndpoint":                                       "The URL to send metrics to. If omitted the Endpoint + \"/v1/metrics\" will be used.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.ProfilesEndpoint":                                      "The URL to send profiles to. If omitted the Endpoint + \"/v1development/profiles\" will be used.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.TracesEndpoint":                                        "The URL to send traces to. If omitted the Endpoint + \"/v1/traces\" will be used.",
+	"go.opentelemetry.io/collector/extension/healthextension.Config":                                                       "Config has the configuration of the health extension.",
+	"go.opentelemetry.io/collector/extension/healthextension.Config.GRPC":                                                  "GRPC is the configuration of the gRPC server implementing the gRPC health checking protocol.",
+	"go.opentelemetry.io/collector/extension/healthextension.Config.HTTP":                                                  "HTTP is the configuration of the HTTP server serving the liveness, readiness and status endpoints.",
+	"go.opentelemetry.io/collector/extension/healthextension.Config.Liveness":                                              "Liveness defines which component errors make the collector not alive.",
+	"go.opentelemetry.io/collector/extension/healthextension.Config.Readiness":                                             "Readiness defines which component errors make the collector not ready.",
+	"go.opentelemetry.io/collector/extension/healthextension.ProbeConfig":                                                  "ProbeConfig defines which component errors make a probe fail. A fatal error always makes it fail.",
+	"go.opentelemetry.io/collector/extension/healthextension.ProbeConfig.IncludePermanentErrors":                           "IncludePermanentErrors makes the probe fail when a component reports a permanent error.",
+	"go.opentelemetry.io/collector/extension/healthextension.ProbeConfig.RecoveryDuration":                                 "RecoveryDuration is how long a component can report a recoverable error before the probe fails.",
+	"go.opentelemetry.io/collector/extension/zpagesextension.Config":                                                       "Config has the configuration for the extension enabling the zPages extension.",
+	"go.opentelemetry.io/collector/extension/zpagesextension.ExpvarConfig":                                                 "ExpvarConfig has the configuration for the expvar service.",
+	"go.opentelemetry.io/collector/extension/zpagesextension.ExpvarConfig.Enabled":                                         "Enabled indicates whether to enable expvar service. (default = false)",
+	"go.opentelemetry.io/collector/filter.Config":                                                                          "Config configures the matching behavior of a Filter. Exactly one of Strict, Regex, Glob, Prefix or Suffix must be set.",
+	"go.opentelemetry.io/collector/filter.Config.CaseInsensitive":                                                          "CaseInsensitive makes the matching ignore the case of the letters.",
+	"go.opentelemetry.io/collector/filter.Config.Glob":                                                                     "Glob matches strings matching the given pattern, where `*` matches any sequence of characters, `?` matches any single character, and `[...]` matches any character in the set (or not in the set, if it starts with `!`). Special characters can be escaped with `\\`.",
+	"go.opentelemetry.io/collector/filter.Config.Prefix":                                                                   "Prefix matches strings starting with the given string.",
+	"go.opentelemetry.io/collector/filter.Config.Regex":                                                                    "Regex matches strings containing a match of the given regular expression.",
+	"go.opentelemetry.io/collector/filter.Config.Strict":                                                                   "Strict matches values equal to the given string.",
+	"go.opentelemetry.io/collector/filter.Config.Suffix":                                                                   "Suffix matches strings ending with the given string.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config":                                                          "Config defines configuration for memory memoryLimiter processor.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.CheckInterval":                                            "CheckInterval is the time between measurements of memory usage for the purposes of avoiding going over the limits. Defaults to zero, so no checks will be performed.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.MemoryLimitMiB":                                           "MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be allocated by the process.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.MemoryLimitPercentage":                                    "MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.MemorySpikeLimitMiB":                                      "MemorySpikeLimitMiB is the maximum, in MiB, spike expected between the measurements of memory usage.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.MemorySpikePercentage":                                    "MemorySpikePercentage is the maximum, in percents against the total memory, spike expected between the measurements of memory usage.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.MinGCIntervalWhenHardLimited":                             "MinGCIntervalWhenHardLimited minimum interval between forced GC when in hard (=limit_mib) limited mode. Zero value means no minimum interval. GCs is a CPU-heavy operation and executing it too frequently may affect the recovery capabilities of the collector.",
+	"go.opentelemetry.io/collector/internal/memorylimiter.Config.MinGCIntervalWhenSoftLimited":                             "MinGCIntervalWhenSoftLimited minimum interval between forced GC when in soft (=limit_mib - spike_limit_mib) limited mode. Zero value means no minimum interval. GCs is a CPU-heavy operation and executing it too frequently may affect the recovery capabilities of the collector.",
+	"go.opentelemetry.io/collector/otelcol.Config":                                                                         "Config defines the configuration for the various elements of collector or agent.",
+	"go.opentelemetry.io/collector/otelcol.Config.Connectors":                                                              "Connectors is a map of ComponentID to connectors.",
+	"go.opentelemetry.io/collector/otelcol.Config.Exporters":                                                               "Exporters is a map of ComponentID to Exporters.",
+	"go.opentelemetry.io/collector/otelcol.Config.Extensions":                                                              "Extensions is a map of ComponentID to extensions.",
+	"go.opentelemetry.io/collector/otelcol.Config.Processors":                                                              "Processors is a map of ComponentID to Processors.",
+	"go.opentelemetry.io/collector/otelcol.Config.Receivers":                                                               "Receivers is a map of ComponentID to Receivers.",
+	"go.opentelemetry.io/collector/pipeline.ID":                                                                            "ID represents the identity for a pipeline. It combines two values: * signal - the Signal of the pipeline. * name - the name of that pipeline.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config":                                                        "Config defines configuration for batch processor.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.MetadataCardinalityLimit":                               "MetadataCardinalityLimit indicates the maximum number of batcher instances that will be created through a distinct combination of MetadataKeys.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.MetadataKeys":                                           "MetadataKeys is a list of client.Metadata keys that will be used to form distinct batchers. If this setting is empty, a single batcher instance will be used. When this setting is not empty, one batcher will be used per distinct combination of values for the listed metadata keys. Empty value and unset metadata are treated as distinct cases. Entries are case-insensitive. Duplicated entries will trigger a validation error.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.SendBatchMaxSize":                                       "SendBatchMaxSize is the maximum size of a batch. It must be larger than SendBatchSize. Larger batches are split into smaller units. Default value is 0, that means no maximum size.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.SendBatchSize":                                          "SendBatchSize is the size of a batch which after hit, will trigger it to be sent. When this is set to zero, the batch size is ignored and data will be sent immediately subject to only send_batch_max_size.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.Timeout":                                                "Timeout sets the time after which a batch will be sent regardless of size. When this is set to zero, batched data will be sent immediately.",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.Config":                                                           "Config defines configuration for OTLP receiver.",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.Config.Protocols":                                                 "Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.HTTPConfig.LogsURLPath":                                           "The URL path to receive logs on. If omitted \"/v1/logs\" will be used.",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.HTTPConfig.MetricsURLPath":                                        "The URL path to receive metrics on. If omitted \"/v1/metrics\" will be used.",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.HTTPConfig.TracesURLPath":                                         "The URL path to receive traces on. If omitted \"/v1/traces\" will be used.",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.Protocols":                                                        "Protocols is the configuration for the supported protocols.",
+	"go.opentelemetry.io/collector/scraper/scraperhelper.ControllerConfig":                                                 "ControllerConfig defines common settings for a scraper controller configuration. Scraper controller receivers can embed this struct, instead of receiver.Settings, and extend it with more fields if needed.",
+	"go.opentelemetry.io/collector/scraper/scraperhelper.ControllerConfig.CollectionInterval":                              "CollectionInterval sets how frequently the scraper should be called and used as the context timeout to ensure that scrapers don't exceed the interval.",
+	"go.opentelemetry.io/collector/scraper/scraperhelper.ControllerConfig.InitialDelay":                                    "InitialDelay sets the initial start delay for the scraper, any non positive value is assumed to be immediately.",
+	"go.opentelemetry.io/collector/scraper/scraperhelper.ControllerConfig.Timeout":                                         "Timeout is an optional value used to set scraper's context deadline.",
+	"go.opentelemetry.io/collector/service.Config":                                                                         "Config defines the configurable components of the Service.",
+	"go.opentelemetry.io/collector/service.Config.Extensions":                                                              "Extensions are the ordered list of extensions configured for the service.",
+	"go.opentelemetry.io/collector/service.Config.Pipelines":                                                               "Pipelines are the set of data pipelines configured for the service.",
+	"go.opentelemetry.io/collector/service.Config.Shutdown":                                                                "Shutdown defines how the pipelines are drained when they are shut down.",
+	"go.opentelemetry.io/collector/service.Config.Telemetry":                                                               "Telemetry is the configuration for collector's own telemetry.",
+	"go.opentelemetry.io/collector/service/pipelines.PipelineConfig":                                                       "PipelineConfig defines the configuration of a Pipeline.",
+	"go.opentelemetry.io/collector/service/shutdown.Config":                                                                "Config defines how the pipelines are shut down.",
+	"go.opentelemetry.io/collector/service/shutdown.Config.DrainTimeout":                                                   "DrainTimeout is the maximum duration to wait for the exporters to drain their queues, once their upstream components are shut down, and before they are shut down. The whole drain phase shares this timeout. Zero disables the drain phase.",
+	"go.opentelemetry.io/collector/service/shutdown.Config.Queue":                                                          "Queue defines what the exporters do with the data left in their memory queue when drained: \"flush\" exports it, \"spill\" writes it to persistent storage to be exported after the next start, which the exporters must support.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.Development":                        "Development puts the logger in development mode, which changes the behavior of DPanicLevel and takes stacktraces more liberally. (default = false)",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.DisableCaller":                      "DisableCaller stops annotating logs with the calling function's file name and line number. By default, all logs are annotated. (default = false)",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.DisableStacktrace":                  "DisableStacktrace completely disables automatic stacktrace capturing. By default, stacktraces are captured for WarnLevel and above logs in development and ErrorLevel and above in production. (default = false)",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.Encoding":                           "Encoding sets the logger's encoding. Example values are \"json\", \"console\".",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.ErrorOutputPaths":                   "ErrorOutputPaths is a list of URLs or file paths to write zap internal logger errors to. The URLs could only be with \"file\" schema or without schema. The URLs with \"file\" schema must use absolute paths. The URLs without schema are treated as local file paths. \"stdout\" and \"stderr\" are interpreted as os.Stdout and os.Stderr. see details at Open in zap/writer.go. Note that this setting only affects the zap internal logger errors. (default = [\"stderr\"])",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.InitialFields":                      "InitialFields is a collection of fields to add to the root logger. Example: initial_fields: foo: \"bar\" By default, there is no initial field.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.Level":                              "Level is the minimum enabled logging level. (default = \"INFO\")",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.OutputPaths":                        "OutputPaths is a list of URLs or file paths to write logging output to. The URLs could only be with \"file\" schema or without schema. The URLs with \"file\" schema must be an absolute path. The URLs without schema are treated as local file paths. \"stdout\" and \"stderr\" are interpreted as os.Stdout and os.Stderr. see details at Open in zap/writer.go. (default = [\"stderr\"])",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.Processors":                         "Processors allow configuration of log record processors to emit logs to any number of supported backends.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsConfigV030.Sampling":                           "Sampling sets a sampling policy. Default: sampling: enabled: true tick: 10s initial: 10 thereafter: 100 Sampling can be disabled by setting 'enabled' to false",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsSamplingConfig":                                "LogsSamplingConfig sets a sampling strategy for the logger. Sampling caps the global CPU and I/O load that logging puts on your process while attempting to preserve a representative subset of your logs.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsSamplingConfig.Enabled":                        "Enabled enable sampling logging",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsSamplingConfig.Initial":                        "Initial represents the first M messages logged each Tick.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsSamplingConfig.Thereafter":                     "Thereafter represents the sampling rate, every Nth message will be sampled after Initial messages are logged during each Tick. If Thereafter is zero, the logger will drop all the messages after the Initial each Tick.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.LogsSamplingConfig.Tick":                           "Tick represents the interval in seconds that the logger apply each sampling.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.MetricsConfigV030.Level":                           "Level is the level of telemetry metrics, the possible values are: - \"none\" indicates that no telemetry data should be collected; - \"basic\" is the recommended and covers the basics of the service telemetry. - \"normal\" adds some other indicators on top of basic. - \"detailed\" adds dimensions and views to the previous levels.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.TracesConfigV030.Level":                            "Level configures whether spans are emitted or not, the possible values are: - \"none\" indicates that no tracing data should be collected; - \"basic\" is the recommended and covers the basics of the service telemetry.",
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration.TracesConfigV030.Propagators":                      "Propagators is a list of TextMapPropagators from the supported propagators list. Currently, tracecontext and b3 are supported. By default, the value is set to empty list and context propagation is disabled.",
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry.Config":                                             "Config defines the configurable settings for service telemetry.",
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry.Config.Resource":                                    "Resource specifies user-defined attributes to include with all emitted telemetry. Note that some attributes are added automatically (e.g. service.version) even if they are not specified here. In order to suppress such attributes the attribute must be specified in this map with null YAML value (nil string pointer).",
+}
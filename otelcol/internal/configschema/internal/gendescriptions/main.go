@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Program gendescriptions generates the descriptions of the config structs of the Collector, and of their fields,
+// from their doc comments.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory of the Go modules to read")
+	out := flag.String("out", "generated_descriptions.go", "file to write")
+	pkg := flag.String("pkg", "configschema", "package of the generated file")
+	skip := flag.String("skip", "", "comma separated directories, relative to the root, which are not read")
+	flag.Parse()
+
+	var skipDirs []string
+	if *skip != "" {
+		for _, dir := range strings.Split(*skip, ",") {
+			skipDirs = append(skipDirs, filepath.Join(*root, dir))
+		}
+	}
+	descriptions, err := readDescriptions(*root, skipDirs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := render(*pkg, descriptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(*out, src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readDescriptions returns the doc comments of the structs with mapstructure tags, and of their fields, found in the
+// Go modules under root, except the skipped directories, by package path and name.
+func readDescriptions(root string, skipDirs []string) (map[string]string, error) {
+	modules, err := findModules(root)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := map[string]string{}
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != root && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				slices.Contains(skipDirs, p)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+		pkgPath, ok := packagePath(modules, filepath.Dir(p))
+		if !ok {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), p, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if file.Name.Name == "main" {
+			return nil
+		}
+		addDescriptions(descriptions, pkgPath, file)
+		return nil
+	})
+	return descriptions, err
+}
+
+// findModules returns the paths of the modules under root, by directory.
+func findModules(root string) (map[string]string, error) {
+	modules := map[string]string{}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != "go.mod" {
+			return nil
+		}
+		modPath, err := modulePath(p)
+		if err != nil {
+			return err
+		}
+		modules[filepath.Dir(p)] = modPath
+		return nil
+	})
+	return modules, err
+}
+
+func modulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod) //nolint:gosec // the go.mod files are found under the root given by the developer.
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if modPath, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(modPath), `"`), nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no module path in %s", goMod)
+}
+
+// packagePath returns the path of the package in the directory, using the module of the closest parent directory.
+func packagePath(modules map[string]string, dir string) (string, bool) {
+	for modDir := dir; ; modDir = filepath.Dir(modDir) {
+		if modPath, ok := modules[modDir]; ok {
+			rel, err := filepath.Rel(modDir, dir)
+			if err != nil {
+				return "", false
+			}
+			return path.Join(modPath, filepath.ToSlash(rel)), true
+		}
+		if parent := filepath.Dir(modDir); parent == modDir {
+			return "", false
+		}
+	}
+}
+
+// addDescriptions adds the descriptions of the structs of the file which have at least one field with a mapstructure
+// tag, under the package path and the name of the struct, and the descriptions of their fields, under the name of the
+// struct followed by the name of the field.
+func addDescriptions(descriptions map[string]string, pkgPath string, file *ast.File) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			st, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || !hasMapstructureTag(st) {
+				continue
+			}
+			typeName := pkgPath + "." + typeSpec.Name.Name
+			doc := typeSpec.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			addDescription(descriptions, typeName, doc)
+			for _, field := range st.Fields.List {
+				// The squashed fields are not properties, their own fields are.
+				tag, _ := mapstructureTag(field)
+				if _, opts, _ := strings.Cut(tag, ","); strings.Contains(opts, "squash") || strings.Contains(opts, "remain") {
+					continue
+				}
+				doc = field.Doc
+				if doc == nil {
+					doc = field.Comment
+				}
+				for _, name := range fieldNames(field) {
+					if ast.IsExported(name) {
+						addDescription(descriptions, typeName+"."+name, doc)
+					}
+				}
+			}
+		}
+	}
+}
+
+func addDescription(descriptions map[string]string, key string, doc *ast.CommentGroup) {
+	if text := strings.Join(strings.Fields(doc.Text()), " "); text != "" {
+		descriptions[key] = text
+	}
+}
+
+func hasMapstructureTag(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if _, ok := mapstructureTag(field); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func mapstructureTag(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	return reflect.StructTag(tag).Lookup("mapstructure")
+}
+
+// fieldNames returns the names of the field, or the name of its type if it is embedded.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		return names
+	}
+	typ := field.Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.SelectorExpr:
+			return []string{t.Sel.Name}
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return []string{t.Name}
+		default:
+			return nil
+		}
+	}
+}
+
+func render(pkg string, descriptions map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(descriptions))
+	for key := range descriptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gendescriptions. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("// descriptions are the doc comments of the config structs, and of their fields, by package path and name.\n")
+	buf.WriteString("var descriptions = map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%s: %s,\n", strconv.Quote(key), strconv.Quote(descriptions[key]))
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigSource = `package testconfig
+
+// Config is the configuration
+// of the component.
+type Config struct {
+	// Endpoint is the address
+	// of the server.
+	Endpoint string ` + "`mapstructure:\"endpoint\"`" + `
+	Timeout  int    ` + "`mapstructure:\"timeout\"`" + ` // Timeout is in seconds.
+	// Squashed fields are not properties.
+	Embedded ` + "`mapstructure:\",squash\"`" + `
+	// Unexported fields are not properties.
+	unexported string
+}
+
+// Embedded is squashed.
+type Embedded struct {
+	// Name is the name.
+	Name string ` + "`mapstructure:\"name\"`" + `
+}
+
+// Other is not a config struct.
+type Other struct {
+	// Field is not described.
+	Field string
+}
+`
+
+func writeFile(t *testing.T, name, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o700))
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+}
+
+func TestReadDescriptions(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/root\n")
+	writeFile(t, filepath.Join(root, "testconfig", "config.go"), testConfigSource)
+	writeFile(t, filepath.Join(root, "nested", "go.mod"), "module example.com/nested\n")
+	writeFile(t, filepath.Join(root, "nested", "config", "config.go"), testConfigSource)
+	writeFile(t, filepath.Join(root, "testconfig", "config_test.go"), "package testconfig\n\n"+
+		"// TestConfig is a test config.\ntype TestConfig struct {\n\tName string `mapstructure:\"name\"`\n}\n")
+	writeFile(t, filepath.Join(root, "skipped", "config.go"), testConfigSource)
+
+	descriptions, err := readDescriptions(root, []string{filepath.Join(root, "skipped")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"example.com/root/testconfig.Config":          "Config is the configuration of the component.",
+		"example.com/root/testconfig.Config.Endpoint": "Endpoint is the address of the server.",
+		"example.com/root/testconfig.Config.Timeout":  "Timeout is in seconds.",
+		"example.com/root/testconfig.Embedded":        "Embedded is squashed.",
+		"example.com/root/testconfig.Embedded.Name":   "Name is the name.",
+		"example.com/nested/config.Config":            "Config is the configuration of the component.",
+		"example.com/nested/config.Config.Endpoint":   "Endpoint is the address of the server.",
+		"example.com/nested/config.Config.Timeout":    "Timeout is in seconds.",
+		"example.com/nested/config.Embedded":          "Embedded is squashed.",
+		"example.com/nested/config.Embedded.Name":     "Name is the name.",
+	}, descriptions)
+
+	src, err := render("testconfig", descriptions)
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "// Code generated by gendescriptions. DO NOT EDIT.")
+	assert.Regexp(t, `"example.com/root/testconfig.Config.Timeout": +"Timeout is in seconds.",`, string(src))
+}
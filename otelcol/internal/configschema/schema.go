@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configschema generates the JSON Schema of configuration structs, using their mapstructure tags.
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+// The descriptions are only generated from the config structs of this repository, the components built from other
+// repositories only get the descriptions of the common types. CI checks they are up to date with `make genconfigschema`.
+//go:generate go run ./internal/gendescriptions -root ../../.. -skip cmd,internal/cmd -out generated_descriptions.go
+
+import (
+	"encoding"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Version is the JSON Schema version of the generated schemas.
+const Version = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Type is the name of the type, or the names of the types, of the values.
+	Type              any                `json:"type,omitempty"`
+	Enum              []any              `json:"enum,omitempty"`
+	Pattern           string             `json:"pattern,omitempty"`
+	Properties        map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties map[string]*Schema `json:"patternProperties,omitempty"`
+	// AdditionalProperties is the *Schema of the additional properties, or false if they are not allowed.
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// knownType holds the description and the allowed values of a type, which cannot be found using reflection or in the
+// doc comments of the type.
+type knownType struct {
+	description string
+	enum        []any
+}
+
+// knownTypes are the known types, by package path and name. Their description is used instead of the generated one.
+var knownTypes = map[string]knownType{
+	"time.Duration": {description: "A duration, e.g. 500ms, 5s or 1h30m."},
+	"go.opentelemetry.io/collector/component.ID": {
+		description: "A component ID, in the type[/name] format.",
+	},
+	"go.opentelemetry.io/collector/config/configopaque.String": {
+		description: "A sensitive value, redacted when the configuration is printed.",
+	},
+	"go.opentelemetry.io/collector/config/configtelemetry.Level": {
+		description: "The level of the telemetry.",
+		enum:        []any{"none", "basic", "normal", "detailed"},
+	},
+	"go.uber.org/zap/zapcore.Level": {
+		description: "The level of the logs.",
+		enum:        []any{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"},
+	},
+	"go.opentelemetry.io/collector/config/configcompression.Type": {
+		description: "The compression type.",
+		enum:        []any{"", "none", "gzip", "zlib", "deflate", "snappy", "x-snappy-framed", "zstd", "lz4"},
+	},
+	"go.opentelemetry.io/collector/config/confignet.TransportType": {
+		description: "The network transport.",
+		enum:        []any{"tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "ip", "ip4", "ip6", "unix", "unixgram", "unixpacket"},
+	},
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request.SizerType": {
+		description: "The unit of the sizes.",
+		enum:        []any{"requests", "items", "bytes"},
+	},
+	"go.opentelemetry.io/collector/config/configcompression.CompressionParams": {description: "The compression parameters."},
+}
+
+const optionalPkgPath = "go.opentelemetry.io/collector/config/configoptional"
+
+var (
+	versionRegexp       = regexp.MustCompile(`^v[0-9]+`)
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Generator generates the schemas of types, sharing the definitions of the named struct types.
+type Generator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+// NewGenerator returns a new Generator.
+func NewGenerator() *Generator {
+	return &Generator{defs: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// Defs returns the definitions of the schemas referenced by the generated ones.
+func (g *Generator) Defs() map[string]*Schema {
+	return g.defs
+}
+
+// Generate returns the schema of the type. The schemas of the named struct types, except the top level one,
+// are added to the definitions and referenced.
+func (g *Generator) Generate(t reflect.Type) *Schema {
+	t = deref(t)
+	if t.Kind() == reflect.Struct && !isOptional(t) {
+		return g.structSchema(t)
+	}
+	return g.schema(t)
+}
+
+func (g *Generator) schema(t reflect.Type) *Schema {
+	t = deref(t)
+	if isOptional(t) {
+		return g.schema(optionalValueType(t))
+	}
+
+	known := knownTypes[typeName(t)]
+	s := &Schema{Description: known.description}
+	switch {
+	case t == durationType:
+		s.Type = "string"
+		s.Pattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+		return s
+	case len(known.enum) > 0:
+		s.Enum = known.enum
+		return s
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		s.Type = "string"
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Slice, reflect.Array:
+		// An empty YAML key is unmarshaled as nil, so the collections accept null.
+		s.Type = []string{"array", "null"}
+		s.Items = g.schema(t.Elem())
+	case reflect.Map:
+		s.Type = []string{"object", "null"}
+		s.AdditionalProperties = g.schema(t.Elem())
+	case reflect.Struct:
+		return g.ref(t)
+	default:
+		// Interfaces, and the kinds which cannot be set from the configuration, accept any value.
+	}
+	return s
+}
+
+// ref returns a reference to the definition of the struct type, adding it if needed.
+func (g *Generator) ref(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	name, ok := g.names[t]
+	if !ok {
+		name = g.defName(t)
+		g.names[t] = name
+		// Add the definition before generating it, so the recursive types reference it.
+		def := &Schema{}
+		g.defs[name] = def
+		*def = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// defName returns the name of the definition of the type, e.g. confighttp.ServerConfig.
+func (g *Generator) defName(t reflect.Type) string {
+	pkg := path.Base(t.PkgPath())
+	if versionRegexp.MatchString(pkg) {
+		// Use the name of the module for the versioned packages, e.g. otelconf_v0.3.0.
+		pkg = path.Base(path.Dir(t.PkgPath())) + "_" + pkg
+	}
+	name := pkg + "." + t.Name()
+	name = strings.NewReplacer("[", "_", "]", "_", "/", "_", "*", "").Replace(name)
+	unique := name
+	for i := 2; g.defs[unique] != nil; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	return unique
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 []string{"object", "null"},
+		Description:          typeDescription(t),
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	g.addFields(s, t)
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+// addFields adds the properties of the fields of the struct type, the same way they are unmarshaled.
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("mapstructure")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		switch {
+		case strings.Contains(opts, "remain"):
+			s.AdditionalProperties = nil
+			continue
+		case strings.Contains(opts, "squash") && deref(field.Type).Kind() == reflect.Struct:
+			g.addFields(s, deref(field.Type))
+			continue
+		case name == "":
+			name = field.Name
+		}
+		prop := g.schema(field.Type)
+		// Keep the description of the type after the one of the field, e.g. the format of a duration.
+		prop.Description = strings.TrimSpace(descriptions[typeName(t)+"."+field.Name] + " " + prop.Description)
+		s.Properties[name] = prop
+	}
+}
+
+// typeDescription returns the description of the known type, or the generated one.
+func typeDescription(t reflect.Type) string {
+	if known, ok := knownTypes[typeName(t)]; ok && known.description != "" {
+		return known.description
+	}
+	return descriptions[typeName(t)]
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func typeName(t reflect.Type) string {
+	return t.PkgPath() + "." + t.Name()
+}
+
+// isOptional returns whether the type is a configoptional.Optional, which is unmarshaled as its value.
+func isOptional(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == optionalPkgPath && strings.HasPrefix(t.Name(), "Optional[")
+}
+
+func optionalValueType(t reflect.Type) reflect.Type {
+	get, ok := reflect.PointerTo(t).MethodByName("Get")
+	if !ok || get.Type.NumOut() != 1 {
+		return reflect.TypeFor[any]()
+	}
+	return get.Type.Out(0)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+type nestedConfig struct {
+	Name string `mapstructure:"name"`
+	// Next is recursive, so the definition references itself.
+	Next *nestedConfig `mapstructure:"next"`
+}
+
+type SquashedConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type testConfig struct {
+	SquashedConfig `mapstructure:",squash"`
+
+	Enabled  bool                                  `mapstructure:"enabled"`
+	Count    int                                   `mapstructure:"count"`
+	Ratio    float64                               `mapstructure:"ratio"`
+	Timeout  time.Duration                         `mapstructure:"timeout"`
+	Token    configopaque.String                   `mapstructure:"token"`
+	Level    configtelemetry.Level                 `mapstructure:"level"`
+	ID       component.ID                          `mapstructure:"id"`
+	Tags     []string                              `mapstructure:"tags"`
+	Headers  map[string]string                     `mapstructure:"headers"`
+	Any      any                                   `mapstructure:"any"`
+	Nested   nestedConfig                          `mapstructure:"nested"`
+	Optional configoptional.Optional[nestedConfig] `mapstructure:"optional"`
+	Untagged string
+	Ignored  string `mapstructure:"-"`
+	ignored  string
+}
+
+type remainConfig struct {
+	Name  string         `mapstructure:"name"`
+	Other map[string]any `mapstructure:",remain"`
+}
+
+func TestGenerate(t *testing.T) {
+	gen := NewGenerator()
+	s := gen.Generate(reflect.TypeFor[*testConfig]())
+
+	assert.Equal(t, []string{"object", "null"}, s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["endpoint"])
+	assert.Equal(t, &Schema{Type: "boolean"}, s.Properties["enabled"])
+	assert.Equal(t, &Schema{Type: "integer"}, s.Properties["count"])
+	assert.Equal(t, &Schema{Type: "number"}, s.Properties["ratio"])
+	assert.Equal(t, "string", s.Properties["timeout"].Type)
+	assert.Regexp(t, s.Properties["timeout"].Pattern, "1h30m")
+	assert.NotRegexp(t, s.Properties["timeout"].Pattern, "30")
+	assert.Equal(t, "string", s.Properties["token"].Type)
+	assert.NotEmpty(t, s.Properties["token"].Description)
+	assert.Equal(t, []any{"none", "basic", "normal", "detailed"}, s.Properties["level"].Enum)
+	assert.Equal(t, "string", s.Properties["id"].Type)
+	assert.Equal(t, &Schema{Type: []string{"array", "null"}, Items: &Schema{Type: "string"}}, s.Properties["tags"])
+	assert.Equal(t, &Schema{Type: []string{"object", "null"}, AdditionalProperties: &Schema{Type: "string"}}, s.Properties["headers"])
+	assert.Equal(t, &Schema{}, s.Properties["any"])
+	assert.Equal(t, &Schema{Ref: "#/$defs/configschema.nestedConfig"}, s.Properties["nested"])
+	assert.Equal(t, &Schema{Ref: "#/$defs/configschema.nestedConfig"}, s.Properties["optional"])
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["Untagged"])
+	assert.NotContains(t, s.Properties, "Ignored")
+	assert.NotContains(t, s.Properties, "ignored")
+	assert.NotContains(t, s.Properties, "SquashedConfig")
+
+	require.Contains(t, gen.Defs(), "configschema.nestedConfig")
+	def := gen.Defs()["configschema.nestedConfig"]
+	assert.Equal(t, &Schema{Ref: "#/$defs/configschema.nestedConfig"}, def.Properties["next"])
+	assert.Len(t, gen.Defs(), 1)
+}
+
+func TestGenerateDescriptions(t *testing.T) {
+	gen := NewGenerator()
+	s := gen.Generate(reflect.TypeFor[configretry.BackOffConfig]())
+	assert.Equal(t, descriptions["go.opentelemetry.io/collector/config/configretry.BackOffConfig"], s.Description)
+	assert.Equal(t, "InitialInterval the time to wait after the first failure before retrying. A duration, e.g. 500ms, 5s or 1h30m.",
+		s.Properties["initial_interval"].Description)
+	assert.Contains(t, s.Properties["enabled"].Description, "Enabled")
+
+	// The description of the known types is kept.
+	s = gen.Generate(reflect.TypeFor[testConfig]())
+	assert.Equal(t, knownTypes["go.opentelemetry.io/collector/config/configopaque.String"].description,
+		s.Properties["token"].Description)
+	assert.Empty(t, s.Properties["count"].Description)
+}
+
+func TestGenerateRemain(t *testing.T) {
+	s := NewGenerator().Generate(reflect.TypeFor[remainConfig]())
+	assert.Nil(t, s.AdditionalProperties)
+	assert.Equal(t, map[string]*Schema{"name": {Type: "string"}}, s.Properties)
+}
@@ -33,31 +33,60 @@ func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 	c.cfgs = make(map[component.ID]component.Config)
 	// Iterate over raw configs and create a config for each.
 	for id := range rawCfgs {
-		// Find factory based on component kind and type that we read from config source.
-		factory, ok := c.factories[id.Type()]
-		if !ok {
-			return errorUnknownType(id, maps.Keys(c.factories))
-		}
-
-		// Get the configuration from the confmap.Conf to preserve internal representation.
-		sub, err := conf.Sub(id.String())
+		cfg, err := c.unmarshalComponent(conf, id)
 		if err != nil {
-			return errorUnmarshalError(id, err)
+			return err
 		}
+		c.cfgs[id] = cfg
+	}
 
-		// Create the default config for this component.
-		cfg := factory.CreateDefaultConfig()
+	return nil
+}
 
-		// Now that the default config struct is created we can Unmarshal into it,
-		// and it will apply user-defined config on top of the default.
-		if err := sub.Unmarshal(&cfg); err != nil {
-			return errorUnmarshalError(id, err)
-		}
+// UnmarshalEach unmarshals the configurations of the components the same way as Unmarshal, but does not stop at
+// the first invalid one: it returns the errors by component ID, and keeps the configurations of the valid ones.
+// The returned error is set if the IDs of the components cannot be unmarshaled.
+func (c *Configs[F]) UnmarshalEach(conf *confmap.Conf) (map[component.ID]error, error) {
+	rawCfgs := make(map[component.ID]map[string]any)
+	if err := conf.Unmarshal(&rawCfgs); err != nil {
+		return nil, err
+	}
 
+	c.cfgs = make(map[component.ID]component.Config)
+	errs := make(map[component.ID]error)
+	for id := range rawCfgs {
+		cfg, err := c.unmarshalComponent(conf, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
 		c.cfgs[id] = cfg
 	}
+	return errs, nil
+}
 
-	return nil
+func (c *Configs[F]) unmarshalComponent(conf *confmap.Conf, id component.ID) (component.Config, error) {
+	// Find factory based on component kind and type that we read from config source.
+	factory, ok := c.factories[id.Type()]
+	if !ok {
+		return nil, errorUnknownType(id, maps.Keys(c.factories))
+	}
+
+	// Get the configuration from the confmap.Conf to preserve internal representation.
+	sub, err := conf.Sub(id.String())
+	if err != nil {
+		return nil, errorUnmarshalError(id, err)
+	}
+
+	// Create the default config for this component.
+	cfg := factory.CreateDefaultConfig()
+
+	// Now that the default config struct is created we can Unmarshal into it,
+	// and it will apply user-defined config on top of the default.
+	if err := sub.Unmarshal(&cfg); err != nil {
+		return nil, errorUnmarshalError(id, err)
+	}
+	return cfg, nil
 }
 
 func (c *Configs[F]) Configs() map[component.ID]component.Config {
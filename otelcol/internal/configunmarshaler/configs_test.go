@@ -143,6 +143,33 @@ func TestUnmarshalError(t *testing.T) {
 	}
 }
 
+func TestUnmarshalEach(t *testing.T) {
+	for _, tk := range testKinds {
+		t.Run(tk.kind, func(t *testing.T) {
+			cfgs := NewConfigs(tk.factories)
+			errs, err := cfgs.UnmarshalEach(confmap.NewFromStringMap(map[string]any{
+				"nop":    nil,
+				"nop/my": map[string]any{"unknown_section": tk.kind},
+				"nosuch": nil,
+			}))
+			require.NoError(t, err)
+
+			assert.Equal(t, map[component.ID]component.Config{
+				component.NewID(nopType): tk.factories[nopType].CreateDefaultConfig(),
+			}, cfgs.Configs())
+			require.Len(t, errs, 2)
+			assert.ErrorContains(t, errs[component.NewIDWithName(nopType, "my")], "error reading configuration for \"nop/my\"")
+			assert.ErrorContains(t, errs[component.MustNewID("nosuch")], "unknown type: \"nosuch\"")
+		})
+	}
+}
+
+func TestUnmarshalEachInvalidIDs(t *testing.T) {
+	cfgs := NewConfigs(testKinds[0].factories)
+	_, err := cfgs.UnmarshalEach(confmap.NewFromStringMap(map[string]any{"/custom": nil}))
+	assert.ErrorContains(t, err, "the part before / should not be empty")
+}
+
 func TestUnmarshal_LoggingExporter(t *testing.T) {
 	conf := confmap.NewFromStringMap(map[string]any{
 		"logging": nil,
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/shutdown"
 	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 )
 
@@ -27,11 +28,6 @@ type configSettings struct {
 // unmarshal the configSettings from a confmap.Conf.
 // After the config is unmarshalled, `Validate()` must be called to validate.
 func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
-	// TODO: inject the telemetry factory through factories, once available.
-	// See https://github.com/open-telemetry/opentelemetry-collector/issues/4970
-	telFactory := otelconftelemetry.NewFactory()
-	defaultTelConfig := *telFactory.CreateDefaultConfig().(*otelconftelemetry.Config)
-
 	// Unmarshal top level sections and validate.
 	cfg := &configSettings{
 		Receivers:  configunmarshaler.NewConfigs(factories.Receivers),
@@ -39,11 +35,34 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 		Exporters:  configunmarshaler.NewConfigs(factories.Exporters),
 		Connectors: configunmarshaler.NewConfigs(factories.Connectors),
 		Extensions: configunmarshaler.NewConfigs(factories.Extensions),
-		// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
-		Service: service.Config{
-			Telemetry: defaultTelConfig,
-		},
+		Service:    defaultServiceConfig(),
 	}
 	err := v.Unmarshal(&cfg)
 	return cfg, err
 }
+
+// defaultServiceConfig returns the service config the configuration is unmarshaled into.
+func defaultServiceConfig() service.Config {
+	// TODO: inject the telemetry factory through factories, once available.
+	// See https://github.com/open-telemetry/opentelemetry-collector/issues/4970
+	telFactory := otelconftelemetry.NewFactory()
+	defaultTelConfig := *telFactory.CreateDefaultConfig().(*otelconftelemetry.Config)
+
+	// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
+	return service.Config{
+		Telemetry: defaultTelConfig,
+		Shutdown:  shutdown.NewDefaultConfig(),
+	}
+}
+
+// toConfig returns the Config holding the unmarshalled configSettings.
+func (cfg *configSettings) toConfig() *Config {
+	return &Config{
+		Receivers:  cfg.Receivers.Configs(),
+		Processors: cfg.Processors.Configs(),
+		Exporters:  cfg.Exporters.Configs(),
+		Connectors: cfg.Connectors.Configs(),
+		Extensions: cfg.Extensions.Configs(),
+		Service:    cfg.Service,
+	}
+}
@@ -6,6 +6,7 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/shutdown"
 	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 )
 
@@ -20,6 +21,9 @@ type Config struct {
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
 
+	// Shutdown defines how the pipelines are drained when they are shut down.
+	Shutdown shutdown.Config `mapstructure:"shutdown,omitempty"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -63,6 +63,15 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New("collector telemetry metrics reader should exist when metric level is not none"),
 		},
+		{
+			name: "invalid-shutdown-drain-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Shutdown.DrainTimeout = -time.Second
+				return cfg
+			},
+			expected: errors.New("shutdown: drain_timeout must not be negative"),
+		},
 	}
 
 	for _, tt := range testCases {
@@ -236,6 +236,8 @@ replace go.opentelemetry.io/collector/extension/extensionauth/extensionauthtest
 
 replace go.opentelemetry.io/collector/extension/xextension => ../extension/xextension
 
+replace go.opentelemetry.io/collector/extension/xextension/storage/storagetest => ../extension/xextension/storage/storagetest
+
 replace go.opentelemetry.io/collector/otelcol => ../otelcol
 
 replace go.opentelemetry.io/collector/confmap/provider/yamlprovider => ../confmap/provider/yamlprovider
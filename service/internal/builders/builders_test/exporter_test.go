@@ -148,6 +148,9 @@ func TestExporterBuilderFactory(t *testing.T) {
 
 	assert.NotNil(t, b.Factory(component.MustNewID("foo").Type()))
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
+
+	assert.Equal(t, struct{}{}, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
 }
 
 func TestNewNopExporterConfigsAndFactories(t *testing.T) {
@@ -4,8 +4,15 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"context"
+	"sync/atomic"
+
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/attribute"
 )
@@ -17,14 +24,13 @@ var _ consumerNode = (*capabilitiesNode)(nil)
 // 1. Present aggregated capabilities to receivers, such as whether the pipeline mutates data.
 // 2. Present a consistent "first consumer" for each pipeline.
 // The nodeID is derived from "pipeline ID".
+//
+// Since the receivers keep a reference to this node, its next consumer can be replaced
+// when the pipeline is reloaded, without rebuilding the receivers.
 type capabilitiesNode struct {
 	attribute.Attributes
 	pipelineID pipeline.ID
-	baseConsumer
-	consumer.ConsumeTracesFunc
-	consumer.ConsumeMetricsFunc
-	consumer.ConsumeLogsFunc
-	xconsumer.ConsumeProfilesFunc
+	next       atomic.Pointer[baseConsumer]
 }
 
 func newCapabilitiesNode(pipelineID pipeline.ID) *capabilitiesNode {
@@ -37,3 +43,28 @@ func newCapabilitiesNode(pipelineID pipeline.ID) *capabilitiesNode {
 func (n *capabilitiesNode) getConsumer() baseConsumer {
 	return n
 }
+
+// setConsumer replaces the consumer the data of the pipeline is passed to.
+func (n *capabilitiesNode) setConsumer(next baseConsumer) {
+	n.next.Store(&next)
+}
+
+func (n *capabilitiesNode) Capabilities() consumer.Capabilities {
+	return (*n.next.Load()).Capabilities()
+}
+
+func (n *capabilitiesNode) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return (*n.next.Load()).(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (n *capabilitiesNode) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return (*n.next.Load()).(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (n *capabilitiesNode) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return (*n.next.Load()).(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+func (n *capabilitiesNode) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return (*n.next.Load()).(xconsumer.Profiles).ConsumeProfiles(ctx, pd)
+}
@@ -5,6 +5,7 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
 	"context"
+	"sync/atomic"
 
 	otelattr "go.opentelemetry.io/otel/attribute"
 
@@ -22,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/tap"
 )
 
 const pipelineIDAttrKey = "otelcol.pipeline.id"
@@ -35,6 +37,10 @@ type connectorNode struct {
 	rcvrPipelineType pipeline.Signal
 	component.Component
 	consumer baseConsumer
+	// producedItems counts the items passed to each of the pipelines the connector exports to.
+	producedItems map[pipeline.ID]*atomic.Int64
+	// tapPoint samples the data passed to the pipelines the connector exports to, for the tapz page.
+	tapPoint *tap.Point
 }
 
 func newConnectorNode(exprPipelineType, rcvrPipelineType pipeline.Signal, connID component.ID) *connectorNode {
@@ -99,16 +105,20 @@ func (n *connectorNode) buildTraces(
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Traces, len(nexts))
+	n.producedItems = make(map[pipeline.ID]*atomic.Int64, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewTraces(
-			next.(consumer.Traces),
+		pipelineID := next.(*capabilitiesNode).pipelineID
+		n.producedItems[pipelineID] = &atomic.Int64{}
+		consumers[pipelineID] = obsconsumer.NewTraces(
+			n.tapPoint.Traces(next.(consumer.Traces)),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
 					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+					pipelineID.String(),
 				),
 			),
+			obsconsumer.WithItemCount(n.producedItems[pipelineID]),
 		)
 	}
 	next := connector.NewTracesRouter(consumers)
@@ -177,16 +187,20 @@ func (n *connectorNode) buildMetrics(
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Metrics, len(nexts))
+	n.producedItems = make(map[pipeline.ID]*atomic.Int64, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewMetrics(
-			next.(consumer.Metrics),
+		pipelineID := next.(*capabilitiesNode).pipelineID
+		n.producedItems[pipelineID] = &atomic.Int64{}
+		consumers[pipelineID] = obsconsumer.NewMetrics(
+			n.tapPoint.Metrics(next.(consumer.Metrics)),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
 					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+					pipelineID.String(),
 				),
 			),
+			obsconsumer.WithItemCount(n.producedItems[pipelineID]),
 		)
 	}
 	next := connector.NewMetricsRouter(consumers)
@@ -255,16 +269,20 @@ func (n *connectorNode) buildLogs(
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Logs, len(nexts))
+	n.producedItems = make(map[pipeline.ID]*atomic.Int64, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewLogs(
-			next.(consumer.Logs),
+		pipelineID := next.(*capabilitiesNode).pipelineID
+		n.producedItems[pipelineID] = &atomic.Int64{}
+		consumers[pipelineID] = obsconsumer.NewLogs(
+			n.tapPoint.Logs(next.(consumer.Logs)),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
 					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+					pipelineID.String(),
 				),
 			),
+			obsconsumer.WithItemCount(n.producedItems[pipelineID]),
 		)
 	}
 	next := connector.NewLogsRouter(consumers)
@@ -333,16 +351,20 @@ func (n *connectorNode) buildProfiles(
 	}
 
 	consumers := make(map[pipeline.ID]xconsumer.Profiles, len(nexts))
+	n.producedItems = make(map[pipeline.ID]*atomic.Int64, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewProfiles(
-			next.(xconsumer.Profiles),
+		pipelineID := next.(*capabilitiesNode).pipelineID
+		n.producedItems[pipelineID] = &atomic.Int64{}
+		consumers[pipelineID] = obsconsumer.NewProfiles(
+			n.tapPoint.Profiles(next.(xconsumer.Profiles)),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
 					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+					pipelineID.String(),
 				),
 			),
+			obsconsumer.WithItemCount(n.producedItems[pipelineID]),
 		)
 	}
 	next := xconnector.NewProfilesRouter(consumers)
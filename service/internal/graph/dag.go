@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	// URL Params
+	zGraphFormat = "format"
+
+	graphFormatJSON = "json"
+	graphFormatDOT  = "dot"
+
+	// Layout of the graph rendered in the zpages, in pixels.
+	graphNodeWidth  = 220
+	graphNodeHeight = 40
+	graphColumnGap  = 90
+	graphRowGap     = 20
+	graphMargin     = 10
+)
+
+// dag is the exported representation of the graph, with the data flowing through each edge.
+type dag struct {
+	Nodes []dagNode `json:"nodes"`
+	Edges []dagEdge `json:"edges"`
+}
+
+type dagNode struct {
+	ID           string `json:"id"`
+	Kind         string `json:"kind"`
+	ComponentID  string `json:"component_id,omitempty"`
+	PipelineID   string `json:"pipeline_id,omitempty"`
+	Signal       string `json:"signal,omitempty"`
+	SignalOutput string `json:"signal_output,omitempty"`
+
+	// layer is the length of the longest path from a receiver to the node.
+	layer int
+}
+
+type dagEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Items is the number of items passed through the edge since the component sending them was built.
+	// It is only available when the telemetry.newPipelineTelemetry feature gate is enabled.
+	Items *int64 `json:"items,omitempty"`
+}
+
+// name returns the name of the node, followed by the detail distinguishing it from the other nodes of the same
+// component.
+func (n *dagNode) name() (string, string) {
+	name := n.Kind
+	if n.ComponentID != "" {
+		name += ":" + n.ComponentID
+	}
+	switch {
+	case n.PipelineID != "":
+		return name, n.PipelineID
+	case n.SignalOutput != "":
+		return name, n.Signal + "->" + n.SignalOutput
+	default:
+		return name, n.Signal
+	}
+}
+
+// newDAGNode describes the node from its attributes.
+func newDAGNode(node graph.Node) dagNode {
+	var set *otelattr.Set
+	if n, ok := node.(interface{ Set() *otelattr.Set }); ok {
+		set = n.Set()
+	}
+	get := func(key string) string {
+		if set == nil {
+			return ""
+		}
+		v, _ := set.Value(otelattr.Key(key))
+		return v.AsString()
+	}
+	n := dagNode{
+		Kind:         get(componentattribute.ComponentKindKey),
+		ComponentID:  get(componentattribute.ComponentIDKey),
+		PipelineID:   get(componentattribute.PipelineIDKey),
+		Signal:       get(componentattribute.SignalKey),
+		SignalOutput: get(componentattribute.SignalOutputKey),
+	}
+	name, detail := n.name()
+	n.ID = name + "[" + detail + "]"
+	return n
+}
+
+// dag exports the graph, with the number of items passed through each edge.
+func (g *Graph) dag() (*dag, error) {
+	nodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return nil, err
+	}
+	countItems := telemetry.NewPipelineTelemetryGate.IsEnabled()
+
+	d := &dag{}
+	dagNodes := make(map[int64]*dagNode, len(nodes))
+	// The number of items produced by each node, passed to all its next nodes, except for the connectors
+	// which route them.
+	produced := make(map[int64]*int64, len(nodes))
+	for _, node := range nodes {
+		dn := newDAGNode(node)
+		received, known := int64(0), countItems
+		to := g.componentGraph.To(node.ID())
+		for to.Next() {
+			prev := dagNodes[to.Node().ID()]
+			dn.layer = max(dn.layer, prev.layer+1)
+			var items *int64
+			if countItems {
+				items = edgeItems(to.Node(), node, produced)
+			}
+			d.Edges = append(d.Edges, dagEdge{From: prev.ID, To: dn.ID, Items: items})
+			if items == nil {
+				known = false
+				continue
+			}
+			received += *items
+		}
+		dagNodes[node.ID()] = &dn
+
+		switch n := node.(type) {
+		case *receiverNode:
+			produced[n.ID()] = loadCount(n.producedItems)
+		case *processorNode:
+			produced[n.ID()] = loadCount(n.producedItems)
+		case *capabilitiesNode, *fanOutNode:
+			// These nodes pass the data they receive as it is.
+			if known {
+				produced[n.ID()] = &received
+			}
+		}
+	}
+
+	for _, dn := range dagNodes {
+		d.Nodes = append(d.Nodes, *dn)
+	}
+	sort.Slice(d.Nodes, func(i, j int) bool {
+		return d.Nodes[i].ID < d.Nodes[j].ID
+	})
+	sort.Slice(d.Edges, func(i, j int) bool {
+		if d.Edges[i].From != d.Edges[j].From {
+			return d.Edges[i].From < d.Edges[j].From
+		}
+		return d.Edges[i].To < d.Edges[j].To
+	})
+	return d, nil
+}
+
+// edgeItems returns the number of items passed from the node to the next one, or nil if it is unknown.
+func edgeItems(from, to graph.Node, produced map[int64]*int64) *int64 {
+	if n, ok := from.(*connectorNode); ok {
+		next, isCapabilities := to.(*capabilitiesNode)
+		if !isCapabilities || n.producedItems == nil {
+			return nil
+		}
+		return loadCount(n.producedItems[next.pipelineID])
+	}
+	return produced[from.ID()]
+}
+
+func loadCount(count *atomic.Int64) *int64 {
+	if count == nil {
+		return nil
+	}
+	v := count.Load()
+	return &v
+}
+
+// writeDOT writes the graph in the DOT language of Graphviz.
+func (d *dag) writeDOT(w io.Writer) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	printf("digraph pipelines {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for i := range d.Nodes {
+		name, detail := d.Nodes[i].name()
+		printf("\t%q [label=%q];\n", d.Nodes[i].ID, name+"\n"+detail)
+	}
+	for _, e := range d.Edges {
+		if e.Items != nil {
+			printf("\t%q -> %q [label=%q];\n", e.From, e.To, strconv.FormatInt(*e.Items, 10))
+			continue
+		}
+		printf("\t%q -> %q;\n", e.From, e.To)
+	}
+	printf("}\n")
+	return err
+}
+
+// graphData lays the graph out in columns, one per layer, for the zpages.
+func (d *dag) graphData() zpages.PipelineGraphData {
+	data := zpages.PipelineGraphData{
+		NodeWidth:  graphNodeWidth,
+		NodeHeight: graphNodeHeight,
+	}
+	type position struct{ x, y int }
+	positions := make(map[string]position, len(d.Nodes))
+	rows := make(map[int]int)
+	nodes := make([]dagNode, len(d.Nodes))
+	copy(nodes, d.Nodes)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].layer < nodes[j].layer
+	})
+	for i := range nodes {
+		n := &nodes[i]
+		pos := position{
+			x: graphMargin + n.layer*(graphNodeWidth+graphColumnGap),
+			y: graphMargin + rows[n.layer]*(graphNodeHeight+graphRowGap),
+		}
+		rows[n.layer]++
+		positions[n.ID] = pos
+		name, detail := n.name()
+		data.Nodes = append(data.Nodes, zpages.PipelineGraphNodeData{
+			X:      pos.x,
+			Y:      pos.y,
+			Kind:   n.Kind,
+			Name:   name,
+			Detail: detail,
+		})
+		data.Width = max(data.Width, pos.x+graphNodeWidth+graphMargin)
+		data.Height = max(data.Height, pos.y+graphNodeHeight+graphMargin)
+	}
+	for _, e := range d.Edges {
+		from, to := positions[e.From], positions[e.To]
+		edge := zpages.PipelineGraphEdgeData{
+			X1: from.x + graphNodeWidth,
+			Y1: from.y + graphNodeHeight/2,
+			X2: to.x,
+			Y2: to.y + graphNodeHeight/2,
+		}
+		edge.LabelX, edge.LabelY = (edge.X1+edge.X2)/2, (edge.Y1+edge.Y2)/2-4
+		if e.Items != nil {
+			data.ItemCounts = true
+			edge.Label = strconv.FormatInt(*e.Items, 10)
+		}
+		data.Edges = append(data.Edges, edge)
+	}
+	return data
+}
+
+// HandleGraphZPages serves the graph of the components, as JSON, DOT, or rendered in an HTML page.
+func (g *Graph) HandleGraphZPages(w http.ResponseWriter, r *http.Request) {
+	g.mu.RLock()
+	d, err := g.dag()
+	g.mu.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch r.URL.Query().Get(zGraphFormat) {
+	case graphFormatJSON:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(d)
+	case graphFormatDOT:
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_ = d.writeDOT(w)
+	default:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipeline Graph"})
+		zpages.WriteHTMLPipelineGraph(w, d.graphData())
+		zpages.WriteHTMLPageFooter(w)
+	}
+}
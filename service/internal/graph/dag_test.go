@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+const (
+	dagTracesReceiver  = "receiver:examplereceiver/traces[traces]"
+	dagTracesCaps      = "capabilities[traces]"
+	dagTracesProcessor = "processor:exampleprocessor/traces[traces]"
+	dagTracesFanout    = "fanout[traces]"
+	dagTracesExporter  = "exporter:exampleexporter[traces]"
+	dagConnector       = "connector:exampleconnector[traces->metrics]"
+	dagMetricsCaps     = "capabilities[metrics]"
+)
+
+func newDAGTestGraph(t *testing.T) (*Graph, *reloadConfig) {
+	cfg := newReloadConfig()
+	cfg.pipelines = pipelines.Config{
+		tracesID: {
+			Receivers:  []component.ID{tracesReceiverID},
+			Processors: []component.ID{tracesProcID},
+			Exporters:  []component.ID{exporterID, connectorID},
+		},
+		metricsID: {
+			Receivers:  []component.ID{connectorID},
+			Processors: []component.ID{metricsProcID},
+			Exporters:  []component.ID{exporterID},
+		},
+	}
+	pg, err := Build(context.Background(), cfg.settings())
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewNopStatusReporter()}))
+	t.Cleanup(func() {
+		require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+	})
+	return pg, cfg
+}
+
+func consumeTestTraces(t *testing.T, pg *Graph, spans int) {
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][tracesReceiverID].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(spans)))
+}
+
+func findEdge(d *dag, from, to string) *dagEdge {
+	for i := range d.Edges {
+		if d.Edges[i].From == from && d.Edges[i].To == to {
+			return &d.Edges[i]
+		}
+	}
+	return nil
+}
+
+func TestGraphDAG(t *testing.T) {
+	setObsConsumerGateForTest(t, true)
+	pg, cfg := newDAGTestGraph(t)
+	consumeTestTraces(t, pg, 2)
+
+	d, err := pg.dag()
+	require.NoError(t, err)
+
+	var ids []string
+	for _, n := range d.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.ElementsMatch(t, []string{
+		dagTracesReceiver, dagTracesCaps, dagTracesProcessor, dagTracesFanout, dagTracesExporter, dagConnector,
+		dagMetricsCaps, "processor:exampleprocessor/metrics[metrics]", "fanout[metrics]", "exporter:exampleexporter[metrics]",
+	}, ids)
+	assert.Len(t, d.Edges, 9)
+
+	for _, e := range [][2]string{
+		{dagTracesReceiver, dagTracesCaps},
+		{dagTracesCaps, dagTracesProcessor},
+		{dagTracesProcessor, dagTracesFanout},
+		{dagTracesFanout, dagTracesExporter},
+		{dagTracesFanout, dagConnector},
+	} {
+		edge := findEdge(d, e[0], e[1])
+		require.NotNil(t, edge, "%s -> %s", e[0], e[1])
+		require.NotNil(t, edge.Items, "%s -> %s", e[0], e[1])
+		assert.Equal(t, int64(2), *edge.Items, "%s -> %s", e[0], e[1])
+	}
+	connEdge := findEdge(d, dagConnector, dagMetricsCaps)
+	require.NotNil(t, connEdge)
+	require.NotNil(t, connEdge.Items)
+	metricsEdge := findEdge(d, dagMetricsCaps, "processor:exampleprocessor/metrics[metrics]")
+	require.NotNil(t, metricsEdge)
+	assert.Equal(t, connEdge.Items, metricsEdge.Items)
+
+	// The counts of the reused components are kept on reload.
+	require.NoError(t, pg.Reload(context.Background(), cfg.settings(), &Host{Reporter: status.NewNopStatusReporter()}))
+	consumeTestTraces(t, pg, 1)
+	d, err = pg.dag()
+	require.NoError(t, err)
+	edge := findEdge(d, dagTracesFanout, dagTracesExporter)
+	require.NotNil(t, edge)
+	require.NotNil(t, edge.Items)
+	assert.Equal(t, int64(3), *edge.Items)
+}
+
+func TestGraphDAGGateDisabled(t *testing.T) {
+	setObsConsumerGateForTest(t, false)
+	pg, _ := newDAGTestGraph(t)
+	consumeTestTraces(t, pg, 2)
+
+	d, err := pg.dag()
+	require.NoError(t, err)
+	require.Len(t, d.Edges, 9)
+	for _, e := range d.Edges {
+		assert.Nil(t, e.Items)
+	}
+	assert.False(t, d.graphData().ItemCounts)
+}
+
+func TestGraphHandleGraphZPages(t *testing.T) {
+	setObsConsumerGateForTest(t, true)
+	pg, _ := newDAGTestGraph(t)
+	consumeTestTraces(t, pg, 2)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		pg.HandleGraphZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/pipelinegraphz"+query, http.NoBody))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec
+	}
+
+	rec := get("?format=json")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var d dag
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &d))
+	assert.Len(t, d.Nodes, 10)
+	assert.Contains(t, d.Nodes, dagNode{
+		ID:          dagTracesProcessor,
+		Kind:        "processor",
+		ComponentID: "exampleprocessor/traces",
+		PipelineID:  "traces",
+		Signal:      "traces",
+	})
+	assert.Contains(t, d.Nodes, dagNode{
+		ID:           dagConnector,
+		Kind:         "connector",
+		ComponentID:  "exampleconnector",
+		Signal:       "traces",
+		SignalOutput: "metrics",
+	})
+
+	rec = get("?format=dot")
+	assert.Contains(t, rec.Body.String(), "digraph pipelines {\n")
+	assert.Contains(t, rec.Body.String(), "\t\""+dagTracesReceiver+"\" [label=\"receiver:examplereceiver/traces\\ntraces\"];\n")
+	assert.Contains(t, rec.Body.String(), "\t\""+dagTracesReceiver+"\" -> \""+dagTracesCaps+"\" [label=\"2\"];\n")
+
+	rec = get("")
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<svg")
+	assert.Contains(t, rec.Body.String(), ">exporter:exampleexporter</text>")
+}
+
+func TestDAGGraphDataLayout(t *testing.T) {
+	d := &dag{
+		Nodes: []dagNode{
+			{ID: "a", Kind: "receiver", ComponentID: "a", Signal: "traces"},
+			{ID: "b", Kind: "capabilities", PipelineID: "traces", layer: 1},
+			{ID: "c", Kind: "exporter", ComponentID: "c", Signal: "traces", layer: 2},
+			{ID: "d", Kind: "exporter", ComponentID: "d", Signal: "traces", layer: 2},
+		},
+		Edges: []dagEdge{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "b", To: "d"}},
+	}
+	data := d.graphData()
+	require.Len(t, data.Nodes, 4)
+	assert.Equal(t, graphMargin, data.Nodes[0].X)
+	assert.Equal(t, graphMargin+graphNodeWidth+graphColumnGap, data.Nodes[1].X)
+	assert.Equal(t, data.Nodes[2].X, data.Nodes[3].X)
+	assert.Equal(t, data.Nodes[2].Y+graphNodeHeight+graphRowGap, data.Nodes[3].Y)
+	assert.Equal(t, "exporter:d", data.Nodes[3].Name)
+	assert.Equal(t, "traces", data.Nodes[3].Detail)
+	assert.Equal(t, data.Nodes[3].X+graphNodeWidth+graphMargin, data.Width)
+	assert.Equal(t, data.Nodes[3].Y+graphNodeHeight+graphMargin, data.Height)
+	require.Len(t, data.Edges, 3)
+	assert.Equal(t, data.Nodes[0].X+graphNodeWidth, data.Edges[0].X1)
+	assert.Equal(t, data.Nodes[1].X, data.Edges[0].X2)
+	assert.Empty(t, data.Edges[0].Label)
+}
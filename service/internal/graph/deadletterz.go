@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	// URL Params
+	zDeadLetterExporter = "exporter"
+	zDeadLetterIndex    = "index"
+	zDeadLetterLimit    = "limit"
+
+	// deadLetterDefaultLimit is the number of requests listed per exporter when the limit is not set.
+	deadLetterDefaultLimit = 100
+)
+
+// deadLetterNode is an exporter node of the graph whose component may store requests in a dead letter queue.
+type deadLetterNode struct {
+	// ID identifies the exporter and its pipeline type, for instance "exporter:otlp[traces]".
+	ID    string
+	queue xexporter.DeadLetterQueue
+}
+
+// deadLetterNodes returns the exporter nodes implementing [xexporter.DeadLetterQueue], sorted by ID.
+func (g *Graph) deadLetterNodes() []deadLetterNode {
+	var nodes []deadLetterNode
+	it := g.componentGraph.Nodes()
+	for it.Next() {
+		n, ok := it.Node().(*exporterNode)
+		if !ok {
+			continue
+		}
+		if dlq, ok := n.Component.(xexporter.DeadLetterQueue); ok {
+			nodes = append(nodes, deadLetterNode{
+				ID:    "exporter:" + n.componentID.String() + "[" + n.pipelineType.String() + "]",
+				queue: dlq,
+			})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// HandleDeadLetterZPages lists the requests stored in the dead letter queues of the exporters. When the
+// service.zpagesDeadLetterReplay feature gate is enabled, a POST request puts the requests of the exporter with the
+// given indexes, or all of them if no index is given, back in its sending queue.
+func (g *Graph) HandleDeadLetterZPages(w http.ResponseWriter, r *http.Request) {
+	replay := deadLetterReplayFeatureGate.IsEnabled()
+	if r.Method == http.MethodPost && !replay {
+		http.Error(w, "replaying the dead letter queues requires the "+deadLetterReplayFeatureGate.ID()+" feature gate",
+			http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// The lock is not held while accessing the storage, the components of a reloaded graph report they are stopped.
+	g.mu.RLock()
+	nodes := g.deadLetterNodes()
+	g.mu.RUnlock()
+	id := r.Form.Get(zDeadLetterExporter)
+	if id != "" {
+		i := sort.Search(len(nodes), func(i int) bool { return nodes[i].ID >= id })
+		if i == len(nodes) || nodes[i].ID != id {
+			http.Error(w, "unknown exporter "+id, http.StatusNotFound)
+			return
+		}
+		nodes = nodes[i : i+1]
+	}
+
+	if r.Method == http.MethodPost {
+		if id == "" {
+			http.Error(w, "the exporter to replay is not set", http.StatusBadRequest)
+			return
+		}
+		indexes := make([]uint64, 0, len(r.Form[zDeadLetterIndex]))
+		for _, value := range r.Form[zDeadLetterIndex] {
+			index, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				http.Error(w, "index must be a non-negative integer", http.StatusBadRequest)
+				return
+			}
+			indexes = append(indexes, index)
+		}
+		replayed, err := nodes[0].queue.ReplayDeadLetters(r.Context(), indexes...)
+		switch {
+		case errors.Is(err, xexporter.ErrNoDeadLetterQueue):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case err != nil:
+			http.Error(w, fmt.Sprintf("replayed %d requests: %v", replayed, err), http.StatusInternalServerError)
+		default:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			fmt.Fprintf(w, "replayed %d requests\n", replayed)
+		}
+		return
+	}
+
+	limit := deadLetterDefaultLimit
+	if l := r.Form.Get(zDeadLetterLimit); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Dead Letter Queues"})
+	params := [][2]string{
+		{zDeadLetterExporter, "Only lists the requests of the exporter, required to replay them"},
+		{zDeadLetterLimit, "Maximum number of requests listed per exporter, defaults to " + strconv.Itoa(deadLetterDefaultLimit)},
+	}
+	if replay {
+		params = append(params, [2]string{zDeadLetterIndex, "Index of a request to replay with a POST request, all the requests are replayed if not set"})
+	}
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Parameters", Properties: params})
+	for i := range nodes {
+		letters, err := nodes[i].queue.InspectDeadLetters(r.Context(), limit)
+		if errors.Is(err, xexporter.ErrNoDeadLetterQueue) {
+			continue
+		}
+		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+			Name:              nodes[i].ID,
+			ComponentEndpoint: "?" + url.Values{zDeadLetterExporter: {nodes[i].ID}}.Encode(),
+			Link:              id == "",
+		})
+		properties := make([][2]string, 0, len(letters)+1)
+		if err != nil {
+			properties = append(properties, [2]string{"error", err.Error()})
+		}
+		for _, letter := range letters {
+			properties = append(properties, [2]string{
+				strconv.FormatUint(letter.Index, 10),
+				fmt.Sprintf("%d items, %d bytes", letter.Items, letter.Bytes),
+			})
+		}
+		zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Requests", Properties: properties})
+	}
+	zpages.WriteHTMLPageFooter(w)
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var (
+	deadLetterExporterID       = component.MustNewID("deadletter")
+	deadLetterNoQueueID        = component.MustNewIDWithName("deadletter", "none")
+	deadLetterExporterNode     = "exporter:deadletter[traces]"
+	deadLetterNoQueueNode      = "exporter:deadletter/none[traces]"
+	errDeadLetterQueueRejected = errors.New("sending queue is full")
+)
+
+type deadLetterConfig struct {
+	noQueue bool
+}
+
+func newDeadLetterExporterFactory() exporter.Factory {
+	return xexporter.NewFactory(deadLetterExporterID.Type(),
+		func() component.Config { return &deadLetterConfig{} },
+		xexporter.WithTraces(func(_ context.Context, _ exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			exp := &deadLetterExporter{Traces: consumertest.NewNop(), noQueue: cfg.(*deadLetterConfig).noQueue}
+			for i := range 3 {
+				exp.letters = append(exp.letters, xexporter.DeadLetter{Index: uint64(i), Items: i + 1, Bytes: 10 * (i + 1)})
+			}
+			return exp, nil
+		}, component.StabilityLevelDevelopment),
+	)
+}
+
+type deadLetterExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+	noQueue bool
+	letters []xexporter.DeadLetter
+}
+
+func (e *deadLetterExporter) InspectDeadLetters(_ context.Context, limit int) ([]xexporter.DeadLetter, error) {
+	if e.noQueue {
+		return nil, xexporter.ErrNoDeadLetterQueue
+	}
+	return e.letters[:min(limit, len(e.letters))], nil
+}
+
+func (e *deadLetterExporter) ReplayDeadLetters(_ context.Context, indexes ...uint64) (int, error) {
+	if e.noQueue {
+		return 0, xexporter.ErrNoDeadLetterQueue
+	}
+	var kept []xexporter.DeadLetter
+	replayed := 0
+	for _, letter := range e.letters {
+		if len(indexes) > 0 && !slices.Contains(indexes, letter.Index) {
+			kept = append(kept, letter)
+			continue
+		}
+		if letter.Items == 3 {
+			return replayed, errDeadLetterQueueRejected
+		}
+		replayed++
+	}
+	e.letters = kept
+	return replayed, nil
+}
+
+func newDeadLetterTestGraph(t *testing.T) *Graph {
+	receiverID := component.MustNewID("examplereceiver")
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				receiverID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				deadLetterExporterID: &deadLetterConfig{},
+				deadLetterNoQueueID:  &deadLetterConfig{noQueue: true},
+			},
+			map[component.Type]exporter.Factory{deadLetterExporterID.Type(): newDeadLetterExporterFactory()},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{receiverID},
+				Exporters: []component.ID{deadLetterExporterID, deadLetterNoQueueID},
+			},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	return pg
+}
+
+func setDeadLetterReplayGateForTest(t *testing.T, enabled bool) {
+	initial := deadLetterReplayFeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(deadLetterReplayFeatureGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(deadLetterReplayFeatureGate.ID(), initial))
+	})
+}
+
+func TestGraphHandleDeadLetterZPages(t *testing.T) {
+	setDeadLetterReplayGateForTest(t, true)
+	pg := newDeadLetterTestGraph(t)
+
+	rec := httptest.NewRecorder()
+	pg.HandleDeadLetterZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/deadletterz?limit=2", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), deadLetterExporterNode)
+	assert.Contains(t, rec.Body.String(), "2 items, 20 bytes")
+	assert.NotContains(t, rec.Body.String(), "3 items, 30 bytes")
+	// Exporters configured without a dead letter queue are not listed.
+	assert.NotContains(t, rec.Body.String(), deadLetterNoQueueNode)
+
+	for _, tt := range []struct {
+		method string
+		query  url.Values
+		code   int
+	}{
+		{method: http.MethodGet, query: url.Values{zDeadLetterExporter: {"exporter:unknown[traces]"}}, code: http.StatusNotFound},
+		{method: http.MethodGet, query: url.Values{zDeadLetterLimit: {"0"}}, code: http.StatusBadRequest},
+		{method: http.MethodPost, query: url.Values{}, code: http.StatusBadRequest},
+		{method: http.MethodPost, query: url.Values{zDeadLetterExporter: {deadLetterNoQueueNode}}, code: http.StatusNotFound},
+		{method: http.MethodPost, query: url.Values{zDeadLetterExporter: {deadLetterExporterNode}, zDeadLetterIndex: {"-1"}}, code: http.StatusBadRequest},
+	} {
+		rec = httptest.NewRecorder()
+		pg.HandleDeadLetterZPages(rec, httptest.NewRequest(tt.method, "/debug/deadletterz?"+tt.query.Encode(), http.NoBody))
+		assert.Equal(t, tt.code, rec.Code, tt.method+" "+tt.query.Encode())
+	}
+}
+
+func TestGraphHandleDeadLetterZPagesReplayFeatureGate(t *testing.T) {
+	setDeadLetterReplayGateForTest(t, false)
+	pg := newDeadLetterTestGraph(t)
+
+	// The page is read-only by default, since replaying changes the state of the exporters.
+	rec := httptest.NewRecorder()
+	pg.HandleDeadLetterZPages(rec, httptest.NewRequest(http.MethodPost,
+		"/debug/deadletterz?"+url.Values{zDeadLetterExporter: {deadLetterExporterNode}}.Encode(), http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = httptest.NewRecorder()
+	pg.HandleDeadLetterZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/deadletterz", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "3 items, 30 bytes")
+}
+
+func TestGraphHandleDeadLetterZPagesReplay(t *testing.T) {
+	setDeadLetterReplayGateForTest(t, true)
+	pg := newDeadLetterTestGraph(t)
+	form := url.Values{zDeadLetterExporter: {deadLetterExporterNode}, zDeadLetterIndex: {"0", "1"}}
+	req := httptest.NewRequest(http.MethodPost, "/debug/deadletterz", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	pg.HandleDeadLetterZPages(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "replayed 2 requests\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	pg.HandleDeadLetterZPages(rec, httptest.NewRequest(http.MethodGet,
+		"/debug/deadletterz?"+url.Values{zDeadLetterExporter: {deadLetterExporterNode}}.Encode(), http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "1 items, 10 bytes")
+	assert.Contains(t, rec.Body.String(), "3 items, 30 bytes")
+
+	// The remaining request is rejected by the sending queue.
+	rec = httptest.NewRecorder()
+	pg.HandleDeadLetterZPages(rec, httptest.NewRequest(http.MethodPost,
+		"/debug/deadletterz?"+url.Values{zDeadLetterExporter: {deadLetterExporterNode}}.Encode(), http.NoBody))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "replayed 0 requests: sending queue is full\n", rec.Body.String())
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/shutdown"
+)
+
+// drainModeAttribute is the attribute of the stopping status event of a component being drained.
+const drainModeAttribute = "drain.mode"
+
+// drainer drains the components implementing [xexporter.Drainer] before they are shut down.
+// All the components share the deadline of the drain phase.
+type drainer struct {
+	ctx    context.Context
+	mode   xexporter.DrainMode
+	logger *zap.Logger
+}
+
+// newDrainer returns a drainer bounded by the drain timeout of cfg, or nil if the drain phase is disabled.
+// The returned function must be called once the components are shut down.
+func newDrainer(ctx context.Context, cfg shutdown.Config, logger *zap.Logger) (*drainer, context.CancelFunc) {
+	if cfg.DrainTimeout <= 0 {
+		return nil, func() {}
+	}
+	mode := cfg.Queue
+	if mode == "" {
+		mode = xexporter.DrainModeFlush
+	}
+	drainCtx, cancel := context.WithTimeout(ctx, cfg.DrainTimeout)
+	return &drainer{ctx: drainCtx, mode: mode, logger: logger}, cancel
+}
+
+// drain drains the component of the node if it supports it, reporting the stopping status with the drain mode.
+// It returns false if the component was not drained, and the stopping status is not reported.
+// A failure to drain is reported as a recoverable error, since the component is shut down anyway.
+func (d *drainer) drain(reporter status.Reporter, instanceID *componentstatus.InstanceID, node graph.Node) bool {
+	if d == nil {
+		return false
+	}
+	drainable, ok := nodeDrainer(node)
+	if !ok {
+		return false
+	}
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr(drainModeAttribute, string(d.mode))
+	reporter.ReportStatus(instanceID, componentstatus.NewEvent(componentstatus.StatusStopping, componentstatus.WithAttributes(attrs)))
+	if err := drainable.Drain(d.ctx, d.mode); err != nil {
+		err = fmt.Errorf("failed to drain: %w", err)
+		reporter.ReportStatus(instanceID, componentstatus.NewRecoverableErrorEvent(err))
+		d.logger.Warn("Failed to drain component",
+			zap.Error(err),
+			zap.String("type", instanceID.Kind().String()),
+			zap.String("id", instanceID.ComponentID().String()),
+		)
+		reporter.ReportStatus(instanceID, componentstatus.NewEvent(componentstatus.StatusStopping))
+	}
+	return true
+}
+
+// validateSpill returns an error for each component which cannot keep the data left in its memory queue when the
+// drain phase is enabled with the spill mode.
+func (g *Graph) validateSpill() error {
+	if g.settings.Shutdown.DrainTimeout <= 0 || g.settings.Shutdown.Queue != xexporter.DrainModeSpill {
+		return nil
+	}
+	var errs error
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		drainable, ok := nodeDrainer(nodes.Node())
+		if !ok {
+			continue
+		}
+		validator, ok := drainable.(xexporter.SpillValidator)
+		if !ok {
+			continue
+		}
+		if err := validator.ValidateSpill(); err != nil {
+			instanceID := g.instanceIDs[nodes.Node().ID()]
+			errs = multierr.Append(errs, fmt.Errorf("%s %q cannot use the %q shutdown queue mode: %w",
+				strings.ToLower(instanceID.Kind().String()), instanceID.ComponentID(), xexporter.DrainModeSpill, err))
+		}
+	}
+	return errs
+}
+
+// nodeDrainer returns the component of the node if it implements [xexporter.Drainer]. The receivers are not
+// drained, since they are shut down first.
+func nodeDrainer(node graph.Node) (xexporter.Drainer, bool) {
+	var comp component.Component
+	switch n := node.(type) {
+	case *processorNode:
+		comp = n.Component
+	case *exporterNode:
+		comp = n.Component
+	case *connectorNode:
+		comp = n.Component
+	}
+	drainable, ok := comp.(xexporter.Drainer)
+	return drainable, ok
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/shutdown"
+)
+
+var drainExporterID = component.MustNewID("drain")
+
+type drainConfig struct {
+	err      error
+	spillErr error
+}
+
+func newDrainExporterFactory() exporter.Factory {
+	return xexporter.NewFactory(drainExporterID.Type(),
+		func() component.Config { return &drainConfig{} },
+		xexporter.WithTraces(func(_ context.Context, _ exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			dCfg := cfg.(*drainConfig)
+			return &drainExporter{Traces: consumertest.NewNop(), err: dCfg.err, spillErr: dCfg.spillErr}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+}
+
+type drainExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+	err      error
+	spillErr error
+	modes    []xexporter.DrainMode
+}
+
+func (e *drainExporter) ValidateSpill() error {
+	return e.spillErr
+}
+
+func (e *drainExporter) Drain(ctx context.Context, mode xexporter.DrainMode) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("the drain phase is not bounded")
+	}
+	e.modes = append(e.modes, mode)
+	return e.err
+}
+
+type statusRecord struct {
+	id    component.ID
+	event *componentstatus.Event
+}
+
+func TestGraphShutdownAllDrain(t *testing.T) {
+	tests := []struct {
+		name       string
+		shutdown   shutdown.Config
+		drainErr   error
+		wantModes  []xexporter.DrainMode
+		wantStatus []componentstatus.Status
+	}{
+		{
+			name:       "disabled",
+			shutdown:   shutdown.NewDefaultConfig(),
+			wantStatus: []componentstatus.Status{componentstatus.StatusStopping, componentstatus.StatusStopped},
+		},
+		{
+			name:       "flush",
+			shutdown:   shutdown.Config{DrainTimeout: time.Second},
+			wantModes:  []xexporter.DrainMode{xexporter.DrainModeFlush},
+			wantStatus: []componentstatus.Status{componentstatus.StatusStopping, componentstatus.StatusStopped},
+		},
+		{
+			name:       "spill",
+			shutdown:   shutdown.Config{DrainTimeout: time.Second, Queue: xexporter.DrainModeSpill},
+			wantModes:  []xexporter.DrainMode{xexporter.DrainModeSpill},
+			wantStatus: []componentstatus.Status{componentstatus.StatusStopping, componentstatus.StatusStopped},
+		},
+		{
+			name:      "error",
+			shutdown:  shutdown.Config{DrainTimeout: time.Second},
+			drainErr:  context.DeadlineExceeded,
+			wantModes: []xexporter.DrainMode{xexporter.DrainModeFlush},
+			wantStatus: []componentstatus.Status{
+				componentstatus.StatusStopping,
+				componentstatus.StatusRecoverableError,
+				componentstatus.StatusStopping,
+				componentstatus.StatusStopped,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newReloadConfig()
+			set := cfg.settings()
+			set.ExporterBuilder = builders.NewExporter(
+				map[component.ID]component.Config{drainExporterID: &drainConfig{err: tt.drainErr}},
+				map[component.Type]exporter.Factory{drainExporterID.Type(): newDrainExporterFactory()})
+			set.PipelineConfigs = pipelines.Config{
+				tracesID: {
+					Receivers:  []component.ID{tracesReceiverID},
+					Processors: []component.ID{tracesProcID},
+					Exporters:  []component.ID{drainExporterID},
+				},
+			}
+			set.Shutdown = tt.shutdown
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+
+			var records []statusRecord
+			reporter := status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+				records = append(records, statusRecord{id: id.ComponentID(), event: ev})
+			}, func(err error) { t.Error(err) })
+			require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: reporter}))
+			records = nil
+			require.NoError(t, pg.ShutdownAll(context.Background(), reporter))
+
+			exp := pg.GetExporters()[tracesID.Signal()][drainExporterID].(*drainExporter)
+			assert.Equal(t, tt.wantModes, exp.modes)
+
+			var statuses []componentstatus.Status
+			for i, record := range records {
+				if record.id != drainExporterID {
+					continue
+				}
+				statuses = append(statuses, record.event.Status())
+				if len(statuses) == 1 {
+					// The exporter is drained and stopped after its upstream processor.
+					assert.Contains(t, records[:i], statusRecord{id: tracesProcID, event: findStatus(records, tracesProcID, componentstatus.StatusStopped)})
+					mode, ok := record.event.Attributes().Get(drainModeAttribute)
+					assert.Equal(t, tt.wantModes != nil, ok)
+					if ok {
+						assert.Equal(t, string(tt.wantModes[0]), mode.Str())
+					}
+				}
+				if record.event.Status() == componentstatus.StatusRecoverableError {
+					require.ErrorIs(t, record.event.Err(), tt.drainErr)
+				}
+			}
+			assert.Equal(t, tt.wantStatus, statuses)
+		})
+	}
+}
+
+func TestGraphReloadDrain(t *testing.T) {
+	cfg := newReloadConfig()
+	newSettings := func(drainErr error) Settings {
+		set := cfg.settings()
+		set.ExporterBuilder = builders.NewExporter(
+			map[component.ID]component.Config{drainExporterID: &drainConfig{err: drainErr}},
+			map[component.Type]exporter.Factory{drainExporterID.Type(): newDrainExporterFactory()})
+		set.PipelineConfigs = pipelines.Config{
+			tracesID: {
+				Receivers: []component.ID{tracesReceiverID},
+				Exporters: []component.ID{drainExporterID},
+			},
+		}
+		set.Shutdown = shutdown.Config{DrainTimeout: time.Second, Queue: xexporter.DrainModeSpill}
+		return set
+	}
+	pg, err := Build(context.Background(), newSettings(nil))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter()}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	oldExp := pg.GetExporters()[tracesID.Signal()][drainExporterID].(*drainExporter)
+
+	// The replaced exporter is drained before being shut down.
+	require.NoError(t, pg.Reload(context.Background(), newSettings(errors.New("changed")), host))
+	assert.Equal(t, []xexporter.DrainMode{xexporter.DrainModeSpill}, oldExp.modes)
+	newExp := pg.GetExporters()[tracesID.Signal()][drainExporterID].(*drainExporter)
+	assert.Empty(t, newExp.modes)
+	require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+}
+
+func TestGraphBuildValidateSpill(t *testing.T) {
+	spillErr := errors.New("no persistent storage")
+	tests := []struct {
+		name     string
+		shutdown shutdown.Config
+		wantErr  string
+	}{
+		{
+			name:     "spill",
+			shutdown: shutdown.Config{DrainTimeout: time.Second, Queue: xexporter.DrainModeSpill},
+			wantErr:  `exporter "drain" cannot use the "spill" shutdown queue mode: no persistent storage`,
+		},
+		{
+			name:     "flush",
+			shutdown: shutdown.Config{DrainTimeout: time.Second, Queue: xexporter.DrainModeFlush},
+		},
+		{
+			name:     "drain disabled",
+			shutdown: shutdown.Config{Queue: xexporter.DrainModeSpill},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newReloadConfig().settings()
+			set.ExporterBuilder = builders.NewExporter(
+				map[component.ID]component.Config{drainExporterID: &drainConfig{spillErr: spillErr}},
+				map[component.Type]exporter.Factory{drainExporterID.Type(): newDrainExporterFactory()})
+			set.PipelineConfigs = pipelines.Config{
+				tracesID: {
+					Receivers: []component.ID{tracesReceiverID},
+					Exporters: []component.ID{drainExporterID},
+				},
+			}
+			set.Shutdown = tt.shutdown
+			_, err := Build(context.Background(), set)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func findStatus(records []statusRecord, id component.ID, st componentstatus.Status) *componentstatus.Event {
+	for _, record := range records {
+		if record.id == id && record.event.Status() == st {
+			return record.event
+		}
+	}
+	return nil
+}
@@ -10,6 +10,8 @@
 // [Graph.StartAll] starts all components in each pipeline.
 //
 // [Graph.ShutdownAll] stops all components in each pipeline.
+//
+// [Graph.Reload] updates the pipelines to a new configuration, restarting only the components that changed.
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
@@ -17,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -37,7 +40,9 @@ import (
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/tap"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/shutdown"
 )
 
 // Settings holds configuration for building builtPipelines.
@@ -53,10 +58,16 @@ type Settings struct {
 	// PipelineConfigs is a map of component.ID to PipelineConfig.
 	PipelineConfigs pipelines.Config
 
+	// Shutdown defines how the components are drained before they are shut down.
+	Shutdown shutdown.Config
+
 	ReportStatus status.ServiceStatusFunc
 }
 
 type Graph struct {
+	// mu guards the fields replaced by Reload, which are read by the zpages handlers from other goroutines.
+	mu sync.RWMutex
+
 	// All component instances represented as nodes, with directed edges indicating data flow.
 	componentGraph *simple.DirectedGraph
 
@@ -67,6 +78,15 @@ type Graph struct {
 	instanceIDs map[int64]*componentstatus.InstanceID
 
 	telemetry component.TelemetrySettings
+
+	// The settings the graph was built with, compared to the new ones on reload.
+	settings Settings
+
+	// The points where the data produced by the components can be tapped, kept across reloads.
+	taps *tap.Registry
+
+	// The nodes whose component was shut down by a failed Reload, skipped by ShutdownAll.
+	stopped map[int64]bool
 }
 
 // Build builds a full pipeline graph.
@@ -77,6 +97,8 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
 		telemetry:      set.Telemetry,
+		settings:       set,
+		taps:           tap.NewRegistry(),
 	}
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
@@ -88,8 +110,10 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		return nil, err
 	}
 	pipelines.createEdges()
-	err := pipelines.buildComponents(ctx, set)
-	return pipelines, err
+	if err := pipelines.buildComponents(ctx, set); err != nil {
+		return pipelines, err
+	}
+	return pipelines, pipelines.validateSpill()
 }
 
 // Creates a node for each instance of a component and adds it to the graph.
@@ -297,77 +321,85 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	}
 
 	for i := len(nodes) - 1; i >= 0; i-- {
-		node := nodes[i]
+		if n, ok := nodes[i].(*capabilitiesNode); ok {
+			n.setConsumer(g.capabilitiesConsumer(n))
+			continue
+		}
+		if err = g.buildComponent(ctx, set, nodes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		switch n := node.(type) {
-		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
-		case *processorNode:
-			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
-		case *exporterNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
-		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
-		case *capabilitiesNode:
-			capability := consumer.Capabilities{
-				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
-				MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
+// buildComponent instantiates the component of the node, or the consumer of a fanout node, hooking it up to the
+// consumers of the next nodes, which must already be built.
+func (g *Graph) buildComponent(ctx context.Context, set Settings, node graph.Node) error {
+	switch n := node.(type) {
+	case *receiverNode:
+		n.tapPoint = g.taps.Point(newDAGNode(n).ID)
+		return n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+	case *processorNode:
+		n.tapPoint = g.taps.Point(newDAGNode(n).ID)
+		// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
+		return n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+	case *exporterNode:
+		return n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
+	case *connectorNode:
+		n.tapPoint = g.taps.Point(newDAGNode(n).ID)
+		return n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+	case *fanOutNode:
+		nexts := g.nextConsumers(n.ID())
+		switch n.pipelineID.Signal() {
+		case pipeline.SignalTraces:
+			consumers := make([]consumer.Traces, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Traces))
 			}
-			for _, proc := range g.pipelines[n.pipelineID].processors {
-				capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+			n.baseConsumer = fanoutconsumer.NewTraces(consumers)
+		case pipeline.SignalMetrics:
+			consumers := make([]consumer.Metrics, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Metrics))
 			}
-			next := g.nextConsumers(n.ID())[0]
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
-				n.baseConsumer = cc
-				n.ConsumeTracesFunc = cc.ConsumeTraces
-			case pipeline.SignalMetrics:
-				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
-				n.baseConsumer = cc
-				n.ConsumeMetricsFunc = cc.ConsumeMetrics
-			case pipeline.SignalLogs:
-				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
-				n.baseConsumer = cc
-				n.ConsumeLogsFunc = cc.ConsumeLogs
-			case xpipeline.SignalProfiles:
-				cc := capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
-				n.baseConsumer = cc
-				n.ConsumeProfilesFunc = cc.ConsumeProfiles
+			n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
+		case pipeline.SignalLogs:
+			consumers := make([]consumer.Logs, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Logs))
 			}
-		case *fanOutNode:
-			nexts := g.nextConsumers(n.ID())
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				consumers := make([]consumer.Traces, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Traces))
-				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
-			case pipeline.SignalMetrics:
-				consumers := make([]consumer.Metrics, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Metrics))
-				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
-			case pipeline.SignalLogs:
-				consumers := make([]consumer.Logs, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Logs))
-				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
-			case xpipeline.SignalProfiles:
-				consumers := make([]xconsumer.Profiles, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(xconsumer.Profiles))
-				}
-				n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
+			n.baseConsumer = fanoutconsumer.NewLogs(consumers)
+		case xpipeline.SignalProfiles:
+			consumers := make([]xconsumer.Profiles, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(xconsumer.Profiles))
 			}
+			n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
 		}
-		if err != nil {
-			return err
-		}
+	}
+	return nil
+}
+
+// capabilitiesConsumer returns the consumer the capabilities node passes the data of its pipeline to,
+// presenting the aggregated capabilities of the processors and exporters of the pipeline.
+func (g *Graph) capabilitiesConsumer(n *capabilitiesNode) baseConsumer {
+	capability := consumer.Capabilities{
+		// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
+		MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
+	}
+	for _, proc := range g.pipelines[n.pipelineID].processors {
+		capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+	}
+	next := g.nextConsumers(n.ID())[0]
+	switch n.pipelineID.Signal() {
+	case pipeline.SignalTraces:
+		return capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
+	case pipeline.SignalMetrics:
+		return capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
+	case pipeline.SignalLogs:
+		return capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
+	case xpipeline.SignalProfiles:
+		return capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
 	}
 	return nil
 }
@@ -415,37 +447,43 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 	// are started before upstream components. This ensures that each
 	// component's consumer is ready to consume.
 	for i := len(nodes) - 1; i >= 0; i-- {
-		node := nodes[i]
-		comp, ok := node.(component.Component)
-
-		if !ok {
-			// Skip capabilities/fanout nodes
-			continue
+		if err = g.startComponent(ctx, host, nodes[i]); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		instanceID := g.instanceIDs[node.ID()]
+// startComponent starts the component of the node, reporting its status.
+func (g *Graph) startComponent(ctx context.Context, host *Host, node graph.Node) error {
+	comp, ok := node.(component.Component)
+	if !ok {
+		// Skip capabilities/fanout nodes
+		return nil
+	}
+
+	instanceID := g.instanceIDs[node.ID()]
+	host.Reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStarting),
+	)
+
+	if compErr := comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); compErr != nil {
 		host.Reporter.ReportStatus(
 			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStarting),
+			componentstatus.NewPermanentErrorEvent(compErr),
 		)
-
-		if compErr := comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); compErr != nil {
-			host.Reporter.ReportStatus(
-				instanceID,
-				componentstatus.NewPermanentErrorEvent(compErr),
+		// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
+		g.telemetry.Logger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).
+			Error("Failed to start component",
+				zap.Error(compErr),
+				zap.String("type", instanceID.Kind().String()),
+				zap.String("id", instanceID.ComponentID().String()),
 			)
-			// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
-			g.telemetry.Logger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).
-				Error("Failed to start component",
-					zap.Error(compErr),
-					zap.String("type", instanceID.Kind().String()),
-					zap.String("id", instanceID.ComponentID().String()),
-				)
-			return fmt.Errorf("failed to start %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), compErr)
-		}
-
-		host.Reporter.ReportOKIfStarting(instanceID)
+		return fmt.Errorf("failed to start %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), compErr)
 	}
+
+	host.Reporter.ReportOKIfStarting(instanceID)
 	return nil
 }
 
@@ -459,40 +497,55 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
 	// before the consumer is stopped.
+	// The components supporting it are also drained before being
+	// stopped, once their upstream components are stopped.
+	d, cancel := newDrainer(ctx, g.settings.Shutdown, g.telemetry.Logger)
+	defer cancel()
 	var errs error
 	for i := 0; i < len(nodes); i++ {
-		node := nodes[i]
-		comp, ok := node.(component.Component)
-
-		if !ok {
-			// Skip capabilities/fanout nodes
+		if g.stopped[nodes[i].ID()] {
 			continue
 		}
+		errs = multierr.Append(errs, g.shutdownComponent(ctx, d, reporter, nodes[i]))
+	}
+	return errs
+}
 
-		instanceID := g.instanceIDs[node.ID()]
+// shutdownComponent shuts down the component of the node, reporting its status.
+// If d is not nil, the component is drained first, if it supports it.
+func (g *Graph) shutdownComponent(ctx context.Context, d *drainer, reporter status.Reporter, node graph.Node) error {
+	comp, ok := node.(component.Component)
+	if !ok {
+		// Skip capabilities/fanout nodes
+		return nil
+	}
+
+	instanceID := g.instanceIDs[node.ID()]
+	if !d.drain(reporter, instanceID, node) {
 		reporter.ReportStatus(
 			instanceID,
 			componentstatus.NewEvent(componentstatus.StatusStopping),
 		)
+	}
 
-		if compErr := comp.Shutdown(ctx); compErr != nil {
-			errs = multierr.Append(errs, compErr)
-			reporter.ReportStatus(
-				instanceID,
-				componentstatus.NewPermanentErrorEvent(compErr),
-			)
-			continue
-		}
-
+	if compErr := comp.Shutdown(ctx); compErr != nil {
 		reporter.ReportStatus(
 			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStopped),
+			componentstatus.NewPermanentErrorEvent(compErr),
 		)
+		return compErr
 	}
-	return errs
+
+	reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopped),
+	)
+	return nil
 }
 
 func (g *Graph) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	exportersMap := make(map[pipeline.Signal]map[component.ID]component.Component)
 	exportersMap[pipeline.SignalTraces] = make(map[component.ID]component.Component)
 	exportersMap[pipeline.SignalMetrics] = make(map[component.ID]component.Component)
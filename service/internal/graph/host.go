@@ -88,10 +88,31 @@ func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID
 
 const (
 	// Paths
-	zServicePath   = "servicez"
-	zPipelinePath  = "pipelinez"
-	zExtensionPath = "extensionz"
-	zFeaturePath   = "featurez"
+	zServicePath       = "servicez"
+	zPipelinePath      = "pipelinez"
+	zPipelineGraphPath = "pipelinegraphz"
+	zTapPath           = "tapz"
+	zDeadLetterPath    = "deadletterz"
+	zExtensionPath     = "extensionz"
+	zFeaturePath       = "featurez"
+)
+
+// tapzFeatureGate enables the tapz page, which exposes a sample of the data flowing through the pipelines.
+var tapzFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"service.zpagesTapz",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("Enables the tapz zpage, streaming a sample of the data of the pipelines, "+
+		"which may contain sensitive data."),
+)
+
+// deadLetterReplayFeatureGate enables replaying the requests of the dead letter queues from the deadletterz page.
+var deadLetterReplayFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"service.zpagesDeadLetterReplay",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.136.0"),
+	featuregate.WithRegisterDescription("Enables replaying the requests of the dead letter queues of the exporters "+
+		"with POST requests to the deadletterz zpage."),
 )
 
 // InfoVar is a singleton instance of the Info struct.
@@ -110,6 +131,11 @@ func init() {
 func (host *Host) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.Pipelines.HandleZPages)
+	mux.HandleFunc(path.Join(pathPrefix, zPipelineGraphPath), host.Pipelines.HandleGraphZPages)
+	if tapzFeatureGate.IsEnabled() {
+		mux.HandleFunc(path.Join(pathPrefix, zTapPath), host.Pipelines.HandleTapZPages)
+	}
+	mux.HandleFunc(path.Join(pathPrefix, zDeadLetterPath), host.Pipelines.HandleDeadLetterZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.ServiceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
 }
@@ -124,6 +150,23 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Pipeline Graph",
+		ComponentEndpoint: zPipelineGraphPath,
+		Link:              true,
+	})
+	if tapzFeatureGate.IsEnabled() {
+		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+			Name:              "Tap",
+			ComponentEndpoint: zTapPath,
+			Link:              true,
+		})
+	}
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Dead Letter Queues",
+		ComponentEndpoint: zDeadLetterPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
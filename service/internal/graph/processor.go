@@ -6,6 +6,7 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -19,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/tap"
 )
 
 var _ consumerNode = (*processorNode)(nil)
@@ -31,6 +33,10 @@ type processorNode struct {
 	pipelineID  pipeline.ID
 	component.Component
 	consumer baseConsumer
+	// producedItems counts the items passed to the next consumer.
+	producedItems *atomic.Int64
+	// tapPoint samples the data passed to the next consumer, for the tapz page.
+	tapPoint *tap.Point
 }
 
 func newProcessorNode(pipelineID pipeline.ID, procID component.ID) *processorNode {
@@ -62,6 +68,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		return err
 	}
 
+	n.producedItems = &atomic.Int64{}
 	producedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ProcessorProducedItems,
 		SizeCounter: tb.ProcessorProducedSize,
@@ -76,7 +83,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 	switch n.pipelineID.Signal() {
 	case pipeline.SignalTraces:
 		n.Component, err = builder.CreateTraces(ctx, set,
-			obsconsumer.NewTraces(next.(consumer.Traces), producedSettings),
+			obsconsumer.NewTraces(n.tapPoint.Traces(next.(consumer.Traces)), producedSettings, obsconsumer.WithItemCount(n.producedItems)),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
@@ -85,7 +92,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		n.consumer = refconsumer.NewTraces(n.consumer.(consumer.Traces))
 	case pipeline.SignalMetrics:
 		n.Component, err = builder.CreateMetrics(ctx, set,
-			obsconsumer.NewMetrics(next.(consumer.Metrics), producedSettings))
+			obsconsumer.NewMetrics(n.tapPoint.Metrics(next.(consumer.Metrics)), producedSettings, obsconsumer.WithItemCount(n.producedItems)))
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
@@ -93,7 +100,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		n.consumer = refconsumer.NewMetrics(n.consumer.(consumer.Metrics))
 	case pipeline.SignalLogs:
 		n.Component, err = builder.CreateLogs(ctx, set,
-			obsconsumer.NewLogs(next.(consumer.Logs), producedSettings))
+			obsconsumer.NewLogs(n.tapPoint.Logs(next.(consumer.Logs)), producedSettings, obsconsumer.WithItemCount(n.producedItems)))
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
@@ -101,7 +108,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		n.consumer = refconsumer.NewLogs(n.consumer.(consumer.Logs))
 	case xpipeline.SignalProfiles:
 		n.Component, err = builder.CreateProfiles(ctx, set,
-			obsconsumer.NewProfiles(next.(xconsumer.Profiles), producedSettings))
+			obsconsumer.NewProfiles(n.tapPoint.Profiles(next.(xconsumer.Profiles)), producedSettings, obsconsumer.WithItemCount(n.producedItems)))
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
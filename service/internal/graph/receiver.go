@@ -6,6 +6,7 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -19,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
+	"go.opentelemetry.io/collector/service/internal/tap"
 )
 
 // A receiver instance can be shared by multiple pipelines of the same type.
@@ -28,6 +30,10 @@ type receiverNode struct {
 	componentID  component.ID
 	pipelineType pipeline.Signal
 	component.Component
+	// producedItems counts the items passed to the pipelines.
+	producedItems *atomic.Int64
+	// tapPoint samples the data passed to the pipelines, for the tapz page.
+	tapPoint *tap.Point
 }
 
 func newReceiverNode(pipelineType pipeline.Signal, recvID component.ID) *receiverNode {
@@ -55,6 +61,7 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 		return err
 	}
 
+	n.producedItems = &atomic.Int64{}
 	producedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ReceiverProducedItems,
 		SizeCounter: tb.ReceiverProducedSize,
@@ -68,7 +75,7 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 			consumers = append(consumers, next.(consumer.Traces))
 		}
 		n.Component, err = builder.CreateTraces(ctx, set,
-			obsconsumer.NewTraces(fanoutconsumer.NewTraces(consumers), producedSettings),
+			obsconsumer.NewTraces(n.tapPoint.Traces(fanoutconsumer.NewTraces(consumers)), producedSettings, obsconsumer.WithItemCount(n.producedItems)),
 		)
 	case pipeline.SignalMetrics:
 		var consumers []consumer.Metrics
@@ -76,21 +83,21 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
 		n.Component, err = builder.CreateMetrics(ctx, set,
-			obsconsumer.NewMetrics(fanoutconsumer.NewMetrics(consumers), producedSettings))
+			obsconsumer.NewMetrics(n.tapPoint.Metrics(fanoutconsumer.NewMetrics(consumers)), producedSettings, obsconsumer.WithItemCount(n.producedItems)))
 	case pipeline.SignalLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
 		n.Component, err = builder.CreateLogs(ctx, set,
-			obsconsumer.NewLogs(fanoutconsumer.NewLogs(consumers), producedSettings))
+			obsconsumer.NewLogs(n.tapPoint.Logs(fanoutconsumer.NewLogs(consumers)), producedSettings, obsconsumer.WithItemCount(n.producedItems)))
 	case xpipeline.SignalProfiles:
 		var consumers []xconsumer.Profiles
 		for _, next := range nexts {
 			consumers = append(consumers, next.(xconsumer.Profiles))
 		}
 		n.Component, err = builder.CreateProfiles(ctx, set,
-			obsconsumer.NewProfiles(fanoutconsumer.NewProfiles(consumers), producedSettings))
+			obsconsumer.NewProfiles(n.tapPoint.Profiles(fanoutconsumer.NewProfiles(consumers)), producedSettings, obsconsumer.WithItemCount(n.producedItems)))
 	default:
 		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"reflect"
+
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Reload updates the running graph to the pipelines and component configurations of set. Only the components whose
+// configuration or next consumers changed are rebuilt and restarted, the others keep running.
+//
+// The new components are started before the old ones are shut down, except the receivers, which are shut down first
+// so their new instance can listen on the same endpoints. The unchanged receivers keep sending their data to the
+// capabilities node of their pipelines, which is switched to the new processors once they are started.
+//
+// If Reload fails, the graph is unchanged and the components it started are shut down, but some of its receivers
+// may have been shut down: the caller is expected to shut down the whole graph, which skips them.
+func (g *Graph) Reload(ctx context.Context, set Settings, host *Host) error {
+	next := &Graph{
+		componentGraph: simple.NewDirectedGraph(),
+		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
+		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
+		telemetry:      set.Telemetry,
+		settings:       set,
+		taps:           g.taps,
+	}
+	for pipelineID := range set.PipelineConfigs {
+		next.pipelines[pipelineID] = &pipelineNodes{
+			receivers: make(map[int64]graph.Node),
+			exporters: make(map[int64]graph.Node),
+		}
+	}
+	if err := next.createNodes(set); err != nil {
+		return err
+	}
+	// Keep the capabilities nodes of the existing pipelines, their unchanged receivers send data to them.
+	for pipelineID, pipe := range next.pipelines {
+		if old, ok := g.pipelines[pipelineID]; ok {
+			pipe.capabilitiesNode = old.capabilitiesNode
+		}
+	}
+	next.createEdges()
+
+	nodes, err := topo.Sort(next.componentGraph)
+	if err != nil {
+		return cycleErr(err, topo.DirectedCyclesIn(next.componentGraph))
+	}
+
+	// Build the components downstream first, as in Build, reusing the running ones when possible.
+	changed := make(map[int64]bool)
+	switched := make(map[*capabilitiesNode]baseConsumer)
+	var built []graph.Node
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		if n, ok := node.(*capabilitiesNode); ok {
+			cc := next.capabilitiesConsumer(n)
+			if n.next.Load() == nil {
+				// The pipeline is new.
+				n.setConsumer(cc)
+				changed[n.ID()] = true
+				continue
+			}
+			if next.nextsChanged(g, n.ID(), changed) {
+				switched[n] = cc
+			}
+			// The receivers must be rebuilt if the pipeline no longer presents the same capabilities.
+			changed[n.ID()] = cc.Capabilities() != n.Capabilities()
+			continue
+		}
+
+		if g.reuse(next, node, changed) {
+			// Keep the InstanceID the running component reports its status with.
+			next.instanceIDs[node.ID()] = g.instanceIDs[node.ID()]
+			continue
+		}
+		changed[node.ID()] = true
+		if err = next.buildComponent(ctx, set, node); err != nil {
+			return err
+		}
+		built = append(built, node)
+	}
+
+	// The old components that are not reused, in topological order.
+	oldNodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return err
+	}
+	var retired []graph.Node
+	for _, node := range oldNodes {
+		if _, ok := node.(component.Component); !ok || g.stopped[node.ID()] {
+			continue
+		}
+		if next.componentGraph.Node(node.ID()) == nil || changed[node.ID()] {
+			retired = append(retired, node)
+		}
+	}
+
+	for _, node := range retired {
+		if _, ok := node.(*receiverNode); ok {
+			g.shutdownRetired(ctx, nil, host, node)
+			// The receiver must not be shut down again, nor reused, if the reload fails.
+			if g.stopped == nil {
+				g.stopped = make(map[int64]bool)
+			}
+			g.stopped[node.ID()] = true
+		}
+	}
+
+	for i, node := range built {
+		if err = next.startComponent(ctx, host, node); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				_ = next.shutdownComponent(ctx, nil, host.Reporter, built[j])
+			}
+			return err
+		}
+	}
+
+	for n, cc := range switched {
+		n.setConsumer(cc)
+	}
+
+	// The retired components no longer receive new data, drain them as on shutdown.
+	d, cancel := newDrainer(ctx, set.Shutdown, g.telemetry.Logger)
+	defer cancel()
+	for _, node := range retired {
+		if _, ok := node.(*receiverNode); !ok {
+			g.shutdownRetired(ctx, d, host, node)
+		}
+	}
+
+	g.telemetry.Logger.Info("Pipelines reloaded",
+		zap.Int("stopped", len(retired)),
+		zap.Int("started", len(built)),
+	)
+	g.mu.Lock()
+	g.componentGraph, g.pipelines, g.instanceIDs = next.componentGraph, next.pipelines, next.instanceIDs
+	g.telemetry, g.settings = next.telemetry, next.settings
+	g.stopped = nil
+	g.mu.Unlock()
+	return nil
+}
+
+// reuse copies the running component of the node from the graph g to the node of the next graph, if g has the node
+// with the same configuration, in the same pipelines, and the same next nodes, none of which changed.
+//
+// The components report their status with the InstanceID they were started with, which lists their pipelines: a
+// component whose pipelines changed is rebuilt, so its status is reported for the pipelines it is now part of.
+func (g *Graph) reuse(next *Graph, node graph.Node, changed map[int64]bool) bool {
+	old := g.componentGraph.Node(node.ID())
+	if old == nil || g.stopped[node.ID()] || next.nextsChanged(g, node.ID(), changed) ||
+		!samePipelines(g.instanceIDs[node.ID()], next.instanceIDs[node.ID()]) {
+		return false
+	}
+
+	switch n := node.(type) {
+	case *receiverNode:
+		if !reflect.DeepEqual(g.settings.ReceiverBuilder.Config(n.componentID), next.settings.ReceiverBuilder.Config(n.componentID)) {
+			return false
+		}
+		n.Component, n.producedItems = old.(*receiverNode).Component, old.(*receiverNode).producedItems
+		n.tapPoint = old.(*receiverNode).tapPoint
+	case *processorNode:
+		if !reflect.DeepEqual(g.settings.ProcessorBuilder.Config(n.componentID), next.settings.ProcessorBuilder.Config(n.componentID)) {
+			return false
+		}
+		n.Component, n.consumer = old.(*processorNode).Component, old.(*processorNode).consumer
+		n.producedItems, n.tapPoint = old.(*processorNode).producedItems, old.(*processorNode).tapPoint
+	case *exporterNode:
+		if !reflect.DeepEqual(g.settings.ExporterBuilder.Config(n.componentID), next.settings.ExporterBuilder.Config(n.componentID)) {
+			return false
+		}
+		n.Component, n.consumer = old.(*exporterNode).Component, old.(*exporterNode).consumer
+	case *connectorNode:
+		if !reflect.DeepEqual(g.settings.ConnectorBuilder.Config(n.componentID), next.settings.ConnectorBuilder.Config(n.componentID)) {
+			return false
+		}
+		n.Component, n.consumer = old.(*connectorNode).Component, old.(*connectorNode).consumer
+		n.producedItems, n.tapPoint = old.(*connectorNode).producedItems, old.(*connectorNode).tapPoint
+	case *fanOutNode:
+		n.baseConsumer = old.(*fanOutNode).baseConsumer
+	}
+	return true
+}
+
+// samePipelines returns whether the instance IDs are part of the same pipelines.
+func samePipelines(id, other *componentstatus.InstanceID) bool {
+	if id == nil || other == nil {
+		return id == other
+	}
+	pipelineIDs := make(map[pipeline.ID]bool)
+	id.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+		pipelineIDs[pipelineID] = true
+		return true
+	})
+	same := true
+	other.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+		same = pipelineIDs[pipelineID]
+		delete(pipelineIDs, pipelineID)
+		return same
+	})
+	return same && len(pipelineIDs) == 0
+}
+
+// nextsChanged returns whether the next nodes of the node in the graph g are different from the ones in the old
+// graph, or have changed.
+func (g *Graph) nextsChanged(old *Graph, nodeID int64, changed map[int64]bool) bool {
+	nexts := g.componentGraph.From(nodeID)
+	if nexts.Len() != old.componentGraph.From(nodeID).Len() {
+		return true
+	}
+	for nexts.Next() {
+		nextID := nexts.Node().ID()
+		if changed[nextID] || !old.componentGraph.HasEdgeFromTo(nodeID, nextID) {
+			return true
+		}
+	}
+	return false
+}
+
+// shutdownRetired shuts down a component removed from the graph. The failure is only logged, since the component
+// is no longer used.
+func (g *Graph) shutdownRetired(ctx context.Context, d *drainer, host *Host, node graph.Node) {
+	if err := g.shutdownComponent(ctx, d, host.Reporter, node); err != nil {
+		instanceID := g.instanceIDs[node.ID()]
+		g.telemetry.Logger.Warn("Failed to shutdown component",
+			zap.Error(err),
+			zap.String("type", instanceID.Kind().String()),
+			zap.String("id", instanceID.ComponentID().String()),
+		)
+	}
+}
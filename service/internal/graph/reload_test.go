@@ -0,0 +1,397 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+type reloadConfig struct {
+	receivers  map[component.ID]component.Config
+	processors map[component.ID]component.Config
+	exporters  map[component.ID]component.Config
+	connectors map[component.ID]component.Config
+	pipelines  pipelines.Config
+}
+
+// changedConfig is a configuration different from the default configuration of the test components.
+type changedConfig struct {
+	Version int
+}
+
+var (
+	tracesID          = pipeline.NewID(pipeline.SignalTraces)
+	traces2ID         = pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+	metricsID         = pipeline.NewID(pipeline.SignalMetrics)
+	logsID            = pipeline.NewID(pipeline.SignalLogs)
+	tracesReceiverID  = component.MustNewIDWithName("examplereceiver", "traces")
+	metricsReceiverID = component.MustNewIDWithName("examplereceiver", "metrics")
+	logsReceiverID    = component.MustNewIDWithName("examplereceiver", "logs")
+	traces2ReceiverID = component.MustNewIDWithName("examplereceiver", "traces2")
+	tracesProcID      = component.MustNewIDWithName("exampleprocessor", "traces")
+	metricsProcID     = component.MustNewIDWithName("exampleprocessor", "metrics")
+	exporterID        = component.MustNewID("exampleexporter")
+	connectorID       = component.MustNewID("exampleconnector")
+)
+
+func newReloadConfig() *reloadConfig {
+	return &reloadConfig{
+		receivers: map[component.ID]component.Config{
+			// The example receivers are shared per configuration instance, use distinct instances.
+			tracesReceiverID:  &changedConfig{Version: 1},
+			metricsReceiverID: &changedConfig{Version: 1},
+		},
+		processors: map[component.ID]component.Config{
+			tracesProcID:  testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			metricsProcID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+		},
+		exporters: map[component.ID]component.Config{
+			exporterID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+		},
+		connectors: map[component.ID]component.Config{
+			connectorID: testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+		},
+		pipelines: pipelines.Config{
+			tracesID: {
+				Receivers:  []component.ID{tracesReceiverID},
+				Processors: []component.ID{tracesProcID},
+				Exporters:  []component.ID{exporterID},
+			},
+			metricsID: {
+				Receivers:  []component.ID{metricsReceiverID},
+				Processors: []component.ID{metricsProcID},
+				Exporters:  []component.ID{exporterID},
+			},
+		},
+	}
+}
+
+func (cfg *reloadConfig) settings() Settings {
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(maps.Clone(cfg.receivers),
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory}),
+		ProcessorBuilder: builders.NewProcessor(maps.Clone(cfg.processors), map[component.Type]processor.Factory{
+			testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			component.MustNewType("err"):                  newErrProcessorFactory(),
+		}),
+		ExporterBuilder: builders.NewExporter(maps.Clone(cfg.exporters),
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory}),
+		ConnectorBuilder: builders.NewConnector(maps.Clone(cfg.connectors),
+			map[component.Type]connector.Factory{testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory}),
+		PipelineConfigs: maps.Clone(cfg.pipelines),
+	}
+}
+
+func TestGraphReload(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(cfg *reloadConfig)
+		// The nodes whose component is expected to be built and started by the reload.
+		started []int64
+	}{
+		{
+			name:   "unchanged",
+			update: func(*reloadConfig) {},
+		},
+		{
+			name: "processor changed",
+			update: func(cfg *reloadConfig) {
+				cfg.processors[tracesProcID] = &changedConfig{Version: 2}
+			},
+			started: []int64{newProcessorNode(tracesID, tracesProcID).ID()},
+		},
+		{
+			name: "processor added",
+			update: func(cfg *reloadConfig) {
+				cfg.pipelines[tracesID].Processors = append(cfg.pipelines[tracesID].Processors, metricsProcID)
+			},
+			started: []int64{
+				newProcessorNode(tracesID, tracesProcID).ID(),
+				newProcessorNode(tracesID, metricsProcID).ID(),
+			},
+		},
+		{
+			name: "exporter changed",
+			update: func(cfg *reloadConfig) {
+				cfg.exporters[exporterID] = &changedConfig{Version: 2}
+			},
+			started: []int64{
+				newExporterNode(pipeline.SignalTraces, exporterID).ID(),
+				newExporterNode(pipeline.SignalMetrics, exporterID).ID(),
+				newProcessorNode(tracesID, tracesProcID).ID(),
+				newProcessorNode(metricsID, metricsProcID).ID(),
+			},
+		},
+		{
+			name: "receiver changed",
+			update: func(cfg *reloadConfig) {
+				cfg.receivers[tracesReceiverID] = &changedConfig{Version: 2}
+			},
+			started: []int64{newReceiverNode(pipeline.SignalTraces, tracesReceiverID).ID()},
+		},
+		{
+			name: "pipeline added",
+			update: func(cfg *reloadConfig) {
+				cfg.receivers[logsReceiverID] = &changedConfig{Version: 1}
+				cfg.pipelines[logsID] = &pipelines.PipelineConfig{
+					Receivers: []component.ID{logsReceiverID},
+					Exporters: []component.ID{exporterID},
+				}
+			},
+			started: []int64{
+				newReceiverNode(pipeline.SignalLogs, logsReceiverID).ID(),
+				newExporterNode(pipeline.SignalLogs, exporterID).ID(),
+			},
+		},
+		{
+			name: "components added to a pipeline",
+			update: func(cfg *reloadConfig) {
+				cfg.receivers[traces2ReceiverID] = &changedConfig{Version: 1}
+				cfg.pipelines[traces2ID] = &pipelines.PipelineConfig{
+					Receivers: []component.ID{traces2ReceiverID},
+					Exporters: []component.ID{exporterID},
+				}
+			},
+			// The exporter is part of a new pipeline, the processor sends data to the new exporter.
+			started: []int64{
+				newReceiverNode(pipeline.SignalTraces, traces2ReceiverID).ID(),
+				newExporterNode(pipeline.SignalTraces, exporterID).ID(),
+				newProcessorNode(tracesID, tracesProcID).ID(),
+			},
+		},
+		{
+			name: "pipeline removed",
+			update: func(cfg *reloadConfig) {
+				delete(cfg.pipelines, metricsID)
+			},
+		},
+		{
+			name: "connector added",
+			update: func(cfg *reloadConfig) {
+				cfg.pipelines[tracesID].Exporters = append(cfg.pipelines[tracesID].Exporters, connectorID)
+				cfg.pipelines[metricsID].Receivers = append(cfg.pipelines[metricsID].Receivers, connectorID)
+			},
+			started: []int64{
+				newConnectorNode(pipeline.SignalTraces, pipeline.SignalMetrics, connectorID).ID(),
+				newProcessorNode(tracesID, tracesProcID).ID(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newReloadConfig()
+			pg, err := Build(context.Background(), cfg.settings())
+			require.NoError(t, err)
+			host := &Host{Reporter: status.NewNopStatusReporter()}
+			require.NoError(t, pg.StartAll(context.Background(), host))
+
+			oldComponents := graphComponents(pg)
+			tt.update(cfg)
+			require.NoError(t, pg.Reload(context.Background(), cfg.settings(), host))
+			newComponents := graphComponents(pg)
+
+			for id, comp := range newComponents {
+				assert.True(t, comp.(startStopper).Started())
+				if assert.Equal(t, slices.Contains(tt.started, id), comp != oldComponents[id]) {
+					assert.False(t, comp.(startStopper).Stopped())
+				}
+			}
+			for id, comp := range oldComponents {
+				assert.Equal(t, newComponents[id] != comp, comp.(startStopper).Stopped())
+			}
+
+			// The data of the unchanged and updated pipelines flows to the new exporters.
+			receivers := pg.getReceivers()
+			require.NoError(t, receivers[pipeline.SignalTraces][tracesReceiverID].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			exp := pg.GetExporters()[pipeline.SignalTraces][exporterID].(*testcomponents.ExampleExporter)
+			assert.Len(t, exp.Traces, 1)
+
+			require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+		})
+	}
+}
+
+func TestGraphReloadInstanceIDPipelines(t *testing.T) {
+	cfg := newReloadConfig()
+	pg, err := Build(context.Background(), cfg.settings())
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter()}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	cfg.receivers[traces2ReceiverID] = &changedConfig{Version: 1}
+	cfg.pipelines[traces2ID] = &pipelines.PipelineConfig{
+		Receivers: []component.ID{traces2ReceiverID},
+		Exporters: []component.ID{exporterID},
+	}
+	require.NoError(t, pg.Reload(context.Background(), cfg.settings(), host))
+
+	pipelineIDs := func(id *componentstatus.InstanceID) []pipeline.ID {
+		var ids []pipeline.ID
+		id.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+			ids = append(ids, pipelineID)
+			return true
+		})
+		return ids
+	}
+	assert.ElementsMatch(t, []pipeline.ID{tracesID, traces2ID},
+		pipelineIDs(pg.instanceIDs[newExporterNode(pipeline.SignalTraces, exporterID).ID()]))
+
+	// The exporter is no longer part of the new pipeline.
+	delete(cfg.pipelines, traces2ID)
+	require.NoError(t, pg.Reload(context.Background(), cfg.settings(), host))
+	assert.Equal(t, []pipeline.ID{tracesID},
+		pipelineIDs(pg.instanceIDs[newExporterNode(pipeline.SignalTraces, exporterID).ID()]))
+	require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+}
+
+func TestGraphReloadStartError(t *testing.T) {
+	cfg := newReloadConfig()
+	pg, err := Build(context.Background(), cfg.settings())
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter()}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	oldComponents := graphComponents(pg)
+
+	errProcID := component.MustNewID("err")
+	cfg.processors[errProcID] = &struct{}{}
+	cfg.exporters[exporterID] = &changedConfig{Version: 2}
+	cfg.pipelines[tracesID].Processors = []component.ID{errProcID}
+	require.EqualError(t, pg.Reload(context.Background(), cfg.settings(), host), `failed to start "err" processor: my error`)
+
+	// The graph is unchanged, and its components are still running.
+	assert.Equal(t, oldComponents, graphComponents(pg))
+	for _, comp := range oldComponents {
+		assert.False(t, comp.(startStopper).Stopped())
+	}
+	require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+}
+
+func TestGraphReloadStartErrorRetiredReceiver(t *testing.T) {
+	var stopped []component.ID
+	reporter := status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		if ev.Status() == componentstatus.StatusStopped {
+			stopped = append(stopped, id.ComponentID())
+		}
+	}, func(err error) { t.Error(err) })
+	host := &Host{Reporter: reporter}
+
+	cfg := newReloadConfig()
+	pg, err := Build(context.Background(), cfg.settings())
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	oldReceiver := pg.getReceivers()[pipeline.SignalTraces][tracesReceiverID].(startStopper)
+
+	errProcID := component.MustNewID("err")
+	cfg.processors[errProcID] = &struct{}{}
+	cfg.receivers[tracesReceiverID] = &changedConfig{Version: 2}
+	cfg.pipelines[tracesID].Processors = []component.ID{errProcID}
+	require.EqualError(t, pg.Reload(context.Background(), cfg.settings(), host), `failed to start "err" processor: my error`)
+	// The retired receiver was shut down before the reload failed.
+	assert.True(t, oldReceiver.Stopped())
+	assert.Equal(t, 1, countID(stopped, tracesReceiverID))
+
+	// It is not reused by the next reload, nor shut down a second time with the graph.
+	cfg.pipelines[tracesID].Processors = []component.ID{tracesProcID}
+	cfg.receivers[tracesReceiverID] = &changedConfig{Version: 1}
+	require.NoError(t, pg.Reload(context.Background(), cfg.settings(), host))
+	assert.NotSame(t, oldReceiver, pg.getReceivers()[pipeline.SignalTraces][tracesReceiverID])
+	assert.Equal(t, 1, countID(stopped, tracesReceiverID))
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), reporter))
+	assert.Equal(t, 2, countID(stopped, tracesReceiverID))
+}
+
+func countID(ids []component.ID, id component.ID) int {
+	count := 0
+	for _, i := range ids {
+		if i == id {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGraphReloadConcurrentZPages(t *testing.T) {
+	cfg := newReloadConfig()
+	pg, err := Build(context.Background(), cfg.settings())
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter()}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	// The zpages handlers read the graph while it is reloaded.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, handler := range []http.HandlerFunc{pg.HandleZPages, pg.HandleGraphZPages, pg.HandleTapZPages, pg.HandleDeadLetterZPages} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+				pg.GetExporters()
+			}
+		}()
+	}
+	for i := range 100 {
+		cfg.processors[tracesProcID] = &changedConfig{Version: i}
+		require.NoError(t, pg.Reload(context.Background(), cfg.settings(), host))
+	}
+	close(done)
+	wg.Wait()
+	require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+}
+
+type startStopper interface {
+	Started() bool
+	Stopped() bool
+}
+
+// graphComponents returns the components of the graph per node ID.
+func graphComponents(g *Graph) map[int64]component.Component {
+	components := make(map[int64]component.Component)
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			components[n.ID()] = n.Component
+		case *processorNode:
+			components[n.ID()] = n.Component
+		case *exporterNode:
+			components[n.ID()] = n.Component
+		case *connectorNode:
+			components[n.ID()] = n.Component
+		}
+	}
+	return components
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/collector/service/internal/tap"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	// URL Params
+	zTapNode      = "node"
+	zTapRate      = "rate"
+	zTapAttribute = "attribute"
+	zTapCount     = "count"
+
+	// tapDefaultRate is the number of batches per second streamed when the rate is not set.
+	tapDefaultRate = 1
+	// tapBuffer is the number of batches buffered for a slow client before they are dropped.
+	tapBuffer = 16
+)
+
+// tapNodes returns the nodes of the graph whose output can be tapped, sorted by ID.
+func (g *Graph) tapNodes() []dagNode {
+	var nodes []dagNode
+	it := g.componentGraph.Nodes()
+	for it.Next() {
+		switch it.Node().(type) {
+		case *receiverNode, *processorNode, *connectorNode:
+			nodes = append(nodes, newDAGNode(it.Node()))
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// HandleTapZPages streams a sample of the data produced by a node of the graph, as newline delimited OTLP JSON,
+// until the client disconnects or the requested number of batches is sent. Without node, it lists the nodes
+// which can be tapped.
+func (g *Graph) HandleTapZPages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	// The lock is not held while streaming, the subscription is kept when the graph is reloaded.
+	g.mu.RLock()
+	nodes := g.tapNodes()
+	g.mu.RUnlock()
+	id := query.Get(zTapNode)
+	if id == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Tap"})
+		zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Parameters", Properties: [][2]string{
+			{zTapRate, "Maximum number of batches streamed per second, defaults to 1"},
+			{zTapAttribute, "Only keeps the items with the attribute, in the key=value form"},
+			{zTapCount, "Number of batches streamed before closing the stream, unlimited if not set"},
+		}})
+		for i := range nodes {
+			zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+				Name:              nodes[i].ID,
+				ComponentEndpoint: "?" + url.Values{zTapNode: {nodes[i].ID}}.Encode(),
+				Link:              true,
+			})
+		}
+		zpages.WriteHTMLPageFooter(w)
+		return
+	}
+
+	i := sort.Search(len(nodes), func(i int) bool { return nodes[i].ID >= id })
+	if i == len(nodes) || nodes[i].ID != id {
+		http.Error(w, "unknown node "+id, http.StatusNotFound)
+		return
+	}
+	point, ok := g.taps.Lookup(id)
+	if !ok {
+		http.Error(w, "unknown node "+id, http.StatusNotFound)
+		return
+	}
+
+	var err error
+	opts := tap.Options{Rate: tapDefaultRate, Buffer: tapBuffer}
+	if rate := query.Get(zTapRate); rate != "" {
+		if opts.Rate, err = strconv.ParseFloat(rate, 64); err != nil || opts.Rate <= 0 {
+			http.Error(w, "rate must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+	if opts.Filter, err = tap.ParseFilter(query.Get(zTapAttribute)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	count := 0
+	if c := query.Get(zTapCount); c != "" {
+		if count, err = strconv.Atoi(c); err != nil || count <= 0 {
+			http.Error(w, "count must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	sub := point.Subscribe(opts)
+	defer point.Unsubscribe(sub)
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	if err = rc.Flush(); err != nil {
+		return
+	}
+	for sent := 0; count == 0 || sent < count; sent++ {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-sub.Data():
+			// The data is shared with the other subscriptions, it must not be modified.
+			if _, err = w.Write(data); err != nil {
+				return
+			}
+			if _, err = w.Write([]byte{'\n'}); err != nil {
+				return
+			}
+			if err = rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
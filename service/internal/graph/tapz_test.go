@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bufio"
+	"context"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+// openTap opens a stream on the tapz page of the graph, returning once the tap is subscribed.
+func openTap(t *testing.T, srv *httptest.Server, query url.Values) *bufio.Scanner {
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"?"+query.Encode(), http.NoBody)
+	require.NoError(t, err)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	return bufio.NewScanner(resp.Body)
+}
+
+func TestGraphHandleTapZPages(t *testing.T) {
+	pg, _ := newDAGTestGraph(t)
+
+	rec := httptest.NewRecorder()
+	pg.HandleTapZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/tapz", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), dagTracesReceiver)
+	assert.Contains(t, rec.Body.String(), dagTracesProcessor)
+	assert.Contains(t, rec.Body.String(), html.EscapeString(dagConnector))
+	assert.NotContains(t, rec.Body.String(), dagTracesExporter)
+	assert.NotContains(t, rec.Body.String(), dagTracesFanout)
+
+	for _, tt := range []struct {
+		query url.Values
+		code  int
+	}{
+		{query: url.Values{zTapNode: {"processor:unknown[traces]"}}, code: http.StatusNotFound},
+		{query: url.Values{zTapNode: {dagTracesExporter}}, code: http.StatusNotFound},
+		{query: url.Values{zTapNode: {dagTracesProcessor}, zTapRate: {"0"}}, code: http.StatusBadRequest},
+		{query: url.Values{zTapNode: {dagTracesProcessor}, zTapRate: {"fast"}}, code: http.StatusBadRequest},
+		{query: url.Values{zTapNode: {dagTracesProcessor}, zTapAttribute: {"key"}}, code: http.StatusBadRequest},
+		{query: url.Values{zTapNode: {dagTracesProcessor}, zTapCount: {"-1"}}, code: http.StatusBadRequest},
+	} {
+		rec = httptest.NewRecorder()
+		pg.HandleTapZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/tapz?"+tt.query.Encode(), http.NoBody))
+		assert.Equal(t, tt.code, rec.Code, tt.query.Encode())
+	}
+}
+
+func TestHostRegisterZPagesTapzFeatureGate(t *testing.T) {
+	pg, _ := newDAGTestGraph(t)
+	host := &Host{Pipelines: pg}
+	for _, enabled := range []bool{false, true} {
+		setTapzGateForTest(t, enabled)
+		mux := http.NewServeMux()
+		host.RegisterZPages(mux, "/debug")
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/tapz", http.NoBody))
+		if !enabled {
+			// The tapz page is disabled by default, since it exposes the data of the pipelines.
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+		} else {
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/servicez", http.NoBody))
+		assert.Equal(t, enabled, strings.Contains(rec.Body.String(), zTapPath))
+	}
+}
+
+func setTapzGateForTest(t *testing.T, enabled bool) {
+	initial := tapzFeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(tapzFeatureGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(tapzFeatureGate.ID(), initial))
+	})
+}
+
+func TestGraphHandleTapZPagesStream(t *testing.T) {
+	pg, cfg := newDAGTestGraph(t)
+	srv := httptest.NewServer(http.HandlerFunc(pg.HandleTapZPages))
+	t.Cleanup(srv.Close)
+
+	processor := openTap(t, srv, url.Values{
+		zTapNode:      {dagTracesProcessor},
+		zTapRate:      {"1e9"},
+		zTapAttribute: {"resource-attr=resource-attr-val-1"},
+		zTapCount:     {"2"},
+	})
+	connector := openTap(t, srv, url.Values{
+		zTapNode:  {dagConnector},
+		zTapCount: {"1"},
+	})
+
+	consumeTestTraces(t, pg, 2)
+	require.True(t, processor.Scan())
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(processor.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+
+	require.True(t, connector.Scan())
+	md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(connector.Bytes())
+	require.NoError(t, err)
+	assert.Positive(t, md.DataPointCount())
+	// The stream is closed once the requested number of batches is sent.
+	assert.False(t, connector.Scan())
+	require.NoError(t, connector.Err())
+
+	// The subscriptions are kept on reload.
+	require.NoError(t, pg.Reload(context.Background(), cfg.settings(), &Host{Reporter: status.NewNopStatusReporter()}))
+	consumeTestTraces(t, pg, 1)
+	require.True(t, processor.Scan())
+	td, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(processor.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 1, td.SpanCount())
+	assert.False(t, processor.Scan())
+	require.NoError(t, processor.Err())
+
+}
@@ -56,6 +56,9 @@ func (c obsLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	attrs := &c.withSuccessAttrs
 
 	itemCount := ld.LogRecordCount()
+	if c.itemCount != nil {
+		c.itemCount.Add(int64(itemCount))
+	}
 	defer func() {
 		c.set.ItemCounter.Add(ctx, int64(itemCount), *attrs)
 	}()
@@ -56,6 +56,9 @@ func (c obsMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 	attrs := &c.withSuccessAttrs
 
 	itemCount := md.DataPointCount()
+	if c.itemCount != nil {
+		c.itemCount.Add(int64(itemCount))
+	}
 	defer func() {
 		c.set.ItemCounter.Add(ctx, int64(itemCount), *attrs)
 	}()
@@ -6,6 +6,7 @@ package obsconsumer_test
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 )
 
@@ -464,3 +466,28 @@ func TestMetricsCapabilities(t *testing.T) {
 	consumer = obsconsumer.NewMetrics(mockConsumer, obsconsumer.Settings{ItemCounter: itemCounter, SizeCounter: sizeCounter, Logger: zap.NewNop()})
 	require.Equal(t, consumer.Capabilities(), mockConsumer.capabilities)
 }
+
+func TestMetricsWithItemCount(t *testing.T) {
+	setGateForTest(t, true)
+
+	mp := sdkmetric.NewMeterProvider()
+	meter := mp.Meter("test")
+	itemCounter, err := meter.Int64Counter("item_counter")
+	require.NoError(t, err)
+	sizeCounter, err := meter.Int64Counter("size_counter")
+	require.NoError(t, err)
+
+	mockConsumer := &mockMetricsConsumer{}
+	var count atomic.Int64
+	consumer := obsconsumer.NewMetrics(mockConsumer, obsconsumer.Settings{ItemCounter: itemCounter, SizeCounter: sizeCounter, Logger: zap.NewNop()},
+		obsconsumer.WithItemCount(&count))
+
+	md := testdata.GenerateMetrics(2)
+	require.NoError(t, consumer.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, int64(md.DataPointCount()), count.Load())
+
+	// Failed items are counted as well.
+	mockConsumer.err = errors.New("failed")
+	require.Error(t, consumer.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, int64(2*md.DataPointCount()), count.Load())
+}
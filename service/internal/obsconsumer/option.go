@@ -4,6 +4,8 @@
 package obsconsumer // import "go.opentelemetry.io/collector/service/internal/obsconsumer"
 
 import (
+	"sync/atomic"
+
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
@@ -17,6 +19,7 @@ type Option func(*options)
 
 type options struct {
 	staticDataPointAttributes []attribute.KeyValue
+	itemCount                 *atomic.Int64
 }
 
 // WithStaticDataPointAttribute returns an Option that adds a static attribute to data points.
@@ -26,7 +29,16 @@ func WithStaticDataPointAttribute(attr attribute.KeyValue) Option {
 	}
 }
 
+// WithItemCount returns an Option that also adds the number of items to the given count,
+// so that it can be read in process.
+func WithItemCount(count *atomic.Int64) Option {
+	return func(opts *options) {
+		opts.itemCount = count
+	}
+}
+
 type compiledOptions struct {
+	itemCount        *atomic.Int64
 	withSuccessAttrs metric.AddOption
 	withFailureAttrs metric.AddOption
 	withRefusedAttrs metric.AddOption
@@ -46,6 +58,7 @@ func (o *options) compile() compiledOptions {
 	refusedAttrs = append(refusedAttrs, o.staticDataPointAttributes...)
 
 	return compiledOptions{
+		itemCount:        o.itemCount,
 		withSuccessAttrs: metric.WithAttributes(successAttrs...),
 		withFailureAttrs: metric.WithAttributes(failureAttrs...),
 		withRefusedAttrs: metric.WithAttributes(refusedAttrs...),
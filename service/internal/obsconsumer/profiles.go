@@ -57,6 +57,9 @@ func (c obsProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles)
 	attrs := &c.withSuccessAttrs
 
 	itemCount := pd.SampleCount()
+	if c.itemCount != nil {
+		c.itemCount.Add(int64(itemCount))
+	}
 	defer func() {
 		c.set.ItemCounter.Add(ctx, int64(itemCount), *attrs)
 	}()
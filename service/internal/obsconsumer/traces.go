@@ -56,6 +56,9 @@ func (c obsTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	attrs := &c.withSuccessAttrs
 
 	itemCount := td.SpanCount()
+	if c.itemCount != nil {
+		c.itemCount.Add(int64(itemCount))
+	}
 	defer func() {
 		c.set.ItemCounter.Add(ctx, int64(itemCount), *attrs)
 	}()
@@ -6,6 +6,7 @@ package obsconsumer_test
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 )
 
@@ -458,3 +460,27 @@ func TestTracesCapabilities(t *testing.T) {
 	consumer = obsconsumer.NewTraces(mockConsumer, obsconsumer.Settings{ItemCounter: itemCounter, SizeCounter: sizeCounter, Logger: zap.NewNop()})
 	require.Equal(t, consumer.Capabilities(), mockConsumer.capabilities)
 }
+
+func TestTracesWithItemCount(t *testing.T) {
+	setGateForTest(t, true)
+
+	mp := sdkmetric.NewMeterProvider()
+	meter := mp.Meter("test")
+	itemCounter, err := meter.Int64Counter("item_counter")
+	require.NoError(t, err)
+	sizeCounter, err := meter.Int64Counter("size_counter")
+	require.NoError(t, err)
+
+	mockConsumer := &mockTracesConsumer{}
+	var count atomic.Int64
+	consumer := obsconsumer.NewTraces(mockConsumer, obsconsumer.Settings{ItemCounter: itemCounter, SizeCounter: sizeCounter, Logger: zap.NewNop()},
+		obsconsumer.WithItemCount(&count))
+
+	require.NoError(t, consumer.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, int64(2), count.Load())
+
+	// Failed items are counted as well.
+	mockConsumer.err = errors.New("failed")
+	require.Error(t, consumer.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	assert.Equal(t, int64(5), count.Load())
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+var tenantFilter = Filter{Key: "tenant", Value: "a"}
+
+func TestTraces(t *testing.T) {
+	p := &Point{}
+	next := new(consumertest.TracesSink)
+	cons := p.Traces(next)
+	all := p.Subscribe(Options{Buffer: 1})
+	filtered := p.Subscribe(Options{Filter: tenantFilter, Buffer: 1})
+
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("tenant", "a")
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+
+	// The data passed to the next consumer is not filtered.
+	require.Len(t, next.AllTraces(), 1)
+	assert.Equal(t, 2, next.AllTraces()[0].SpanCount())
+
+	got, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-all.Data())
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+	got, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-filtered.Data())
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.SpanCount())
+}
+
+func TestMetrics(t *testing.T) {
+	p := &Point{}
+	next := new(consumertest.MetricsSink)
+	cons := p.Metrics(next)
+	all := p.Subscribe(Options{Buffer: 1})
+	filtered := p.Subscribe(Options{Filter: tenantFilter, Buffer: 1})
+
+	md := testdata.GenerateMetrics(2)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().PutStr("tenant", "a")
+	require.NoError(t, cons.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, next.AllMetrics(), 1)
+	assert.Equal(t, md.DataPointCount(), next.AllMetrics()[0].DataPointCount())
+
+	got, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(<-all.Data())
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+	got, err = (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(<-filtered.Data())
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.DataPointCount())
+}
+
+func TestLogs(t *testing.T) {
+	p := &Point{}
+	next := new(consumertest.LogsSink)
+	cons := p.Logs(next)
+	all := p.Subscribe(Options{Buffer: 1})
+	filtered := p.Subscribe(Options{Filter: tenantFilter, Buffer: 1})
+
+	ld := testdata.GenerateLogs(2)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("tenant", "a")
+	require.NoError(t, cons.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, next.AllLogs(), 1)
+	assert.Equal(t, 2, next.AllLogs()[0].LogRecordCount())
+
+	got, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(<-all.Data())
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+	got, err = (&plog.JSONUnmarshaler{}).UnmarshalLogs(<-filtered.Data())
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.LogRecordCount())
+}
+
+func TestProfiles(t *testing.T) {
+	p := &Point{}
+	next := new(consumertest.ProfilesSink)
+	cons := p.Profiles(next)
+	all := p.Subscribe(Options{Buffer: 1})
+	filtered := p.Subscribe(Options{Filter: tenantFilter, Buffer: 1})
+
+	pd := testdata.GenerateProfiles(2)
+	require.NoError(t, cons.ConsumeProfiles(context.Background(), pd))
+
+	require.Len(t, next.AllProfiles(), 1)
+	assert.Equal(t, pd.SampleCount(), next.AllProfiles()[0].SampleCount())
+
+	got, err := (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(<-all.Data())
+	require.NoError(t, err)
+	assert.Equal(t, pd.SampleCount(), got.SampleCount())
+	// No profile matches the filter.
+	assert.Empty(t, filtered.Data())
+}
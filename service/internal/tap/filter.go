@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Filter keeps the items having the attribute Key with the value Value, in their resource, their scope, or
+// the item itself. The zero Filter keeps all the items.
+type Filter struct {
+	Key   string
+	Value string
+}
+
+// ParseFilter parses a filter in the key=value form. An empty string is parsed to the zero Filter.
+func ParseFilter(s string) (Filter, error) {
+	if s == "" {
+		return Filter{}, nil
+	}
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return Filter{}, errors.New("attribute filter must be in the key=value form")
+	}
+	return Filter{Key: key, Value: value}, nil
+}
+
+// IsEmpty returns whether the filter keeps all the items.
+func (f Filter) IsEmpty() bool {
+	return f.Key == ""
+}
+
+func (f Filter) matches(attrs pcommon.Map) bool {
+	v, ok := attrs.Get(f.Key)
+	return ok && v.AsString() == f.Value
+}
+
+func (f Filter) filterTraces(td ptrace.Traces) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		if f.matches(rs.Resource().Attributes()) {
+			return false
+		}
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			if f.matches(ss.Scope().Attributes()) {
+				return false
+			}
+			ss.Spans().RemoveIf(func(s ptrace.Span) bool {
+				return !f.matches(s.Attributes())
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+func (f Filter) filterMetrics(md pmetric.Metrics) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if f.matches(rm.Resource().Attributes()) {
+			return false
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			if f.matches(sm.Scope().Attributes()) {
+				return false
+			}
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return f.filterDataPoints(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// filterDataPoints removes the data points of the metric not matching the filter, and returns the number of
+// data points left.
+func (f Filter) filterDataPoints(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !f.matches(dp.Attributes()) })
+		return dps.Len()
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !f.matches(dp.Attributes()) })
+		return dps.Len()
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return !f.matches(dp.Attributes()) })
+		return dps.Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return !f.matches(dp.Attributes()) })
+		return dps.Len()
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return !f.matches(dp.Attributes()) })
+		return dps.Len()
+	default:
+		return 0
+	}
+}
+
+func (f Filter) filterLogs(ld plog.Logs) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		if f.matches(rl.Resource().Attributes()) {
+			return false
+		}
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			if f.matches(sl.Scope().Attributes()) {
+				return false
+			}
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !f.matches(lr.Attributes())
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
+
+// filterProfiles only matches the resource and scope attributes, since the attributes of the samples are
+// stored in the dictionary of the profiles.
+func (f Filter) filterProfiles(pd pprofile.Profiles) {
+	pd.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		if f.matches(rp.Resource().Attributes()) {
+			return false
+		}
+		rp.ScopeProfiles().RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+			return !f.matches(sp.Scope().Attributes())
+		})
+		return rp.ScopeProfiles().Len() == 0
+	})
+}
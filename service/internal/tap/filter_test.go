@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("")
+	require.NoError(t, err)
+	assert.True(t, f.IsEmpty())
+
+	f, err = ParseFilter("service.name=checkout=eu")
+	require.NoError(t, err)
+	assert.Equal(t, Filter{Key: "service.name", Value: "checkout=eu"}, f)
+
+	f, err = ParseFilter("key=")
+	require.NoError(t, err)
+	assert.Equal(t, Filter{Key: "key"}, f)
+
+	_, err = ParseFilter("key")
+	require.EqualError(t, err, "attribute filter must be in the key=value form")
+	_, err = ParseFilter("=value")
+	require.EqualError(t, err, "attribute filter must be in the key=value form")
+}
+
+func TestFilterTraces(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutStr("tenant", "a")
+	Filter{Key: "tenant", Value: "a"}.filterTraces(td)
+	require.Equal(t, 1, td.SpanCount())
+	assert.Equal(t, "operationB", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	td = testdata.GenerateTraces(2)
+	Filter{Key: "resource-attr", Value: "resource-attr-val-1"}.filterTraces(td)
+	assert.Equal(t, 2, td.SpanCount())
+
+	td = testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Attributes().PutInt("tenant", 1)
+	Filter{Key: "tenant", Value: "1"}.filterTraces(td)
+	assert.Equal(t, 2, td.SpanCount())
+
+	td = testdata.GenerateTraces(2)
+	Filter{Key: "tenant", Value: "a"}.filterTraces(td)
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestFilterMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsAllTypes()
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	metrics.At(2).Sum().DataPoints().At(0).Attributes().PutStr("tenant", "a")
+	Filter{Key: "tenant", Value: "a"}.filterMetrics(md)
+	require.Equal(t, 1, md.DataPointCount())
+	assert.Equal(t, pmetric.MetricTypeSum, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Type())
+
+	md = testdata.GenerateMetricsAllTypes()
+	Filter{Key: "tenant", Value: "a"}.filterMetrics(md)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = testdata.GenerateMetricsAllTypes()
+	count := md.DataPointCount()
+	Filter{Key: "resource-attr", Value: "resource-attr-val-1"}.filterMetrics(md)
+	assert.Equal(t, count, md.DataPointCount())
+}
+
+func TestFilterLogs(t *testing.T) {
+	ld := testdata.GenerateLogs(3)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(2).Attributes().PutStr("tenant", "a")
+	Filter{Key: "tenant", Value: "a"}.filterLogs(ld)
+	assert.Equal(t, 1, ld.LogRecordCount())
+
+	ld = testdata.GenerateLogs(3)
+	Filter{Key: "tenant", Value: "a"}.filterLogs(ld)
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+}
+
+func TestFilterProfiles(t *testing.T) {
+	pd := testdata.GenerateProfiles(2)
+	count := pd.SampleCount()
+	Filter{Key: "resource-attr", Value: "resource-attr-val-1"}.filterProfiles(pd)
+	assert.Equal(t, count, pd.SampleCount())
+
+	Filter{Key: "resource-attr", Value: "other"}.filterProfiles(pd)
+	assert.Equal(t, 0, pd.ResourceProfiles().Len())
+}
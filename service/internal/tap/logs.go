@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var logsMarshaler = &plog.JSONMarshaler{}
+
+// Logs returns a consumer publishing the data to the subscriptions of the point before passing it to next.
+// It returns next if the point is nil.
+func (p *Point) Logs(next consumer.Logs) consumer.Logs {
+	if p == nil {
+		return next
+	}
+	return tapLogs{Logs: next, point: p}
+}
+
+type tapLogs struct {
+	consumer.Logs
+	point *Point
+}
+
+func (c tapLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.point.publish(func(f Filter) []byte {
+		sample := ld
+		if !f.IsEmpty() {
+			sample = plog.NewLogs()
+			ld.CopyTo(sample)
+			f.filterLogs(sample)
+		}
+		if sample.LogRecordCount() == 0 {
+			return nil
+		}
+		b, err := logsMarshaler.MarshalLogs(sample)
+		if err != nil {
+			return nil
+		}
+		return b
+	})
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var metricsMarshaler = &pmetric.JSONMarshaler{}
+
+// Metrics returns a consumer publishing the data to the subscriptions of the point before passing it to next.
+// It returns next if the point is nil.
+func (p *Point) Metrics(next consumer.Metrics) consumer.Metrics {
+	if p == nil {
+		return next
+	}
+	return tapMetrics{Metrics: next, point: p}
+}
+
+type tapMetrics struct {
+	consumer.Metrics
+	point *Point
+}
+
+func (c tapMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.point.publish(func(f Filter) []byte {
+		sample := md
+		if !f.IsEmpty() {
+			sample = pmetric.NewMetrics()
+			md.CopyTo(sample)
+			f.filterMetrics(sample)
+		}
+		if sample.DataPointCount() == 0 {
+			return nil
+		}
+		b, err := metricsMarshaler.MarshalMetrics(sample)
+		if err != nil {
+			return nil
+		}
+		return b
+	})
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+var profilesMarshaler = &pprofile.JSONMarshaler{}
+
+// Profiles returns a consumer publishing the data to the subscriptions of the point before passing it to next.
+// It returns next if the point is nil.
+func (p *Point) Profiles(next xconsumer.Profiles) xconsumer.Profiles {
+	if p == nil {
+		return next
+	}
+	return tapProfiles{Profiles: next, point: p}
+}
+
+type tapProfiles struct {
+	xconsumer.Profiles
+	point *Point
+}
+
+func (c tapProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	c.point.publish(func(f Filter) []byte {
+		sample := pd
+		if !f.IsEmpty() {
+			sample = pprofile.NewProfiles()
+			pd.CopyTo(sample)
+			f.filterProfiles(sample)
+		}
+		if sample.SampleCount() == 0 {
+			return nil
+		}
+		b, err := profilesMarshaler.MarshalProfiles(sample)
+		if err != nil {
+			return nil
+		}
+		return b
+	})
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tap samples the data flowing out of the components of the pipelines, so that it can be inspected
+// while the collector is running.
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Registry holds the tap points of the graph, by node ID. The points outlive the components, so that the
+// subscriptions are kept when the pipelines are reloaded.
+type Registry struct {
+	mu     sync.Mutex
+	points map[string]*Point
+}
+
+func NewRegistry() *Registry {
+	return &Registry{points: make(map[string]*Point)}
+}
+
+// Point returns the tap point with the given ID, creating it if needed.
+func (r *Registry) Point(id string) *Point {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.points[id]
+	if !ok {
+		p = &Point{}
+		r.points[id] = p
+	}
+	return p
+}
+
+// Lookup returns the tap point with the given ID, if it exists.
+func (r *Registry) Lookup(id string) (*Point, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.points[id]
+	return p, ok
+}
+
+// Point is a place in the graph where the data can be tapped. It does nothing until it has subscriptions.
+type Point struct {
+	mu            sync.Mutex
+	subscriptions atomic.Pointer[[]*Subscription]
+}
+
+// Options defines how the data is sampled for a subscription.
+type Options struct {
+	// Rate is the maximum number of batches per second sent to the subscription.
+	Rate float64
+	// Filter only keeps the items matching it, if it is not empty.
+	Filter Filter
+	// Buffer is the number of batches buffered for the subscription, the batches are dropped when it is full.
+	Buffer int
+}
+
+// Subscription receives the data sampled at a tap point, marshaled as OTLP JSON.
+type Subscription struct {
+	interval time.Duration
+	filter   Filter
+	data     chan []byte
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// Data returns the channel the sampled batches are sent to.
+func (s *Subscription) Data() <-chan []byte {
+	return s.data
+}
+
+// ready returns whether the rate allows sending a batch at the given time.
+func (s *Subscription) ready(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !now.Before(s.next)
+}
+
+// send sends the batch, unless the rate was reached since ready was called, or the buffer is full.
+func (s *Subscription) send(now time.Time, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Before(s.next) {
+		return
+	}
+	select {
+	case s.data <- data:
+		s.next = now.Add(s.interval)
+	default:
+	}
+}
+
+// Subscribe starts sampling the data flowing through the point.
+func (p *Point) Subscribe(opts Options) *Subscription {
+	s := &Subscription{
+		filter: opts.Filter,
+		data:   make(chan []byte, max(opts.Buffer, 1)),
+	}
+	if opts.Rate > 0 {
+		s.interval = time.Duration(float64(time.Second) / opts.Rate)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var subs []*Subscription
+	if current := p.subscriptions.Load(); current != nil {
+		subs = slices.Clone(*current)
+	}
+	subs = append(subs, s)
+	p.subscriptions.Store(&subs)
+	return s
+}
+
+// Unsubscribe stops sampling the data for the subscription.
+func (p *Point) Unsubscribe(s *Subscription) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current := p.subscriptions.Load()
+	if current == nil {
+		return
+	}
+	subs := slices.DeleteFunc(slices.Clone(*current), func(sub *Subscription) bool { return sub == s })
+	if len(subs) == 0 {
+		p.subscriptions.Store(nil)
+		return
+	}
+	p.subscriptions.Store(&subs)
+}
+
+// publish sends the batch to the subscriptions ready to receive one. The marshal function returns the
+// batch keeping only the items matching the filter, or nil if none does.
+func (p *Point) publish(marshal func(Filter) []byte) {
+	subs := p.subscriptions.Load()
+	if subs == nil {
+		return
+	}
+	now := time.Now()
+	// The batch is marshaled once for all the subscriptions without filter.
+	var unfiltered []byte
+	for _, s := range *subs {
+		if !s.ready(now) {
+			continue
+		}
+		var data []byte
+		if s.filter.IsEmpty() {
+			if unfiltered == nil {
+				unfiltered = marshal(s.filter)
+			}
+			data = unfiltered
+		} else {
+			data = marshal(s.filter)
+		}
+		if data != nil {
+			s.send(now, data)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	_, ok := r.Lookup("processor:batch[traces]")
+	assert.False(t, ok)
+
+	p := r.Point("processor:batch[traces]")
+	assert.Same(t, p, r.Point("processor:batch[traces]"))
+	found, ok := r.Lookup("processor:batch[traces]")
+	require.True(t, ok)
+	assert.Same(t, p, found)
+}
+
+func TestNilPoint(t *testing.T) {
+	var p *Point
+	next := consumertest.NewNop()
+	assert.Equal(t, next, p.Traces(next))
+	assert.Equal(t, next, p.Metrics(next))
+	assert.Equal(t, next, p.Logs(next))
+	assert.Equal(t, next, p.Profiles(next))
+}
+
+func TestSubscribe(t *testing.T) {
+	p := &Point{}
+	next := new(consumertest.TracesSink)
+	cons := p.Traces(next)
+	assert.Equal(t, next.Capabilities(), cons.Capabilities())
+
+	// Nothing is published without subscription.
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	sub1 := p.Subscribe(Options{Buffer: 2})
+	sub2 := p.Subscribe(Options{Buffer: 2})
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sub1.Data(), 1)
+	assert.Len(t, sub2.Data(), 1)
+
+	p.Unsubscribe(sub1)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sub1.Data(), 1)
+	assert.Len(t, sub2.Data(), 2)
+
+	// The batches are dropped when the buffer is full.
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sub2.Data(), 2)
+
+	p.Unsubscribe(sub2)
+	p.Unsubscribe(sub2)
+	assert.Nil(t, p.subscriptions.Load())
+	assert.Len(t, next.AllTraces(), 4)
+}
+
+func TestSubscribeRate(t *testing.T) {
+	p := &Point{}
+	cons := p.Traces(consumertest.NewNop())
+	sub := p.Subscribe(Options{Rate: 0.001, Buffer: 10})
+	for range 5 {
+		require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	assert.Len(t, sub.Data(), 1)
+}
+
+func TestSubscribeRateFiltered(t *testing.T) {
+	p := &Point{}
+	cons := p.Traces(consumertest.NewNop())
+	sub := p.Subscribe(Options{Rate: 0.001, Filter: Filter{Key: "resource-attr", Value: "other"}, Buffer: 10})
+
+	// The batches not matching the filter do not count toward the rate.
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Empty(t, sub.Data())
+
+	td := testdata.GenerateTraces(1)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("resource-attr", "other")
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+	assert.Len(t, sub.Data(), 1)
+}
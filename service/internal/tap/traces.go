@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var tracesMarshaler = &ptrace.JSONMarshaler{}
+
+// Traces returns a consumer publishing the data to the subscriptions of the point before passing it to next.
+// It returns next if the point is nil.
+func (p *Point) Traces(next consumer.Traces) consumer.Traces {
+	if p == nil {
+		return next
+	}
+	return tapTraces{Traces: next, point: p}
+}
+
+type tapTraces struct {
+	consumer.Traces
+	point *Point
+}
+
+func (c tapTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	c.point.publish(func(f Filter) []byte {
+		sample := td
+		if !f.IsEmpty() {
+			sample = ptrace.NewTraces()
+			td.CopyTo(sample)
+			f.filterTraces(sample)
+		}
+		if sample.SpanCount() == 0 {
+			return nil
+		}
+		b, err := tracesMarshaler.MarshalTraces(sample)
+		if err != nil {
+			return nil
+		}
+		return b
+	})
+	return c.Traces.ConsumeTraces(ctx, td)
+}
@@ -12,9 +12,10 @@ import (
 
 var (
 	templateFunctions = template.FuncMap{
-		"even":     even,
-		"getKey":   getKey,
-		"getValue": getValue,
+		"even":      even,
+		"getKey":    getKey,
+		"getValue":  getValue,
+		"nodeColor": nodeColor,
 	}
 
 	//go:embed templates/component_header.html
@@ -37,6 +38,10 @@ var (
 	pipelinesTableBytes    []byte
 	pipelinesTableTemplate = parseTemplate("pipelines_table", pipelinesTableBytes)
 
+	//go:embed templates/pipeline_graph.html
+	pipelineGraphBytes    []byte
+	pipelineGraphTemplate = parseTemplate("pipeline_graph", pipelineGraphBytes)
+
 	//go:embed templates/properties_table.html
 	propertiesTableBytes    []byte
 	propertiesTableTemplate = parseTemplate("properties_table", propertiesTableBytes)
@@ -104,6 +109,46 @@ func WriteHTMLPipelinesSummaryTable(w io.Writer, spd SummaryPipelinesTableData)
 	}
 }
 
+// PipelineGraphData contains data for the pipeline graph template.
+type PipelineGraphData struct {
+	Width      int
+	Height     int
+	NodeWidth  int
+	NodeHeight int
+	Nodes      []PipelineGraphNodeData
+	Edges      []PipelineGraphEdgeData
+	// ItemCounts is true if the edges are labeled with the number of items passed through them.
+	ItemCounts bool
+}
+
+// PipelineGraphNodeData contains data for one node in the pipeline graph template.
+type PipelineGraphNodeData struct {
+	X      int
+	Y      int
+	Kind   string
+	Name   string
+	Detail string
+}
+
+// PipelineGraphEdgeData contains data for one edge in the pipeline graph template.
+type PipelineGraphEdgeData struct {
+	X1     int
+	Y1     int
+	X2     int
+	Y2     int
+	LabelX int
+	LabelY int
+	Label  string
+}
+
+// WriteHTMLPipelineGraph writes the graph of the pipeline components as an SVG image.
+// It does not write the header or footer.
+func WriteHTMLPipelineGraph(w io.Writer, pgd PipelineGraphData) {
+	if err := pipelineGraphTemplate.Execute(w, pgd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // ComponentHeaderData contains data for component header template.
 type ComponentHeaderData struct {
 	Name              string
@@ -142,6 +187,21 @@ func even(x int) bool {
 	return x%2 == 0
 }
 
+func nodeColor(kind string) string {
+	switch kind {
+	case "receiver":
+		return "#c8e6c9"
+	case "processor":
+		return "#bbdefb"
+	case "exporter":
+		return "#ffe0b2"
+	case "connector":
+		return "#e1bee7"
+	default:
+		return "#eeeeee"
+	}
+}
+
 func getKey(row [2]string) string {
 	return row[0]
 }
@@ -82,3 +82,30 @@ func TestNoCrash(t *testing.T) {
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 }
+
+func TestWriteHTMLPipelineGraph(t *testing.T) {
+	buf := new(bytes.Buffer)
+	WriteHTMLPipelineGraph(buf, PipelineGraphData{
+		Width:      400,
+		Height:     60,
+		NodeWidth:  100,
+		NodeHeight: 40,
+		Nodes: []PipelineGraphNodeData{
+			{X: 10, Y: 10, Kind: "receiver", Name: "receiver:otlp", Detail: "traces"},
+			{X: 200, Y: 10, Kind: "exporter", Name: "exporter:debug", Detail: "traces"},
+		},
+		Edges:      []PipelineGraphEdgeData{{X1: 110, Y1: 30, X2: 200, Y2: 30, LabelX: 155, LabelY: 26, Label: "42"}},
+		ItemCounts: true,
+	})
+	out := buf.String()
+	assert.Contains(t, out, `<line x1="110" y1="30" x2="200" y2="30"`)
+	assert.Contains(t, out, `<text x="155" y="26" text-anchor="middle" fill="#3f51b5">42</text>`)
+	assert.Contains(t, out, `<svg x="200" y="10" width="100" height="40">`)
+	assert.Contains(t, out, `fill="#c8e6c9"`)
+	assert.Contains(t, out, `>receiver:otlp</text>`)
+	assert.NotContains(t, out, "feature gate")
+
+	buf.Reset()
+	WriteHTMLPipelineGraph(buf, PipelineGraphData{})
+	assert.Contains(t, buf.String(), "telemetry.newPipelineTelemetry feature gate")
+}
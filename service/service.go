@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
@@ -105,6 +106,10 @@ type Service struct {
 	loggerProvider    telemetry.LoggerProvider
 	meterProvider     telemetry.MeterProvider
 	tracerProvider    telemetry.TracerProvider
+
+	// The configuration of the telemetry and extensions, which cannot be reloaded.
+	cfg               Config
+	extensionsConfigs map[component.ID]component.Config
 }
 
 // New creates a new Service, its telemetry, and Components.
@@ -122,7 +127,9 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 			BuildInfo:         set.BuildInfo,
 			AsyncErrorChannel: set.AsyncErrorChannel,
 		},
-		collectorConf: set.CollectorConf,
+		collectorConf:     set.CollectorConf,
+		cfg:               cfg,
+		extensionsConfigs: set.ExtensionsConfigs,
 	}
 
 	telemetryFactory := otelconftelemetry.NewFactory()
@@ -304,6 +311,48 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// Reload updates the pipelines of the running service to the configuration of set and cfg, restarting only the
+// components whose configuration changed, or whose consumers changed, and leaving the others running.
+//
+// The telemetry and the extensions cannot be reloaded: Reload returns an error if their configuration changed.
+// If Reload fails, Shutdown should be called to ensure a clean state, and a new service should be created.
+func (srv *Service) Reload(ctx context.Context, set Settings, cfg Config) error {
+	if !reflect.DeepEqual(cfg.Telemetry, srv.cfg.Telemetry) {
+		return errors.New("the telemetry configuration changed")
+	}
+	if !reflect.DeepEqual(cfg.Extensions, srv.cfg.Extensions) || !reflect.DeepEqual(set.ExtensionsConfigs, srv.extensionsConfigs) {
+		return errors.New("the extensions configuration changed")
+	}
+
+	receivers := builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories)
+	processors := builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories)
+	exporters := builders.NewExporter(set.ExportersConfigs, set.ExportersFactories)
+	connectors := builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories)
+	if err := srv.host.Pipelines.Reload(ctx, graph.Settings{
+		Telemetry:        srv.telemetrySettings,
+		BuildInfo:        srv.buildInfo,
+		ReceiverBuilder:  receivers,
+		ProcessorBuilder: processors,
+		ExporterBuilder:  exporters,
+		ConnectorBuilder: connectors,
+		PipelineConfigs:  cfg.Pipelines,
+		Shutdown:         cfg.Shutdown,
+		ReportStatus:     srv.host.Reporter.ReportStatus,
+	}, srv.host); err != nil {
+		return fmt.Errorf("failed to reload pipelines: %w", err)
+	}
+	srv.host.Receivers, srv.host.Processors, srv.host.Exporters, srv.host.Connectors = receivers, processors, exporters, connectors
+	srv.cfg.Pipelines, srv.cfg.Shutdown = cfg.Pipelines, cfg.Shutdown
+
+	if set.CollectorConf != nil {
+		srv.collectorConf = set.CollectorConf
+		if err := srv.host.ServiceExtensions.NotifyConfig(ctx, srv.collectorConf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Creates extensions.
 func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) error {
 	var err error
@@ -329,6 +378,7 @@ func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
 		ExporterBuilder:  srv.host.Exporters,
 		ConnectorBuilder: srv.host.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
+		Shutdown:         cfg.Shutdown,
 		ReportStatus:     srv.host.Reporter.ReportStatus,
 	}); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
@@ -492,6 +542,7 @@ func Validate(ctx context.Context, set Settings, cfg Config) error {
 		ExporterBuilder:  builders.NewExporter(set.ExportersConfigs, set.ExportersFactories),
 		ConnectorBuilder: builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories),
 		PipelineConfigs:  cfg.Pipelines,
+		Shutdown:         cfg.Shutdown,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
@@ -257,6 +257,46 @@ func TestServiceGetExporters(t *testing.T) {
 	assert.Contains(t, expMap[xpipeline.SignalProfiles], component.NewID(nopType))
 }
 
+func TestServiceReload(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	set := newNopSettings()
+	set.CollectorConf = confmap.NewFromStringMap(map[string]any{"reloaded": true})
+	cfg := newNopConfig()
+	delete(cfg.Pipelines, pipeline.NewID(xpipeline.SignalProfiles))
+	require.NoError(t, srv.Reload(context.Background(), set, cfg))
+
+	//nolint:staticcheck
+	expMap := srv.host.GetExporters()
+	assert.Len(t, expMap[pipeline.SignalTraces], 1)
+	assert.Empty(t, expMap[xpipeline.SignalProfiles])
+	assert.Equal(t, set.CollectorConf, srv.collectorConf)
+}
+
+func TestServiceReloadNotSupported(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.Level = configtelemetry.LevelDetailed
+	require.EqualError(t, srv.Reload(context.Background(), newNopSettings(), cfg), "the telemetry configuration changed")
+
+	cfg = newNopConfig()
+	cfg.Extensions = nil
+	require.EqualError(t, srv.Reload(context.Background(), newNopSettings(), cfg), "the extensions configuration changed")
+}
+
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
 func TestServiceTelemetryCleanupOnError(t *testing.T) {
@@ -448,6 +488,8 @@ func zpagesHealthy(zpagesAddr string) bool {
 	paths := []string{
 		"/debug/tracez",
 		"/debug/pipelinez",
+		"/debug/pipelinegraphz",
+		"/debug/deadletterz",
 		"/debug/servicez",
 		"/debug/extensionz",
 	}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shutdown // import "go.opentelemetry.io/collector/service/shutdown"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/exporter/xexporter"
+)
+
+// Config defines how the pipelines are shut down.
+type Config struct {
+	// DrainTimeout is the maximum duration to wait for the exporters to drain their queues, once their upstream
+	// components are shut down, and before they are shut down. The whole drain phase shares this timeout.
+	// Zero disables the drain phase.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout,omitempty"`
+
+	// Queue defines what the exporters do with the data left in their memory queue when drained:
+	// "flush" exports it, "spill" writes it to persistent storage to be exported after the next start, which the
+	// exporters must support.
+	Queue xexporter.DrainMode `mapstructure:"queue,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultConfig returns the default shutdown configuration, which does not drain the exporters.
+func NewDefaultConfig() Config {
+	return Config{
+		Queue: xexporter.DrainModeFlush,
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.DrainTimeout < 0 {
+		return errors.New("drain_timeout must not be negative")
+	}
+	return nil
+}
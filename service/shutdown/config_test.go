@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shutdown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+)
+
+func TestConfigUnmarshal(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"drain_timeout": "10s",
+		"queue":         "spill",
+	}).Unmarshal(&cfg))
+	assert.Equal(t, 10*time.Second, cfg.DrainTimeout)
+	assert.Equal(t, xexporter.DrainModeSpill, cfg.Queue)
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = NewDefaultConfig()
+	require.ErrorContains(t, confmap.NewFromStringMap(map[string]any{
+		"queue": "drop",
+	}).Unmarshal(&cfg), `invalid drain mode "drop"`)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.DrainTimeout = -time.Second
+	require.EqualError(t, xconfmap.Validate(cfg), "drain_timeout must not be negative")
+}